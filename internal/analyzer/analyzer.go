@@ -2,11 +2,20 @@
 package analyzer
 
 import (
-	"database/sql"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
-	"lottopredictor/internal/common"
+	"lottopredictor/internal/audit"
+	"lottopredictor/internal/bayes"
 	"lottopredictor/internal/config"
-	"math/rand"
+	"lottopredictor/internal/constraint"
+	"lottopredictor/internal/cooccur"
+	"lottopredictor/internal/game"
+	"lottopredictor/internal/portfolio"
+	"lottopredictor/internal/recency"
+	"lottopredictor/internal/util"
 	"sort"
 
 	"lottopredictor/internal/db"
@@ -14,89 +23,186 @@ import (
 
 // PredictionResult 구조체는 분석 결과 + 추천 번호 세트를 포함한다.
 type PredictionResult struct {
-	DrawNumber     int
-	Probabilities  map[int]float64
-	Gaps           map[int]int
-	TopFrequent    []int
-	LeastFrequent  []int
-	RecentMissing  []int
-	FreqInLast10   []int
-	SuggestionSets [][]int
-	Percentage     []float64
-	Ranks          []int
+	Game           string          `json:"game,omitempty"` // 게임 id (비어 있으면 로또 6/45)
+	DrawNumber     int             `json:"draw_number"`
+	Probabilities  map[int]float64 `json:"probabilities"`
+	Gaps           map[int]int     `json:"gaps"`
+	TopFrequent    []int           `json:"top_frequent"`
+	LeastFrequent  []int           `json:"least_frequent"`
+	RecentMissing  []int           `json:"recent_missing"`
+	FreqInLast10   []int           `json:"freq_in_last10"`
+	SuggestionSets [][]int         `json:"suggestion_sets"`
+	BonusSets      [][]int         `json:"bonus_sets,omitempty"` // 세트별 보너스 풀 번호 (보너스 풀이 있는 게임만)
+	Percentage     []float64       `json:"percentage"`
+	Ranks          []int           `json:"ranks"`
+	Strategy       string          `json:"strategy"`       // 추천 세트를 만든 전략 이름
+	Seed           int64           `json:"seed,omitempty"` // 추천 세트 난수 시드 (replay 명령으로 같은 세트를 다시 만든다)
+	Scores         map[int]float64 `json:"scores"`         // 전략이 계산한 번호별 점수
+	Jackpots       []JackpotPoint  `json:"jackpots"`       // 최근 회차 판매액 / 1등 당첨금 추이
+	ExpectedValue  float64         `json:"expected_value"` // 추천 세트 1개(1게임)의 기대 당첨금
+
+	Violations [][]constraint.Violation `json:"violations,omitempty"` // 세트별로 어긴 조건 (모든 세트가 조건을 지키면 비어 있음)
+	Portfolio  *portfolio.Summary       `json:"portfolio,omitempty"`  // 포트폴리오 최적화로 고른 경우 세트 묶음의 적중 확률
+	Audit      *audit.Report            `json:"audit,omitempty"`      // 당첨 이력 무작위성 검정
+
+	Cooccurrence *cooccur.Analysis `json:"cooccurrence,omitempty"` // 번호 쌍/세 번호 동시 출현
+	Bayes        *bayes.Result     `json:"bayes,omitempty"`        // 번호별 베이즈 사후 평균, 95% 신용구간
+	Recency      *recency.Model    `json:"recency,omitempty"`      // 시간 감쇠/기간별 출현 확률
 }
 
-func Analyze(dbConn *sql.DB) *PredictionResult {
-	rows, _ := dbConn.Query("SELECT draw_number, n1, n2, n3, n4, n5, n6 FROM lotto_results ORDER BY draw_number")
-	defer rows.Close()
+// Analyze DB 최신 회차까지의 이력으로 다음 회차를 예측/저장한다. (AnalyzeWithDrawNumber와 같은 통계와 저장 과정)
+func Analyze(ctx context.Context, store *db.Store) (*PredictionResult, error) {
+	latestDraw, err := store.LatestDrawNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("최신 회차 조회 실패: %w", err)
+	}
+	return AnalyzeWithDrawNumber(ctx, store, latestDraw)
+}
 
-	totalDraws := 0
-	latestDraw := 0
-	draws := make(map[int][]int)
-	count := make([]int, common.MaxLottoNum)
-	lastSeen := make([]int, common.MaxLottoNum)
-	last10freq := make([]int, common.MaxLottoNum)
-
-	for rows.Next() {
-		var drawNo int
-		var nums [6]int
-		rows.Scan(&drawNo, &nums[0], &nums[1], &nums[2], &nums[3], &nums[4], &nums[5])
-		latestDraw = drawNo
-		draws[drawNo] = nums[:]
-		totalDraws++
-		for _, n := range nums {
-			count[n-1]++
-			lastSeen[n-1] = drawNo
-			if drawNo > latestDraw-common.Lookback {
-				last10freq[n-1]++
-			}
-		}
+// maxCandidateRounds 포트폴리오 후보가 모자랄 때 후보를 다시 만드는 최대 횟수
+const maxCandidateRounds = 5
+
+// predictSets 전략으로 count개 추천 세트를 만든다.
+// 포트폴리오 최적화를 켜면 후보 세트를 더 만든 뒤 count개를 함께 고르고 묶음의 적중 확률을 계산한다.
+// 보너스 풀이 있는 게임은 세트를 다 고른 뒤 세트마다 보너스 풀 번호를 뽑는다.
+// 난수는 모두 h.Rand에서 뽑으므로 같은 시드면 같은 세트가 나온다.
+func predictSets(strategy Strategy, h *History, count int, opts *portfolio.Options) (*Prediction, *portfolio.Summary, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if !opts.Enabled() {
+		prediction := strategy.Predict(h, count)
+		prediction.Bonus = h.SampleBonus(len(prediction.Sets))
+		return prediction, nil, nil
+	}
+	if err := portfolio.Supports(h.game()); err != nil {
+		return nil, nil, err
 	}
+	prediction := strategy.Predict(h, opts.CandidateCount(count))
+	// 추천 조건이 좁아 같은 세트가 많이 나오면 서로 다른 후보가 count개가 될 때까지 더 만든다
+	for round := 1; round < maxCandidateRounds && portfolio.Distinct(prediction.Sets) < count; round++ {
+		prediction.Sets = append(prediction.Sets, strategy.Predict(h, opts.CandidateCount(count)).Sets...)
+	}
+	sets, summary, err := portfolio.Optimize(h.game(), prediction.Sets, count, prediction.Scores, opts, h.random)
+	if err != nil {
+		return nil, nil, err
+	}
+	log.Printf("[포트폴리오] %s: 후보 %d개 중 %d세트, %d개 이상 일치 1세트 이상 확률 %.3f%% (겹침 없는 상한 %.3f%%)",
+		summary.Objective, summary.Candidates, len(sets), summary.HitMatch, summary.HitProbability*100, summary.IndependentBound*100)
+	prediction.Sets = sets
+	return prediction, summary, nil
+}
 
-	probs := map[int]float64{} // probs: 등장 확률.	과거 통계 기반
-	gaps := map[int]int{}      // gaps: 최근 미등장. 안 나온 번호 우선 반영
-	for i := 0; i < common.MaxLottoNum; i++ {
-		probs[i+1] = float64(count[i]) / float64(totalDraws) * 100
-		gaps[i+1] = latestDraw - lastSeen[i]
+// RunSettings 추천 세트 생성에 쓴 설정. 예측과 함께 저장해 설정 파일이 바뀌어도 replay가 같은 조건으로 다시 만든다.
+type RunSettings struct {
+	Constraints  constraint.Rules  `json:"constraints"`
+	PairAffinity float64           `json:"pair_affinity,omitempty"`
+	Portfolio    portfolio.Options `json:"portfolio"`
+}
+
+// RunSeed 설정의 시드, 없으면 새로 만든 시드
+func RunSeed() int64 {
+	if config.AppConfig.Seed != 0 {
+		return config.AppConfig.Seed
 	}
+	return util.NewSeed()
+}
 
-	db.SaveDrawProbabilities(dbConn, latestDraw, probs)
-	db.SaveReappearanceProbabilities(dbConn, latestDraw, computeReappearance(draws, latestDraw))
+// configRules 설정 파일의 추천 세트 조건을 g 게임 번호 풀로 검증해 반환
+func configRules(g *game.Game) (*constraint.Rules, error) {
+	rules := &config.AppConfig.Constraints
+	if err := rules.For(g.Main).Validate(); err != nil {
+		return nil, fmt.Errorf("추천 조건 오류: %w", err)
+	}
+	return rules, nil
+}
 
-	top10 := topNumbers(count, 10, true)
-	least10 := topNumbers(count, 10, false)
-	missing := []int{}
-	for i := 0; i < common.MaxLottoNum; i++ {
-		if latestDraw-lastSeen[i] >= config.AppConfig.GapThreshold {
-			missing = append(missing, i+1)
+// logViolations 조건을 지키지 못한 세트를 규칙별로 남긴다.
+func logViolations(targetDraw int, violations [][]constraint.Violation) {
+	for i, vs := range violations {
+		for _, v := range vs {
+			log.Printf("[조건 위반] 회차 %d 추천 %d: %s", targetDraw, i+1, v)
 		}
 	}
-	last10Top := topNumbers(last10freq, 10, true)
+}
 
-	suggestions := [][]int{}
-	for i := 0; i < config.AppConfig.SuggestionSetCount; i++ {
-		suggestions = append(suggestions, generateWeightedSample(probs, gaps, common.SetSize))
+// saveProbabilities baseDraw 기준 번호별 등장 확률(베이즈 추정 포함), 재등장 확률, 동시 출현 스냅샷을 저장
+func saveProbabilities(ctx context.Context, store *db.Store, baseDraw int, probs map[int]float64, draws map[int][]int, co *cooccur.Analysis, posterior *bayes.Result) error {
+	size := store.Game().Main.Max
+	if err := store.SaveDrawProbabilities(ctx, baseDraw, probs); err != nil {
+		return fmt.Errorf("등장 확률 저장 실패: %w", err)
+	}
+	estimates := make([]db.NumberEstimate, len(posterior.Estimates))
+	for i, e := range posterior.Estimates {
+		estimates[i] = db.NumberEstimate{Number: e.Number, Mean: e.Mean, Lower: e.Lower, Upper: e.Upper, PAboveUniform: e.PAboveUniform}
+	}
+	if err := store.SaveDrawEstimates(ctx, baseDraw, estimates); err != nil {
+		return fmt.Errorf("베이즈 추정 저장 실패: %w", err)
+	}
+	if err := store.SaveReappearanceProbabilities(ctx, baseDraw, computeReappearance(draws, size, baseDraw)); err != nil {
+		return fmt.Errorf("재등장 확률 저장 실패: %w", err)
 	}
+	pairs := make([]db.PairCount, len(co.Pairs))
+	for i, p := range co.Pairs {
+		pairs[i] = db.PairCount{A: p.A, B: p.B, Count: p.Count, Lift: p.Lift, Z: p.Z}
+	}
+	triples := []db.TripleCount{}
+	for _, t := range append(append([]cooccur.Triple(nil), co.TopTriples...), co.BottomTriples...) {
+		triples = append(triples, db.TripleCount{Numbers: t.Numbers, Count: t.Count, Lift: t.Lift, Z: t.Z})
+	}
+	if err := store.SaveCooccurrence(ctx, baseDraw, pairs, triples); err != nil {
+		return fmt.Errorf("동시 출현 저장 실패: %w", err)
+	}
+	return nil
+}
 
-	metaIdx, err := db.InsertPredictionMeta(dbConn, latestDraw+1)
+// savePrediction 추천 세트를 전략 정보, 시드, 생성 설정과 함께 targetDraw 회차 예측으로 저장하고 meta idx를 반환
+// 전략 모델의 번호별 확률이 있으면 targetDraw-1 회차 기준 스냅샷으로 함께 저장한다.
+func savePrediction(ctx context.Context, store *db.Store, targetDraw int, strategy Strategy, prediction *Prediction, seed int64, settings *RunSettings) (int, error) {
+	encoded, err := json.Marshal(settings)
 	if err != nil {
-		log.Fatal("메타 저장 실패:", err)
+		return 0, fmt.Errorf("생성 설정 변환 실패: %w", err)
 	}
-	err = db.SavePredictionResults(dbConn, int64(latestDraw+1), metaIdx, suggestions)
+	run := &db.PredictionRun{
+		DrawNumber:     targetDraw,
+		Strategy:       strategy.Name(),
+		StrategyParams: EncodeParams(strategy),
+		Params:         EncodePredictionParams(prediction),
+		Seed:           seed,
+		Settings:       string(encoded),
+	}
+	for i, set := range prediction.Sets {
+		ps := db.PredictionSet{Numbers: set}
+		if prediction.Bonus != nil {
+			ps.Bonus = prediction.Bonus[i]
+		}
+		run.Sets = append(run.Sets, ps)
+	}
+	metaIdx, err := store.SavePredictionRun(ctx, run)
 	if err != nil {
-		log.Fatal("추천 결과 저장 실패:", err)
+		return 0, fmt.Errorf("추천 결과 저장 실패: %w", err)
 	}
+	if prediction.Probabilities != nil {
+		if err := store.SaveStrategyProbabilities(ctx, targetDraw-1, strategy.Name(), prediction.Probabilities); err != nil {
+			return 0, fmt.Errorf("전략 확률 저장 실패: %w", err)
+		}
+	}
+	return metaIdx, nil
+}
 
-	return &PredictionResult{
-		DrawNumber:     latestDraw + 1,
-		Probabilities:  probs,
-		Gaps:           gaps,
-		TopFrequent:    top10,
-		LeastFrequent:  least10,
-		RecentMissing:  missing,
-		FreqInLast10:   last10Top,
-		SuggestionSets: suggestions,
+// TopProbable 확률이 높은 순으로 n개 번호 (같으면 작은 번호 먼저)
+func TopProbable(probs map[int]float64, n int) []int {
+	nums := make([]int, 0, len(probs))
+	for num := range probs {
+		nums = append(nums, num)
 	}
+	sort.Slice(nums, func(i, j int) bool {
+		if probs[nums[i]] != probs[nums[j]] {
+			return probs[nums[i]] > probs[nums[j]]
+		}
+		return nums[i] < nums[j]
+	})
+	return nums[:min(n, len(nums))]
 }
 
 func topNumbers(arr []int, count int, descending bool) []int {
@@ -121,9 +227,9 @@ func topNumbers(arr []int, count int, descending bool) []int {
 	return res
 }
 
-func computeReappearance(draws map[int][]int, latest int) map[int]float64 {
-	total := make([]int, common.MaxLottoNum)
-	repeat := make([]int, common.MaxLottoNum)
+func computeReappearance(draws map[int][]int, size, latest int) map[int]float64 {
+	total := make([]int, size)
+	repeat := make([]int, size)
 	for i := 1; i < latest; i++ {
 		curr := draws[i]
 		next, ok := draws[i+1]
@@ -142,7 +248,7 @@ func computeReappearance(draws map[int][]int, latest int) map[int]float64 {
 		}
 	}
 	res := map[int]float64{}
-	for i := 0; i < common.MaxLottoNum; i++ {
+	for i := 0; i < size; i++ {
 		if total[i] > 0 {
 			res[i+1] = float64(repeat[i]) / float64(total[i]) * 100
 		} else {
@@ -152,182 +258,314 @@ func computeReappearance(draws map[int][]int, latest int) map[int]float64 {
 	return res
 }
 
-func generateWeightedSample(probs map[int]float64, gaps map[int]int, count int) []int {
-	selected := map[int]bool{}
-	result := []int{}
-	for len(result) < count {
-		sum := 0.0
-		weights := map[int]float64{}
-		for i := 1; i <= common.MaxLottoNum; i++ {
-			if selected[i] {
-				continue
-			}
-			gap := gaps[i]
-			// 시간 가중 평균 기반: 1 + (gap × multiplier)
-			boost := 1.0 + float64(gap)*config.AppConfig.GAPBoostMultiplier
-			w := probs[i] * boost
-			weights[i] = w
-			sum += w
-		}
-		r := randFloat() * sum
-		acc := 0.0
-		for i := 1; i <= common.MaxLottoNum; i++ {
-			if selected[i] {
-				continue
-			}
-			acc += weights[i]
-			if r < acc {
-				selected[i] = true
-				result = append(result, i)
-				break
-			}
-		}
+// generateWeightedSample 번호별 가중치 확률 × (1 + 간격 × gapBoost)로 h.Rules를 지키는 세트를 뽑는다.
+func generateWeightedSample(h *History, probs map[int]float64, gaps map[int]int, gapBoost float64) []int {
+	weights := map[int]float64{}
+	for i := 1; i <= h.game().Main.Max; i++ {
+		// 시간 가중 평균 기반: 1 + (gap × multiplier)
+		boost := 1.0 + float64(gaps[i])*gapBoost
+		weights[i] = probs[i] * boost
 	}
-	sort.Ints(result)
-	return result
-}
-
-func randFloat() float64 {
-	return float64(rand.Intn(1000000)) / 1000000.0
+	return h.Sample(weights)
 }
 
 // 1회부터 baseDraw 회차 직전까지의 확률을 구하고, 다음 회차를 예측
 // 예측 결과를 prediction_results, prediction_meta 테이블에 저장하는 테스트/시뮬레이션용 분석 함수
-func AnalyzeWithDrawNumber(dbConn *sql.DB, baseDraw int) *PredictionResult {
+func AnalyzeWithDrawNumber(ctx context.Context, store *db.Store, baseDraw int) (*PredictionResult, error) {
+	strategy, err := StrategyFromConfig()
+	if err != nil {
+		return nil, fmt.Errorf("전략 생성 실패: %w", err)
+	}
+	return AnalyzeWithStrategy(ctx, store, baseDraw, strategy)
+}
+
+// AnalyzeWithStrategy 설정 대신 주어진 전략으로 AnalyzeWithDrawNumber와 같은 예측/저장을 수행
+func AnalyzeWithStrategy(ctx context.Context, store *db.Store, baseDraw int, strategy Strategy) (*PredictionResult, error) {
 	targetDraw := baseDraw + 1
 	log.Printf("[AnalyzeWithDrawNumber] 시작 - 기준 회차: %d → 예측 대상: %d\n", baseDraw, targetDraw)
 
-	rows, _ := dbConn.Query(`
-		SELECT draw_number, n1, n2, n3, n4, n5, n6 
-		FROM lotto_results 
-		WHERE draw_number <= ? ORDER BY draw_number`, baseDraw)
-	defer rows.Close()
+	result, draws, err := computeStats(ctx, store, baseDraw)
+	if err != nil {
+		return nil, err
+	}
+	if err := addAnalyses(result, store.Game(), draws); err != nil {
+		return nil, err
+	}
+	history := NewHistory(store.Game(), baseDraw, draws)
+
+	// 확률 저장은 baseDraw 기준
+	if err := saveProbabilities(ctx, store, baseDraw, result.Probabilities, history.Draws, result.Cooccurrence, result.Bayes); err != nil {
+		return nil, err
+	}
+
+	rules, err := configRules(store.Game())
+	if err != nil {
+		return nil, err
+	}
+	settings := &RunSettings{Constraints: *rules, PairAffinity: config.AppConfig.PairAffinity, Portfolio: config.AppConfig.Portfolio}
+	seed := RunSeed()
+	history.Rules = rules
+	history.PairAffinity = settings.PairAffinity
+	history.Rand = util.NewRand(seed)
+	prediction, summary, err := predictSets(strategy, history, config.AppConfig.SuggestionSetCount, &settings.Portfolio)
+	if err != nil {
+		return nil, err
+	}
+	suggestions := prediction.Sets
+	result.Portfolio = summary
+	result.Violations = history.Violations(suggestions)
+	logViolations(targetDraw, result.Violations)
+	log.Printf("[AnalyzeWithDrawNumber] 추천 번호 생성 완료 (%s, %d 세트, 시드 %d), 저장 시작", strategy.Name(), len(suggestions), seed)
+
+	metaIdx, err := savePrediction(ctx, store, targetDraw, strategy, prediction, seed, settings)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("추천 결과 저장 성공(drawNo:%d, metaIdx:%d)", targetDraw, metaIdx)
+
+	result.SuggestionSets = suggestions
+	result.BonusSets = prediction.Bonus
+	result.Strategy = strategy.Name()
+	result.Seed = seed
+	result.Scores = prediction.Scores
+	return result, nil
+}
+
+// ComputeStats baseDraw+1 회차 기준 번호별 확률/미출현 간격 등 기본 통계만 계산한다. (예측, 저장, 검정/추정 분석 없음)
+func ComputeStats(ctx context.Context, store *db.Store, baseDraw int) (*PredictionResult, error) {
+	result, _, err := computeStats(ctx, store, baseDraw)
+	return result, err
+}
+
+// computeStats baseDraw 회차까지의 당첨 이력으로 baseDraw+1 회차 기준 기본 통계(확률, 간격, 상위 목록, 1등 추이, 기대값)를 계산한다.
+// 통계에 쓴 회차 목록도 함께 반환한다. DB에는 아무것도 저장하지 않는다.
+// 결과 파일에 표시하는 검정/추정 분석은 addAnalyses로 따로 채운다.
+func computeStats(ctx context.Context, store *db.Store, baseDraw int) (*PredictionResult, []db.Draw, error) {
+	targetDraw := baseDraw + 1
+	g := store.Game()
+
+	history, err := store.ListDraws(ctx, 1, baseDraw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("당첨 번호 조회 실패: %w", err)
+	}
 
 	totalDraws := 0
-	draws := make(map[int][]int)
-	count := make([]int, common.MaxLottoNum)
-	lastSeen := make([]int, common.MaxLottoNum)
-	last10freq := make([]int, common.MaxLottoNum)
-
-	for rows.Next() {
-		var drawNo int
-		var nums [6]int
-		rows.Scan(&drawNo, &nums[0], &nums[1], &nums[2], &nums[3], &nums[4], &nums[5])
-		draws[drawNo] = nums[:]
+	size := g.Main.Max
+	count := make([]int, size)
+	lastSeen := make([]int, size)
+	lastNFreq := make([]int, size)
+
+	for _, d := range history {
+		drawNo := d.Number
 		totalDraws++
-		for _, n := range nums {
+		for _, n := range d.Numbers {
 			count[n-1]++
 			lastSeen[n-1] = drawNo
-			if drawNo > baseDraw-config.AppConfig.LookbackRounds {
-				last10freq[n-1]++
+			if drawNo > baseDraw-lookbackRounds() {
+				lastNFreq[n-1]++
 			}
 		}
 	}
 
 	probs := map[int]float64{}
 	gaps := map[int]int{}
-	for i := 0; i < common.MaxLottoNum; i++ {
+	for i := 0; i < size; i++ {
 		probs[i+1] = float64(count[i]) / float64(totalDraws) * 100
 		gaps[i+1] = targetDraw - lastSeen[i]
 	}
 
-	// 확률 저장은 baseDraw 기준
-	db.SaveDrawProbabilities(dbConn, baseDraw, probs)
-	db.SaveReappearanceProbabilities(dbConn, baseDraw, computeReappearance(draws, baseDraw))
-
-	top10 := topNumbers(count, 10, true)
-	least10 := topNumbers(count, 10, false)
 	missing := []int{}
-	for i := 0; i < common.MaxLottoNum; i++ {
+	for i := 0; i < size; i++ {
 		if targetDraw-lastSeen[i] >= config.AppConfig.GapThreshold {
 			missing = append(missing, i+1)
 		}
 	}
-	last10Top := topNumbers(last10freq, 10, true)
 
-	suggestions := [][]int{}
-	for i := 0; i < config.AppConfig.SuggestionSetCount; i++ {
-		suggestions = append(suggestions, generateWeightedSample(probs, gaps, common.SetSize))
+	jackpots, err := jackpotTrend(ctx, store, baseDraw)
+	if err != nil {
+		return nil, nil, err
 	}
-	log.Printf("[AnalyzeWithDrawNumber] 추천 번호 생성 완료 (%d 세트), 저장 시작", len(suggestions))
+	prizes, err := PrizeTable(ctx, store, baseDraw)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &PredictionResult{
+		Game:          g.ID,
+		DrawNumber:    targetDraw,
+		Probabilities: probs,
+		Gaps:          gaps,
+		TopFrequent:   topNumbers(count, 10, true),
+		LeastFrequent: topNumbers(count, 10, false),
+		RecentMissing: missing,
+		FreqInLast10:  topNumbers(lastNFreq, 10, true),
+		Jackpots:      jackpots,
+		ExpectedValue: ExpectedValue(g, prizes),
+	}, history, nil
+}
 
-	metaIdx, err := db.InsertPredictionMeta(dbConn, targetDraw)
+// addAnalyses 무작위성 검정, 동시 출현, 베이즈 추정, 시간 감쇠 확률을 result에 채운다.
+// 세 번호 조합까지 세는 등 계산이 무거워 예측과 결과 파일 출력에서만 쓴다.
+func addAnalyses(result *PredictionResult, g *game.Game, history []db.Draw) error {
+	posterior, err := posteriorFromConfig(g, history)
 	if err != nil {
-		log.Fatal("메타 저장 실패:", err)
-	} else {
-		log.Printf("메타 저장 성공(drawNo:%d, metaIdx:%d)", targetDraw, metaIdx)
+		return err
+	}
+	recencyOpts := config.AppConfig.Recency
+	if err := recencyOpts.Validate(); err != nil {
+		return err
 	}
+	draws := drawList(history)
+	result.Audit = AuditDraws(g, history, audit.DefaultAlpha)
+	result.Cooccurrence = cooccur.Analyze(draws, g.Main, cooccur.DefaultTop)
+	result.Bayes = posterior
+	result.Recency = recency.Compute(draws, g.Main, recencyOpts)
+	return nil
+}
 
-	err = db.SavePredictionResults(dbConn, int64(targetDraw), metaIdx, suggestions)
+// lookbackRounds 최근 출현 빈도 목록에 쓰는 최근 회차 수 (설정이 없으면 10)
+func lookbackRounds() int {
+	if config.AppConfig.LookbackRounds > 0 {
+		return config.AppConfig.LookbackRounds
+	}
+	return 10
+}
+
+// posteriorFromConfig 설정 파일의 사전 분포/반감기로 g 게임 번호별 베이즈 추정
+func posteriorFromConfig(g *game.Game, history []db.Draw) (*bayes.Result, error) {
+	opts := config.AppConfig.Bayes
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	return bayes.Posterior(drawList(history), g.Main, opts), nil
+}
+
+// FittedWeights baseDraw 회차까지 이력으로 추정한 번호별 출현 확률 (베이즈 사후 평균, index = 번호-1).
+// 시뮬레이션의 fitted 추첨 모델 가중치로 쓴다.
+func FittedWeights(ctx context.Context, store *db.Store, baseDraw int) ([]float64, error) {
+	history, err := store.ListDraws(ctx, 1, baseDraw)
+	if err != nil {
+		return nil, fmt.Errorf("당첨 번호 조회 실패: %w", err)
+	}
+	posterior, err := posteriorFromConfig(store.Game(), history)
 	if err != nil {
-		log.Fatal("추천 결과 저장 실패:", err)
-	} else {
-		log.Printf("추천 결과 저장 성공(drawNo:%d, metaIdx:%d)", targetDraw, metaIdx)
+		return nil, err
 	}
+	weights := make([]float64, len(posterior.Estimates))
+	for i, e := range posterior.Estimates {
+		weights[i] = e.Mean
+	}
+	return weights, nil
+}
 
-	return &PredictionResult{
-		DrawNumber:     targetDraw,
-		Probabilities:  probs,
-		Gaps:           gaps,
-		TopFrequent:    top10,
-		LeastFrequent:  least10,
-		RecentMissing:  missing,
-		FreqInLast10:   last10Top,
-		SuggestionSets: suggestions,
+// drawList 회차 순 당첨 번호 목록
+func drawList(history []db.Draw) [][]int {
+	draws := make([][]int, len(history))
+	for i, d := range history {
+		draws[i] = d.Numbers
 	}
+	return draws
 }
 
-func LoadLastPredictionResult(dbConn *sql.DB, drawNo int) *PredictionResult {
-	result := &PredictionResult{
-		DrawNumber: drawNo,
+// AuditDraws g 게임의 회차 순 당첨 이력에 무작위성 검정을 수행한다.
+func AuditDraws(g *game.Game, history []db.Draw, alpha float64) *audit.Report {
+	from := 0
+	if len(history) > 0 {
+		from = history[0].Number
 	}
+	return audit.Run(from, drawList(history), g.Main, alpha)
+}
+
+// LoadPredictionReport drawNo 회차의 마지막 예측 세트(평가 포함)에 drawNo-1 회차까지의 통계와 검정/추정 분석을 채워 반환
+// report 명령처럼 새 예측 없이 저장된 결과를 결과 파일로 다시 출력할 때 사용한다.
+func LoadPredictionReport(ctx context.Context, store *db.Store, drawNo int) (*PredictionResult, error) {
+	return loadPrediction(ctx, store, drawNo, true)
+}
 
-	// 가장 최근 meta_idx 가져오기
-	row := dbConn.QueryRow(`
-		SELECT MAX(meta_idx)
-		FROM prediction_results
-		WHERE draw_number = ?
-	`, drawNo)
+// LoadPrediction LoadPredictionReport에서 검정/추정 분석을 뺀 결과 (기본 통계와 저장된 세트만 필요한 API 조회용)
+func LoadPrediction(ctx context.Context, store *db.Store, drawNo int) (*PredictionResult, error) {
+	return loadPrediction(ctx, store, drawNo, false)
+}
 
-	var nullMetaIdx sql.NullInt64
-	if err := row.Scan(&nullMetaIdx); err != nil {
-		log.Printf("메타 인덱스 조회 실패: %v\n", err)
-		return result
+func loadPrediction(ctx context.Context, store *db.Store, drawNo int, analyses bool) (*PredictionResult, error) {
+	result, draws, err := computeStats(ctx, store, drawNo-1)
+	if err != nil {
+		return nil, err
+	}
+	if analyses {
+		if err := addAnalyses(result, store.Game(), draws); err != nil {
+			return nil, err
+		}
+	}
+	last, run, err := loadLastPrediction(ctx, store, drawNo)
+	if err != nil {
+		return nil, err
 	}
+	// 저장된 세트는 생성할 때의 조건으로 다시 확인한다 (설정 파일이 바뀌어도 같은 위반 보고)
+	rules, err := runRules(store.Game(), run)
+	if err != nil {
+		return nil, err
+	}
+	history := NewHistory(store.Game(), drawNo-1, draws)
+	history.Rules = rules
+	result.Violations = history.Violations(last.SuggestionSets)
+	result.SuggestionSets = last.SuggestionSets
+	result.BonusSets = last.BonusSets
+	result.Percentage = last.Percentage
+	result.Ranks = last.Ranks
+	result.Strategy = last.Strategy
+	result.Seed = last.Seed
+	return result, nil
+}
 
-	metaIdx := 0
-	if nullMetaIdx.Valid {
-		metaIdx = int(nullMetaIdx.Int64)
-	} else {
-		log.Printf("draw_number %d에 대한 meta_idx 결과 없음, 0으로 처리\n", drawNo)
-		return result
+// runRules run을 만들 때 저장한 추천 조건. 생성 설정을 기록하기 전의 예측(또는 예측 없음)은 현재 설정의 조건
+func runRules(g *game.Game, run *db.PredictionRun) (*constraint.Rules, error) {
+	if run == nil || run.Settings == "" {
+		return configRules(g)
 	}
+	var settings RunSettings
+	if err := json.Unmarshal([]byte(run.Settings), &settings); err != nil {
+		return nil, fmt.Errorf("회차 %d 예측 %d 생성 설정 해석 실패: %w", run.DrawNumber, run.Idx, err)
+	}
+	return &settings.Constraints, nil
+}
 
-	// 해당 meta_idx의 추천 번호 가져오기
-	rows, err := dbConn.Query(`
-		SELECT num1, num2, num3, num4, num5, num6, percentage, rank
-		FROM prediction_results
-		WHERE draw_number = ? AND meta_idx = ?
-		ORDER BY set_index ASC
-	`, drawNo, metaIdx)
+// LoadLastPredictionResult drawNo 회차의 가장 최근 예측 세트와 평가 결과. 저장된 예측이 없으면 세트가 빈 결과를 반환
+func LoadLastPredictionResult(ctx context.Context, store *db.Store, drawNo int) (*PredictionResult, error) {
+	result, _, err := loadLastPrediction(ctx, store, drawNo)
+	return result, err
+}
+
+// loadLastPrediction LoadLastPredictionResult와 같은 결과와 그 예측 run (예측이 없으면 nil)
+func loadLastPrediction(ctx context.Context, store *db.Store, drawNo int) (*PredictionResult, *db.PredictionRun, error) {
+	result := &PredictionResult{
+		Game:       store.Game().ID,
+		DrawNumber: drawNo,
+	}
+
+	run, err := store.LatestPredictionRun(ctx, drawNo)
+	if errors.Is(err, db.ErrNotFound) {
+		log.Printf("draw_number %d에 대한 예측 결과 없음\n", drawNo)
+		return result, nil, nil
+	}
 	if err != nil {
-		log.Printf("추천번호 불러오기 실패: %v\n", err)
-		return result
+		return nil, nil, fmt.Errorf("추천번호 불러오기 실패: %w", err)
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		var n1, n2, n3, n4, n5, n6 int
-		var perc float64
-		var rank int
-		err := rows.Scan(&n1, &n2, &n3, &n4, &n5, &n6, &perc, &rank)
-		if err != nil {
-			continue
+	result.Strategy = run.Strategy
+	result.Seed = run.Seed
+	for _, set := range run.Sets {
+		result.SuggestionSets = append(result.SuggestionSets, set.Numbers)
+		if set.Bonus != nil {
+			result.BonusSets = append(result.BonusSets, set.Bonus)
+		}
+		if set.Evaluation != nil {
+			result.Percentage = append(result.Percentage, set.Evaluation.Percentage)
+			result.Ranks = append(result.Ranks, set.Evaluation.Rank)
+		} else {
+			result.Percentage = append(result.Percentage, 0)
+			result.Ranks = append(result.Ranks, 0)
 		}
-		result.SuggestionSets = append(result.SuggestionSets, []int{n1, n2, n3, n4, n5, n6})
-		result.Percentage = append(result.Percentage, perc)
-		result.Ranks = append(result.Ranks, rank)
 	}
-
-	return result
+	return result, run, nil
 }
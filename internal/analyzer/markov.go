@@ -0,0 +1,215 @@
+// internal/analyzer/markov.go
+package analyzer
+
+import (
+	"math"
+	"sort"
+
+	"lottopredictor/internal/game"
+)
+
+// markov 전략 기본 파라미터
+const (
+	defaultMarkovMaxOrder   = 3
+	defaultMarkovSmoothing  = 10
+	defaultMarkovValidation = 100
+)
+
+// markovStrategy 직전 회차(차수 k면 직전 k개 회차)의 당첨 번호로 다음 회차 번호별 출현 확률을 예측하는 전이 모델 전략.
+//
+// 시차 L마다 조건부 행렬 M_L[i][j] = P(j가 나옴 | L회 전에 i가 나옴)을 세고,
+// (횟수 + smoothing × j의 전체 출현율) / (i 출현 횟수 + smoothing)으로 평활화한다.
+// 다음 회차 확률은 시차별로 그 회차 번호 6개 행의 평균을 구한 뒤 시차끼리 평균한다. 차수 0은 전체 출현율만 쓴다.
+// order가 0이면 0 ~ max_order 중 최근 validation 회차를 한 회차씩 전진하며 예측한 로그 우도가 가장 높은 차수를 고른다.
+type markovStrategy struct {
+	order      int
+	maxOrder   int
+	smoothing  float64
+	validation int
+}
+
+func newMarkovStrategy(params map[string]float64) Strategy {
+	return &markovStrategy{
+		order:      int(paramOr(params, "order", 0)),
+		maxOrder:   max(1, int(paramOr(params, "max_order", defaultMarkovMaxOrder))),
+		smoothing:  max(0.01, paramOr(params, "smoothing", defaultMarkovSmoothing)),
+		validation: max(1, int(paramOr(params, "validation", defaultMarkovValidation))),
+	}
+}
+
+func (s *markovStrategy) Name() string { return "markov" }
+
+func (s *markovStrategy) Params() map[string]float64 {
+	return map[string]float64{
+		"order":      float64(s.order),
+		"max_order":  float64(s.maxOrder),
+		"smoothing":  s.smoothing,
+		"validation": float64(s.validation),
+	}
+}
+
+func (s *markovStrategy) Predict(h *History, count int) *Prediction {
+	seq := h.Sequence()
+	pool := h.game().Main
+	order := s.order
+	if order <= 0 {
+		order, _ = selectMarkovOrder(seq, pool, s.maxOrder, s.smoothing, s.validation)
+	}
+
+	m := newMarkovModel(pool, order, s.smoothing)
+	for t := range seq {
+		m.add(seq, t)
+	}
+	next := m.predict(seq, len(seq))
+
+	probs := map[int]float64{}
+	scores := map[int]float64{}
+	for n := 1; n <= pool.Max; n++ {
+		probs[n] = next[n] * 100
+		scores[n] = next[n]
+	}
+	sets := [][]int{}
+	for i := 0; i < count; i++ {
+		sets = append(sets, generateWeightedSample(h, probs, nil, 0))
+	}
+	// 실제로 쓴 차수는 예측마다 다를 수 있어 전략 파라미터 대신 예측 결과로 남긴다
+	params := map[string]float64{"selected_order": float64(order)}
+	return &Prediction{Sets: sets, Scores: scores, Probabilities: probs, Params: params}
+}
+
+// selectMarkovOrder 0 ~ maxOrder 차수 모델을 함께 전진시키며 마지막 validation 회차의 로그 우도 합을 비교한다.
+// 우도가 같으면 낮은 차수를 고른다. 반환값은 (차수, 차수별 로그 우도)
+func selectMarkovOrder(seq [][]int, pool game.Pool, maxOrder int, smoothing float64, validation int) (int, []float64) {
+	models := make([]*markovModel, maxOrder+1)
+	for k := range models {
+		models[k] = newMarkovModel(pool, k, smoothing)
+	}
+	ll := make([]float64, maxOrder+1)
+	start := max(maxOrder, len(seq)-validation)
+	for t := range seq {
+		if t >= start {
+			for k, m := range models {
+				ll[k] += logLikelihood(m.predict(seq, t), seq[t])
+			}
+		}
+		for _, m := range models {
+			m.add(seq, t)
+		}
+	}
+	best := 0
+	for k := range ll {
+		if ll[k] > ll[best]+1e-9 {
+			best = k
+		}
+	}
+	return best, ll
+}
+
+// markovModel 시차 1 ~ order의 전이 횟수와 번호별 전체 출현 횟수 (index = 번호, 0은 비워 둔다)
+type markovModel struct {
+	order     int
+	smoothing float64
+	pool      game.Pool
+	size      int         // 최대 번호 + 1
+	pair      [][]float64 // [시차-1][i*size+j]
+	from      [][]float64 // [시차-1][i] 전이 출발 횟수
+	freq      []float64
+	draws     float64
+}
+
+func newMarkovModel(pool game.Pool, order int, smoothing float64) *markovModel {
+	size := pool.Max + 1
+	m := &markovModel{
+		order:     order,
+		smoothing: smoothing,
+		pool:      pool,
+		size:      size,
+		pair:      make([][]float64, order),
+		from:      make([][]float64, order),
+		freq:      make([]float64, size),
+	}
+	for lag := range order {
+		m.pair[lag] = make([]float64, size*size)
+		m.from[lag] = make([]float64, size)
+	}
+	return m
+}
+
+// add seq[t] 회차를 모델에 추가 (seq[t-L] → seq[t] 전이 포함)
+func (m *markovModel) add(seq [][]int, t int) {
+	for lag := 1; lag <= m.order && lag <= t; lag++ {
+		for _, i := range seq[t-lag] {
+			m.from[lag-1][i]++
+			for _, j := range seq[t] {
+				m.pair[lag-1][i*m.size+j]++
+			}
+		}
+	}
+	for _, j := range seq[t] {
+		m.freq[j]++
+	}
+	m.draws++
+}
+
+// base 평활화한 번호별 전체 출현율 (이력이 없으면 세트 크기 / 풀 크기, 6/45)
+func (m *markovModel) base(j int) float64 {
+	prior := float64(m.pool.Picks) / float64(m.pool.Max)
+	return (m.freq[j] + m.smoothing*prior) / (m.draws + m.smoothing)
+}
+
+// predict seq[t] 회차(t == len(seq)이면 다음 회차) 번호별 출현 확률 (index = 번호, 합은 약 세트 크기)
+func (m *markovModel) predict(seq [][]int, t int) []float64 {
+	p := make([]float64, m.size)
+	lags := 0
+	for lag := 1; lag <= m.order && lag <= t; lag++ {
+		lags++
+		prev := seq[t-lag]
+		for j := 1; j <= m.pool.Max; j++ {
+			sum := 0.0
+			for _, i := range prev {
+				sum += (m.pair[lag-1][i*m.size+j] + m.smoothing*m.base(j)) / (m.from[lag-1][i] + m.smoothing)
+			}
+			p[j] += sum / float64(len(prev))
+		}
+	}
+	for j := 1; j <= m.pool.Max; j++ {
+		if lags == 0 {
+			p[j] = m.base(j)
+		} else {
+			p[j] /= float64(lags)
+		}
+		p[j] = min(max(p[j], 1e-6), 1-1e-6)
+	}
+	return p
+}
+
+// logLikelihood 번호마다 나옴/안 나옴을 베르누이로 본 당첨 번호의 로그 우도
+func logLikelihood(p []float64, draw []int) float64 {
+	in := make([]bool, len(p))
+	for _, n := range draw {
+		in[n] = true
+	}
+	ll := 0.0
+	for j := 1; j < len(p); j++ {
+		if in[j] {
+			ll += math.Log(p[j])
+		} else {
+			ll += math.Log(1 - p[j])
+		}
+	}
+	return ll
+}
+
+// Sequence 회차 순 당첨 번호 목록
+func (h *History) Sequence() [][]int {
+	nums := make([]int, 0, len(h.Draws))
+	for drawNo := range h.Draws {
+		nums = append(nums, drawNo)
+	}
+	sort.Ints(nums)
+	seq := make([][]int, len(nums))
+	for i, drawNo := range nums {
+		seq[i] = h.Draws[drawNo]
+	}
+	return seq
+}
@@ -0,0 +1,73 @@
+// internal/analyzer/prize.go
+package analyzer
+
+import (
+	"context"
+	"fmt"
+
+	"lottopredictor/internal/common"
+	"lottopredictor/internal/config"
+	"lottopredictor/internal/db"
+	"lottopredictor/internal/game"
+)
+
+// JackpotPoint 회차별 판매액 / 1등 당첨 정보 (당첨금 추이 표시용)
+type JackpotPoint struct {
+	DrawNumber      int    `json:"draw_number"`
+	Date            string `json:"date"`
+	TotalSales      int64  `json:"total_sales"`
+	Winners         int    `json:"winners"`
+	PrizePerWinner  int64  `json:"prize_per_winner"`
+	TotalFirstPrize int64  `json:"total_first_prize"`
+}
+
+// jackpotTrendSize 리포트에 표시하는 최근 회차 수
+const jackpotTrendSize = 10
+
+// jackpotTrend baseDraw 회차까지 최근 jackpotTrendSize 회차의 당첨금 정보 (판매액 자료가 없는 회차는 제외)
+func jackpotTrend(ctx context.Context, store *db.Store, baseDraw int) ([]JackpotPoint, error) {
+	draws, err := store.ListDraws(ctx, baseDraw-jackpotTrendSize+1, baseDraw)
+	if err != nil {
+		return nil, fmt.Errorf("당첨금 추이 조회 실패: %w", err)
+	}
+	points := []JackpotPoint{}
+	for _, d := range draws {
+		if d.TotalSales == 0 {
+			continue
+		}
+		points = append(points, JackpotPoint{
+			DrawNumber:      d.Number,
+			Date:            d.Date,
+			TotalSales:      d.TotalSales,
+			Winners:         d.FirstWinners,
+			PrizePerWinner:  d.FirstPrize,
+			TotalFirstPrize: d.FirstTotal,
+		})
+	}
+	return points, nil
+}
+
+// PrizeTable 기대값 계산에 쓰는 DB 게임의 등수별 당첨금.
+// 1등은 설정에 값이 없으면 baseDraw까지의 1인당 평균 1등 당첨금(자료가 있을 때)을 사용한다.
+func PrizeTable(ctx context.Context, store *db.Store, baseDraw int) (map[int]int64, error) {
+	g := store.Game()
+	prizes := map[int]int64{}
+	for rank := 1; rank <= g.Ranks(); rank++ {
+		prizes[rank] = config.PrizeAmount(g, rank)
+	}
+	if _, ok := config.AppConfig.Prizes[g.ID][common.RankFirst]; !ok {
+		avg, err := store.AverageFirstPrize(ctx, baseDraw)
+		if err != nil {
+			return nil, fmt.Errorf("평균 1등 당첨금 조회 실패: %w", err)
+		}
+		if avg > 0 {
+			prizes[common.RankFirst] = avg
+		}
+	}
+	return prizes, nil
+}
+
+// ExpectedValue g 게임 1게임의 기대 당첨금. 추첨이 균등하다면 어떤 번호 조합이든 같은 값이다.
+func ExpectedValue(g *game.Game, prizes map[int]int64) float64 {
+	return g.ExpectedValue(prizes)
+}
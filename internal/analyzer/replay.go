@@ -0,0 +1,65 @@
+// internal/analyzer/replay.go
+package analyzer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"lottopredictor/internal/db"
+	"lottopredictor/internal/util"
+)
+
+// Replay 저장된 예측의 결과
+type Replay struct {
+	Run       *db.PredictionRun
+	Sets      [][]int // 저장된 전략/파라미터/시드/생성 설정으로 다시 만든 세트
+	Bonus     [][]int // 다시 만든 세트별 보너스 풀 번호 (보너스 풀이 있는 게임만)
+	Identical bool    // 저장된 세트(보너스 풀 번호 포함)와 순서까지 같은지
+}
+
+// ReplayRun 저장된 예측 run을 같은 전략, 파라미터, 시드, 생성 설정으로 다시 만들어 저장된 세트와 비교한다.
+// 이력은 run.DrawNumber-1 회차까지만 쓰고 DB에는 아무것도 저장하지 않는다.
+func ReplayRun(ctx context.Context, store *db.Store, run *db.PredictionRun) (*Replay, error) {
+	if run.Seed == 0 || run.Settings == "" {
+		return nil, fmt.Errorf("회차 %d 예측 %d: 시드가 기록되지 않은 예측이라 다시 만들 수 없음", run.DrawNumber, run.Idx)
+	}
+	params := map[string]float64{}
+	if run.StrategyParams != "" {
+		if err := json.Unmarshal([]byte(run.StrategyParams), &params); err != nil {
+			return nil, fmt.Errorf("전략 파라미터 해석 실패: %w", err)
+		}
+	}
+	strategy, err := NewStrategy(run.Strategy, params)
+	if err != nil {
+		return nil, err
+	}
+	var settings RunSettings
+	if err := json.Unmarshal([]byte(run.Settings), &settings); err != nil {
+		return nil, fmt.Errorf("생성 설정 해석 실패: %w", err)
+	}
+
+	baseDraw := run.DrawNumber - 1
+	history, err := store.ListDraws(ctx, 1, baseDraw)
+	if err != nil {
+		return nil, fmt.Errorf("당첨 번호 조회 실패: %w", err)
+	}
+	h := NewHistory(store.Game(), baseDraw, history)
+	h.Rules = &settings.Constraints
+	h.PairAffinity = settings.PairAffinity
+	h.Rand = util.NewRand(run.Seed)
+	prediction, _, err := predictSets(strategy, h, len(run.Sets), &settings.Portfolio)
+	if err != nil {
+		return nil, err
+	}
+
+	identical := len(prediction.Sets) == len(run.Sets)
+	for i := 0; identical && i < len(run.Sets); i++ {
+		identical = slices.Equal(prediction.Sets[i], run.Sets[i].Numbers)
+		if identical && prediction.Bonus != nil {
+			identical = slices.Equal(prediction.Bonus[i], run.Sets[i].Bonus)
+		}
+	}
+	return &Replay{Run: run, Sets: prediction.Sets, Bonus: prediction.Bonus, Identical: identical}, nil
+}
@@ -0,0 +1,347 @@
+// internal/analyzer/strategy.go
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"lottopredictor/internal/config"
+	"lottopredictor/internal/constraint"
+	"lottopredictor/internal/cooccur"
+	"lottopredictor/internal/db"
+	"lottopredictor/internal/game"
+	"lottopredictor/internal/recency"
+	"lottopredictor/internal/util"
+)
+
+// DefaultStrategy 설정에 전략이 없을 때 사용하는 기본 전략 이름
+const DefaultStrategy = "frequency_gap"
+
+// History 예측에 사용할 baseDraw 회차까지의 당첨 이력 (회차 → 당첨 번호, 로또 6/45는 6개)
+type History struct {
+	BaseDraw int
+	Draws    map[int][]int
+	// Game 이력의 게임 (nil이면 로또 6/45)
+	Game *game.Game
+	// Bonus 회차 → 보너스 풀 당첨 번호 (보너스 풀이 있는 게임만)
+	Bonus map[int][]int
+	Rules *constraint.Rules // 추천 세트가 지켜야 할 조건 (nil이면 없음)
+	// PairAffinity 번호 쌍 동시 출현 반영 강도 (0이면 사용하지 않음, 양수면 함께 자주 나온 번호 선호)
+	PairAffinity float64
+	// Rand 세트 추첨에 쓰는 난수. 같은 시드의 Rand를 주면 같은 세트가 나온다 (nil이면 새 시드로 만든다)
+	Rand *rand.Rand
+
+	pastKeys  map[string]bool // PastWinner용 1등 조합 캐시
+	pastDraws int             // 캐시를 만들 때의 Draws 개수
+	pairs     *cooccur.Matrix // Pairs 캐시
+}
+
+// NewHistory baseDraw 회차까지의 당첨 번호(draws)로 g 게임 이력을 만든다.
+func NewHistory(g *game.Game, baseDraw int, draws []db.Draw) *History {
+	h := &History{BaseDraw: baseDraw, Draws: make(map[int][]int, len(draws)), Game: g}
+	for _, d := range draws {
+		h.Add(d)
+	}
+	return h
+}
+
+// Add 회차 하나를 이력에 추가한다. (백테스트는 회차를 하나씩 추가한다)
+func (h *History) Add(d db.Draw) {
+	h.Draws[d.Number] = d.Numbers
+	if len(d.BonusNumbers) > 0 {
+		if h.Bonus == nil {
+			h.Bonus = map[int][]int{}
+		}
+		h.Bonus[d.Number] = d.BonusNumbers
+	}
+}
+
+// game 이력의 게임 (nil이면 로또 6/45)
+func (h *History) game() *game.Game {
+	if h.Game == nil {
+		return game.Default
+	}
+	return h.Game
+}
+
+// rules h.Rules를 게임 본 번호 풀에 적용한 조건
+func (h *History) rules() *constraint.Rules {
+	return h.Rules.For(h.game().Main)
+}
+
+// PastWinner nums가 이력 중 어느 회차의 1등 번호 조합과 같은지
+func (h *History) PastWinner(nums []int) bool {
+	// 백테스트는 같은 History에 회차를 하나씩 추가하므로 개수로 캐시를 확인한다
+	if h.pastKeys == nil || h.pastDraws != len(h.Draws) {
+		h.pastKeys = make(map[string]bool, len(h.Draws))
+		h.pastDraws = len(h.Draws)
+		for _, winning := range h.Draws {
+			h.pastKeys[constraint.Key(winning)] = true
+		}
+	}
+	return h.pastKeys[constraint.Key(nums)]
+}
+
+// Pairs 이력의 번호 쌍 동시 출현 행렬
+func (h *History) Pairs() *cooccur.Matrix {
+	if h.pairs == nil || h.pairs.Draws != len(h.Draws) {
+		draws := make([][]int, 0, len(h.Draws))
+		for _, nums := range h.Draws {
+			draws = append(draws, nums)
+		}
+		h.pairs = cooccur.NewMatrix(draws, h.game().Main)
+	}
+	return h.pairs
+}
+
+// Sample 번호별 가중치로 h.Rules를 지키는 세트 하나를 뽑는다. (내장 전략 공통)
+// PairAffinity가 있으면 이미 뽑은 번호와 함께 나온 정도를 가중치에 곱한다.
+func (h *History) Sample(weights map[int]float64) []int {
+	var affinity constraint.Affinity
+	if h.PairAffinity != 0 {
+		pairs := h.Pairs()
+		affinity = func(chosen []int, n int) float64 { return pairs.Affinity(chosen, n, h.PairAffinity) }
+	}
+	set, _ := h.rules().SampleAffinity(weights, affinity, h.random, h.PastWinner)
+	return set
+}
+
+// SampleBonus 보너스 풀이 있는 게임에서 세트 count개의 보너스 풀 번호를 뽑는다. (보너스 풀이 없으면 nil)
+// 전략과 관계없이 이력의 보너스 풀 번호별 출현 횟수 + 1에 비례해 뽑는다.
+func (h *History) SampleBonus(count int) [][]int {
+	pool := h.game().Bonus
+	if pool.Picks == 0 {
+		return nil
+	}
+	weights := map[int]float64{}
+	for n := 1; n <= pool.Max; n++ {
+		weights[n] = 1
+	}
+	for _, nums := range h.Bonus {
+		for _, n := range nums {
+			weights[n]++
+		}
+	}
+	rules := (&constraint.Rules{}).For(pool)
+	sets := make([][]int, count)
+	for i := range sets {
+		sets[i], _ = rules.Sample(weights, h.random, nil)
+	}
+	return sets
+}
+
+// random h.Rand의 [0, 1) 난수
+func (h *History) random() float64 {
+	if h.Rand == nil {
+		h.Rand = util.NewRand(util.NewSeed())
+	}
+	return h.Rand.Float64()
+}
+
+// Violations 세트별로 h.Rules에서 어긴 규칙. 모든 세트가 규칙을 지키면 nil
+func (h *History) Violations(sets [][]int) [][]constraint.Violation {
+	if h.Rules.IsZero() {
+		return nil
+	}
+	all := make([][]constraint.Violation, len(sets))
+	found := false
+	for i, set := range sets {
+		all[i] = h.rules().Check(set, h.PastWinner)
+		found = found || len(all[i]) > 0
+	}
+	if !found {
+		return nil
+	}
+	return all
+}
+
+// Frequencies 번호별 등장 횟수 (index = 번호-1)
+func (h *History) Frequencies() []int {
+	count := make([]int, h.game().Main.Max)
+	for _, nums := range h.Draws {
+		for _, n := range nums {
+			count[n-1]++
+		}
+	}
+	return count
+}
+
+// LastSeen 번호별 마지막 등장 회차 (index = 번호-1, 미등장 0)
+func (h *History) LastSeen() []int {
+	lastSeen := make([]int, h.game().Main.Max)
+	for drawNo, nums := range h.Draws {
+		for _, n := range nums {
+			if drawNo > lastSeen[n-1] {
+				lastSeen[n-1] = drawNo
+			}
+		}
+	}
+	return lastSeen
+}
+
+// Prediction 전략이 만든 추천 세트와 번호별 점수
+type Prediction struct {
+	Sets   [][]int
+	Bonus  [][]int // 세트별 보너스 풀 번호 (보너스 풀이 있는 게임만, 전략 대신 predictSets가 채운다)
+	Scores map[int]float64
+	// Probabilities 전략 모델이 예측한 다음 회차 번호별 출현 확률 (%). 있으면 strategy_probabilities에 저장된다.
+	Probabilities map[int]float64
+	// Params 이 예측에서 전략이 정한 값 (markov의 selected_order 등). 있으면 예측/백테스트 결과와 함께 저장된다.
+	Params map[string]float64
+}
+
+// Strategy 당첨 이력으로 다음 회차 추천 세트를 만드는 예측 전략
+type Strategy interface {
+	Name() string
+	// Params prediction_meta에 함께 저장되는 전략 파라미터 (기본값 적용 후).
+	// 설정만 반환하고, 예측마다 달라지는 값은 Prediction.Params로 돌려준다.
+	Params() map[string]float64
+	// Predict h.BaseDraw 회차까지의 이력만 보고 count개의 추천 세트를 만든다.
+	Predict(h *History, count int) *Prediction
+}
+
+// StrategyFactory 파라미터로 전략을 생성한다. 없는 파라미터는 기본값으로 채운다.
+type StrategyFactory func(params map[string]float64) Strategy
+
+var strategies = map[string]StrategyFactory{}
+
+// RegisterStrategy 전략을 이름으로 등록. 같은 이름을 두 번 등록하면 panic
+func RegisterStrategy(name string, factory StrategyFactory) {
+	if _, ok := strategies[name]; ok {
+		panic("RegisterStrategy: 중복 등록 " + name)
+	}
+	strategies[name] = factory
+}
+
+// NewStrategy 등록된 전략을 이름으로 생성
+func NewStrategy(name string, params map[string]float64) (Strategy, error) {
+	factory, ok := strategies[name]
+	if !ok {
+		return nil, fmt.Errorf("알 수 없는 전략 %q (사용 가능: %v)", name, StrategyNames())
+	}
+	return factory(params), nil
+}
+
+// StrategyNames 등록된 전략 이름 목록 (정렬)
+func StrategyNames() []string {
+	names := make([]string, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// StrategyFromConfig config.AppConfig의 strategy / strategy_params로 전략 생성
+func StrategyFromConfig() (Strategy, error) {
+	name := config.AppConfig.Strategy
+	if name == "" {
+		name = DefaultStrategy
+	}
+	return NewStrategy(name, config.AppConfig.StrategyParams)
+}
+
+// EncodeParams 전략 파라미터를 DB 저장용 JSON 문자열로 변환 (키 정렬)
+func EncodeParams(s Strategy) string {
+	b, err := json.Marshal(s.Params())
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
+
+// EncodePredictionParams 예측별 전략 값을 DB 저장용 JSON 문자열로 변환 (없으면 빈 문자열)
+func EncodePredictionParams(p *Prediction) string {
+	if len(p.Params) == 0 {
+		return ""
+	}
+	b, err := json.Marshal(p.Params)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// paramOr params[key]가 있으면 그 값, 없으면 def
+func paramOr(params map[string]float64, key string, def float64) float64 {
+	if v, ok := params[key]; ok {
+		return v
+	}
+	return def
+}
+
+func init() {
+	RegisterStrategy(DefaultStrategy, newFrequencyGapStrategy)
+	RegisterStrategy("uniform", newUniformStrategy)
+	RegisterStrategy("markov", newMarkovStrategy)
+}
+
+// frequencyGapStrategy 등장 확률 × (1 + 미등장 간격 × 배수) 가중치로 샘플링하는 기본 전략.
+// half_life가 있으면 등장 확률을 최근 회차일수록 크게 센 시간 감쇠 확률로 바꾼다.
+type frequencyGapStrategy struct {
+	gapBoost float64
+	halfLife float64
+}
+
+func newFrequencyGapStrategy(params map[string]float64) Strategy {
+	return &frequencyGapStrategy{
+		gapBoost: paramOr(params, "gap_boost_multiplier", config.AppConfig.GAPBoostMultiplier),
+		halfLife: max(0, paramOr(params, "half_life", 0)),
+	}
+}
+
+func (s *frequencyGapStrategy) Name() string { return DefaultStrategy }
+
+func (s *frequencyGapStrategy) Params() map[string]float64 {
+	return map[string]float64{"gap_boost_multiplier": s.gapBoost, "half_life": s.halfLife}
+}
+
+func (s *frequencyGapStrategy) Predict(h *History, count int) *Prediction {
+	weighted, _ := recency.Weighted(h.Sequence(), h.game().Main, s.halfLife)
+	lastSeen := h.LastSeen()
+	target := h.BaseDraw + 1
+
+	probs := map[int]float64{}
+	gaps := map[int]int{}
+	scores := map[int]float64{}
+	for i := range weighted {
+		probs[i+1] = weighted[i]
+		gaps[i+1] = target - lastSeen[i]
+		scores[i+1] = probs[i+1] * (1.0 + float64(gaps[i+1])*s.gapBoost)
+	}
+
+	sets := [][]int{}
+	for i := 0; i < count; i++ {
+		sets = append(sets, generateWeightedSample(h, probs, gaps, s.gapBoost))
+	}
+	return &Prediction{Sets: sets, Scores: scores}
+}
+
+// uniformStrategy 이력을 보지 않고 균등하게 뽑는 기준선 전략 (백테스트 비교용)
+type uniformStrategy struct{}
+
+func newUniformStrategy(params map[string]float64) Strategy {
+	return uniformStrategy{}
+}
+
+func (uniformStrategy) Name() string { return "uniform" }
+
+func (uniformStrategy) Params() map[string]float64 { return map[string]float64{} }
+
+func (uniformStrategy) Predict(h *History, count int) *Prediction {
+	probs := map[int]float64{}
+	scores := map[int]float64{}
+	size := h.game().Main.Max
+	for i := 1; i <= size; i++ {
+		probs[i] = 1
+		scores[i] = 1.0 / float64(size)
+	}
+
+	sets := [][]int{}
+	for i := 0; i < count; i++ {
+		sets = append(sets, generateWeightedSample(h, probs, nil, 0))
+	}
+	return &Prediction{Sets: sets, Scores: scores}
+}
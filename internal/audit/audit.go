@@ -0,0 +1,394 @@
+// internal/audit/audit.go
+package audit
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"lottopredictor/internal/common"
+	"lottopredictor/internal/game"
+)
+
+// DefaultAlpha 보정 후 p값이 이 값보다 작으면 유의한 편차로 판정
+const DefaultAlpha = 0.05
+
+// 검정 이름
+const (
+	TestFrequency   = "frequency_chi_square"
+	TestOddEvenRuns = "odd_even_runs"
+	TestOverlap     = "consecutive_overlap"
+	TestSumSerial   = "sum_serial_correlation"
+	TestSumUniform  = "sum_distribution"
+)
+
+// Test 검정 하나의 결과. 자료가 부족하면 Skipped이고 p값은 1
+type Test struct {
+	Name        string  `json:"name"`
+	Title       string  `json:"title"`
+	Statistic   float64 `json:"statistic"`
+	DF          float64 `json:"df,omitempty"` // 카이제곱 검정 자유도 (z 검정은 0)
+	PValue      float64 `json:"p_value"`
+	AdjustedP   float64 `json:"adjusted_p"` // Holm-Bonferroni 보정
+	Significant bool    `json:"significant"`
+	Skipped     bool    `json:"skipped,omitempty"`
+	Verdict     string  `json:"verdict"`
+}
+
+// NumberDeviation 번호 하나의 등장 횟수 편차 (이항 z 검정, 풀의 모든 번호에 대해 Holm 보정)
+type NumberDeviation struct {
+	Number      int     `json:"number"`
+	Count       int     `json:"count"`
+	Expected    float64 `json:"expected"`
+	Z           float64 `json:"z"`
+	PValue      float64 `json:"p_value"`
+	AdjustedP   float64 `json:"adjusted_p"`
+	Significant bool    `json:"significant"`
+}
+
+// Report 당첨 번호 이력의 무작위성 검정 결과
+type Report struct {
+	FromDraw int               `json:"from_draw"`
+	ToDraw   int               `json:"to_draw"`
+	Draws    int               `json:"draws"`
+	Alpha    float64           `json:"alpha"`
+	Tests    []Test            `json:"tests"`
+	Numbers  []NumberDeviation `json:"numbers"` // |z| 큰 순
+	Verdict  string            `json:"verdict"`
+}
+
+// minDraws 검정을 수행하는 최소 회차 수
+const minDraws = 20
+
+// Run p 번호 풀의 draws(회차 순 당첨 번호 목록)에 대해 모든 검정을 수행한다. fromDraw는 첫 회차 번호 (표시용)
+func Run(fromDraw int, draws [][]int, p game.Pool, alpha float64) *Report {
+	if alpha <= 0 {
+		alpha = DefaultAlpha
+	}
+	r := &Report{FromDraw: fromDraw, ToDraw: fromDraw + len(draws) - 1, Draws: len(draws), Alpha: alpha}
+	if len(draws) == 0 {
+		r.ToDraw = 0
+	}
+	r.Tests = []Test{
+		frequencyTest(draws, p),
+		runsTest(draws, p),
+		overlapTest(draws, p),
+		sumSerialTest(draws),
+		sumUniformTest(draws, p),
+	}
+
+	// 수행한 검정끼리 Holm 보정
+	ps, idx := []float64{}, []int{}
+	for i, t := range r.Tests {
+		if !t.Skipped {
+			ps = append(ps, t.PValue)
+			idx = append(idx, i)
+		}
+	}
+	for j, adj := range holm(ps) {
+		t := &r.Tests[idx[j]]
+		t.AdjustedP = adj
+		t.Significant = adj < alpha
+		t.Verdict = verdict(t.Name, t.Significant)
+	}
+	for i := range r.Tests {
+		if r.Tests[i].Skipped {
+			r.Tests[i].PValue, r.Tests[i].AdjustedP = 1, 1
+			r.Tests[i].Verdict = fmt.Sprintf("자료 부족 (%d회 이상 필요)", minDraws)
+		}
+	}
+
+	r.Numbers = numberDeviations(draws, p, alpha)
+	r.Verdict = overallVerdict(r)
+	return r
+}
+
+// frequencyTest 번호별 등장 횟수 카이제곱 적합도 검정.
+// 한 회차에서 k개를 중복 없이 뽑으므로 Pearson 통계량의 기대값이 m-k (6/45는 39)이다. (m-1)/(m-k)를 곱해 자유도 m-1 분포에 맞춘다.
+func frequencyTest(draws [][]int, p game.Pool) Test {
+	t := Test{Name: TestFrequency, Title: "번호별 등장 횟수 균등성 (카이제곱)"}
+	if len(draws) < minDraws {
+		t.Skipped = true
+		return t
+	}
+	counts := numberCounts(draws, p)
+	m, k := float64(p.Max), float64(p.Picks)
+	expected := float64(len(draws)) * k / m
+	x2 := 0.0
+	for _, c := range counts {
+		x2 += (float64(c) - expected) * (float64(c) - expected) / expected
+	}
+	t.Statistic = x2 * (m - 1) / (m - k)
+	t.DF = m - 1
+	t.PValue = chiSquareSF(t.Statistic, t.DF)
+	return t
+}
+
+// runsTest 회차별 홀수가 짝수보다 많은지/적은지 순서에 대한 Wald-Wolfowitz 런 검정 (6/45에서 홀수 3개처럼 같은 회차는 제외)
+func runsTest(draws [][]int, p game.Pool) Test {
+	t := Test{Name: TestOddEvenRuns, Title: fmt.Sprintf("홀짝 흐름 런 검정 (홀수 %d개 이상 / %d개 이하)", p.Picks/2+1, (p.Picks-1)/2)}
+	seq := []bool{}
+	for _, d := range draws {
+		odd := 0
+		for _, n := range d {
+			odd += n % 2
+		}
+		if 2*odd != p.Picks {
+			seq = append(seq, 2*odd > p.Picks)
+		}
+	}
+	n1 := 0
+	for _, v := range seq {
+		if v {
+			n1++
+		}
+	}
+	n2 := len(seq) - n1
+	if len(seq) < minDraws || n1 == 0 || n2 == 0 {
+		t.Skipped = true
+		return t
+	}
+	runs := 1
+	for i := 1; i < len(seq); i++ {
+		if seq[i] != seq[i-1] {
+			runs++
+		}
+	}
+	fn1, fn2, n := float64(n1), float64(n2), float64(len(seq))
+	mean := 2*fn1*fn2/n + 1
+	variance := 2 * fn1 * fn2 * (2*fn1*fn2 - n) / (n * n * (n - 1))
+	t.Statistic = (float64(runs) - mean) / math.Sqrt(variance)
+	t.PValue = normalSF2(t.Statistic)
+	return t
+}
+
+// overlapTest 연속한 두 회차에 함께 나온 번호 수의 평균이 독립 추첨의 기대값(초기하분포)과 같은지 z 검정.
+// 가운데 회차를 고정하면 앞뒤 겹침은 서로 독립이라 이웃한 겹침끼리 상관이 없다.
+func overlapTest(draws [][]int, p game.Pool) Test {
+	t := Test{Name: TestOverlap, Title: "연속 회차 번호 겹침 (이월수)"}
+	if len(draws) < minDraws {
+		t.Skipped = true
+		return t
+	}
+	total := 0
+	for i := 1; i < len(draws); i++ {
+		prev := map[int]bool{}
+		for _, n := range draws[i-1] {
+			prev[n] = true
+		}
+		for _, n := range draws[i] {
+			if prev[n] {
+				total++
+			}
+		}
+	}
+	m, k := float64(p.Max), float64(p.Picks)
+	mean := k * k / m
+	variance := k * (k / m) * ((m - k) / m) * ((m - k) / (m - 1))
+	pairs := float64(len(draws) - 1)
+	t.Statistic = (float64(total)/pairs - mean) / math.Sqrt(variance/pairs)
+	t.PValue = normalSF2(t.Statistic)
+	return t
+}
+
+// sumSerialTest 연속 회차 번호 합계의 1차 자기상관. 독립이면 r·√n이 근사적으로 표준정규분포
+func sumSerialTest(draws [][]int) Test {
+	t := Test{Name: TestSumSerial, Title: "연속 회차 합계 자기상관"}
+	if len(draws) < minDraws {
+		t.Skipped = true
+		return t
+	}
+	sums := make([]float64, len(draws))
+	mean := 0.0
+	for i, d := range draws {
+		for _, n := range d {
+			sums[i] += float64(n)
+		}
+		mean += sums[i]
+	}
+	mean /= float64(len(sums))
+	num, den := 0.0, 0.0
+	for i, s := range sums {
+		den += (s - mean) * (s - mean)
+		if i > 0 {
+			num += (s - mean) * (sums[i-1] - mean)
+		}
+	}
+	if den == 0 {
+		t.Skipped = true
+		return t
+	}
+	t.Statistic = num / den * math.Sqrt(float64(len(sums)))
+	t.PValue = normalSF2(t.Statistic)
+	return t
+}
+
+// sumUniformTest 번호 합계 분포가 무작위 추첨의 정확한 합계 분포와 같은지 카이제곱 검정.
+// 기대 빈도가 비슷한 구간(최대 20개, 구간당 기대 5회 이상)으로 나눈다.
+func sumUniformTest(draws [][]int, p game.Pool) Test {
+	t := Test{Name: TestSumUniform, Title: "번호 합계 분포 (카이제곱)"}
+	n := len(draws)
+	bins := min(20, n/10)
+	if n < minDraws || bins < 2 {
+		t.Skipped = true
+		return t
+	}
+	dist := SumDistribution(p)
+	lo, hi := 0, len(dist)-1
+
+	// 누적확률 기준 등분 경계
+	edges := []int{}
+	cum, next := 0.0, 1
+	for s := lo; s <= hi; s++ {
+		cum += dist[s]
+		if next < bins && cum >= float64(next)/float64(bins) {
+			edges = append(edges, s)
+			next++
+		}
+	}
+	edges = append(edges, hi)
+	binOf := func(sum int) int { return sort.SearchInts(edges, sum) }
+
+	expected := make([]float64, len(edges))
+	for s, p := range dist {
+		if p > 0 {
+			expected[binOf(s)] += p * float64(n)
+		}
+	}
+	observed := make([]float64, len(edges))
+	for _, d := range draws {
+		s := 0
+		for _, x := range d {
+			s += x
+		}
+		observed[binOf(s)]++
+	}
+	x2 := 0.0
+	for i := range edges {
+		x2 += (observed[i] - expected[i]) * (observed[i] - expected[i]) / expected[i]
+	}
+	t.Statistic = x2
+	t.DF = float64(len(edges) - 1)
+	t.PValue = chiSquareSF(x2, t.DF)
+	return t
+}
+
+// SumDistribution p 번호 풀에서 무작위로 뽑은 번호 합계의 확률 (index = 합계)
+func SumDistribution(p game.Pool) []float64 {
+	maxSum := 0
+	for n := p.Max - p.Picks + 1; n <= p.Max; n++ {
+		maxSum += n
+	}
+	// ways[k][s]: k개를 골라 합이 s인 경우의 수
+	ways := make([][]int64, p.Picks+1)
+	for k := range ways {
+		ways[k] = make([]int64, maxSum+1)
+	}
+	ways[0][0] = 1
+	for n := 1; n <= p.Max; n++ {
+		for k := p.Picks; k >= 1; k-- {
+			for s := maxSum; s >= n; s-- {
+				ways[k][s] += ways[k-1][s-n]
+			}
+		}
+	}
+	dist := make([]float64, maxSum+1)
+	total := float64(common.Binomial(p.Max, p.Picks))
+	for s, w := range ways[p.Picks] {
+		dist[s] = float64(w) / total
+	}
+	return dist
+}
+
+func numberCounts(draws [][]int, p game.Pool) []int {
+	counts := make([]int, p.Max)
+	for _, d := range draws {
+		for _, n := range d {
+			counts[n-1]++
+		}
+	}
+	return counts
+}
+
+// numberDeviations 번호별 등장 횟수 정확 이항 검정 ("핫 넘버"가 우연인지). 풀의 번호를 한꺼번에 보므로 Holm 보정 필수
+func numberDeviations(draws [][]int, pool game.Pool, alpha float64) []NumberDeviation {
+	if len(draws) == 0 {
+		return []NumberDeviation{}
+	}
+	p := float64(pool.Picks) / float64(pool.Max)
+	n := len(draws)
+	expected := float64(n) * p
+	sd := math.Sqrt(float64(n) * p * (1 - p))
+	devs := make([]NumberDeviation, pool.Max)
+	ps := make([]float64, pool.Max)
+	for i, c := range numberCounts(draws, pool) {
+		z := (float64(c) - expected) / sd
+		devs[i] = NumberDeviation{Number: i + 1, Count: c, Expected: expected, Z: z, PValue: binomialP2(c, n, p)}
+		ps[i] = devs[i].PValue
+	}
+	for i, adj := range holm(ps) {
+		devs[i].AdjustedP = adj
+		devs[i].Significant = adj < alpha
+	}
+	sort.SliceStable(devs, func(a, b int) bool { return math.Abs(devs[a].Z) > math.Abs(devs[b].Z) })
+	return devs
+}
+
+func verdict(name string, significant bool) string {
+	if significant {
+		switch name {
+		case TestFrequency:
+			return "번호별 등장 횟수 차이가 우연으로 보기 어려울 만큼 큼. 자료 오류나 추첨기 편향을 확인할 것"
+		case TestOddEvenRuns:
+			return "홀짝 흐름이 무작위보다 뭉치거나 번갈아 나옴"
+		case TestOverlap:
+			return "앞 회차 번호가 다시 나오는 정도가 무작위 기대값과 다름"
+		case TestSumSerial:
+			return "연속 회차 합계에 상관이 있음"
+		default:
+			return "번호 합계 분포가 무작위 추첨과 다름"
+		}
+	}
+	switch name {
+	case TestFrequency:
+		return "번호별 등장 횟수 차이는 무작위 추첨에서 흔히 생기는 수준"
+	case TestOddEvenRuns:
+		return "홀짝 흐름은 무작위와 구별되지 않음"
+	case TestOverlap:
+		return "이월수는 독립 추첨 기대값과 구별되지 않음"
+	case TestSumSerial:
+		return "앞 회차 합계로 다음 회차 합계를 예측할 근거 없음"
+	default:
+		return "번호 합계 분포는 무작위 추첨과 구별되지 않음"
+	}
+}
+
+func overallVerdict(r *Report) string {
+	significant, run := 0, 0
+	for _, t := range r.Tests {
+		if !t.Skipped {
+			run++
+			if t.Significant {
+				significant++
+			}
+		}
+	}
+	hot := 0
+	for _, d := range r.Numbers {
+		if d.Significant {
+			hot++
+		}
+	}
+	switch {
+	case run == 0:
+		return "회차 수가 적어 검정하지 않음"
+	case significant == 0 && hot == 0:
+		return fmt.Sprintf("%d개 검정 모두 다중 비교 보정 후 유의하지 않음: 이력은 무작위 추첨과 구별되지 않으며, 특정 번호가 더 잘 나온다는 \"핫 넘버\" 주장은 근거가 없음", run)
+	case hot == 0:
+		return fmt.Sprintf("%d개 검정 중 %d개에서 보정 후 유의한 편차. 다만 개별 번호 중 보정 후 유의하게 많이/적게 나온 번호는 없어 \"핫 넘버\" 주장의 근거는 되지 않음", run, significant)
+	case significant == 0:
+		return fmt.Sprintf("번호 %d개의 등장 횟수가 보정 후에도 기대값과 다르지만 전체 빈도 검정 등 %d개 검정은 유의하지 않음. 회차가 적을 때 생기는 우연일 수 있으니 더 긴 구간으로 다시 확인할 것", hot, run)
+	default:
+		return fmt.Sprintf("%d개 검정 중 %d개, 번호 %d개에서 보정 후 유의한 편차. 자료 오류 여부부터 확인할 것", run, significant, hot)
+	}
+}
@@ -0,0 +1,100 @@
+// internal/audit/dist.go
+package audit
+
+import (
+	"math"
+	"sort"
+)
+
+// chiSquareSF 자유도 df 카이제곱 분포에서 x 이상일 확률 (상위 꼬리)
+func chiSquareSF(x, df float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	return gammaQ(df/2, x/2)
+}
+
+// normalSF2 표준정규분포 양측 p값
+func normalSF2(z float64) float64 {
+	return math.Erfc(math.Abs(z) / math.Sqrt2)
+}
+
+// binomialP2 이항분포 B(n, p)에서 k의 정확 양측 p값 (작은 쪽 꼬리 × 2)
+func binomialP2(k, n int, p float64) float64 {
+	lgN, _ := math.Lgamma(float64(n + 1))
+	pmf := func(i int) float64 {
+		lgI, _ := math.Lgamma(float64(i + 1))
+		lgR, _ := math.Lgamma(float64(n - i + 1))
+		return math.Exp(lgN - lgI - lgR + float64(i)*math.Log(p) + float64(n-i)*math.Log1p(-p))
+	}
+	lower, upper := 0.0, 0.0
+	for i := 0; i <= k; i++ {
+		lower += pmf(i)
+	}
+	for i := k; i <= n; i++ {
+		upper += pmf(i)
+	}
+	return math.Min(1, 2*math.Min(lower, upper))
+}
+
+// gammaQ 정규화 상위 불완전 감마 함수 Q(a, x)
+// x < a+1이면 급수, 아니면 연분수로 계산한다 (Numerical Recipes gser/gcf).
+func gammaQ(a, x float64) float64 {
+	const (
+		maxIter = 1000
+		eps     = 1e-14
+		tiny    = 1e-300
+	)
+	lg, _ := math.Lgamma(a)
+	if x < a+1 {
+		sum, term := 1/a, 1/a
+		for n := 1; n < maxIter; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*eps {
+				break
+			}
+		}
+		return 1 - sum*math.Exp(-x+a*math.Log(x)-lg)
+	}
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for i := 1; i < maxIter; i++ {
+		an := -float64(i) * (float64(i) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < eps {
+			break
+		}
+	}
+	return math.Exp(-x+a*math.Log(x)-lg) * h
+}
+
+// holm Holm-Bonferroni 보정 p값. ps와 같은 순서로 반환
+func holm(ps []float64) []float64 {
+	m := len(ps)
+	idx := make([]int, m)
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool { return ps[idx[a]] < ps[idx[b]] })
+	adj := make([]float64, m)
+	running := 0.0
+	for rank, i := range idx {
+		running = math.Max(running, math.Min(1, float64(m-rank)*ps[i]))
+		adj[i] = running
+	}
+	return adj
+}
@@ -0,0 +1,167 @@
+// internal/backtest/backtest.go
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"lottopredictor/internal/analyzer"
+	"lottopredictor/internal/common"
+	"lottopredictor/internal/config"
+	"lottopredictor/internal/db"
+	"lottopredictor/internal/game"
+	"lottopredictor/internal/util"
+)
+
+// Options 백테스트 구간과 회차당 세트 수
+type Options struct {
+	From        int // 첫 예측 대상 회차
+	To          int // 마지막 예측 대상 회차 (0이면 DB 최신 회차)
+	SetsPerDraw int
+	Seed        int64 // 기준 시드 (0이면 설정 파일 값, 설정도 0이면 새 시드). 전략마다 전략 이름으로 파생한 시드를 쓴다.
+}
+
+// Report 전략 하나의 백테스트 집계 결과
+type Report struct {
+	Game           *game.Game
+	RunID          int64
+	Strategy       string
+	StrategyParams string
+	Seed           int64 // 추천 세트 난수 시드 (기준 시드와 전략 이름으로 파생, 같은 시드, 같은 구간이면 같은 세트)
+	BaseSeed       int64 // 실행에 준 기준 시드
+	From           int
+	To             int
+	Draws          int
+	Sets           int
+	HitCounts      [game.MaxMainPicks + 1]int // 본 번호 일치 개수(0~6)별 세트 수
+	RankCounts     map[int]int                // 등수별 세트 수 (RankNone 포함)
+	TotalPrize     int64
+	Cost           int64
+	ExpectedValue  float64 // 세트당 평균 당첨금
+}
+
+// ROI (당첨금 - 구매 비용) / 구매 비용
+func (r *Report) ROI() float64 {
+	if r.Cost == 0 {
+		return 0
+	}
+	return float64(r.TotalPrize-r.Cost) / float64(r.Cost)
+}
+
+// Run From~To 회차를 한 회차씩 전진하며 예측/평가한다.
+// target 회차 예측에는 target-1 회차까지의 이력만 전달해 미래 데이터를 보지 않는다.
+// 결과는 backtest_runs / backtest_results 테이블에 저장되고 실시간 예측 테이블은 건드리지 않는다.
+func Run(ctx context.Context, store *db.Store, strategy analyzer.Strategy, opts Options) (*Report, error) {
+	if opts.From < 2 {
+		return nil, fmt.Errorf("첫 예측 회차는 2 이상이어야 함: %d", opts.From)
+	}
+	if opts.SetsPerDraw <= 0 {
+		opts.SetsPerDraw = config.AppConfig.SuggestionSetCount
+	}
+
+	all, err := store.ListDraws(ctx, 1, opts.To)
+	if err != nil {
+		return nil, err
+	}
+	if len(all) == 0 {
+		return nil, fmt.Errorf("lotto_results가 비어 있음")
+	}
+	if opts.To == 0 {
+		opts.To = all[len(all)-1].Number
+	}
+	if opts.From > opts.To {
+		return nil, fmt.Errorf("구간이 잘못됨: %d ~ %d", opts.From, opts.To)
+	}
+
+	if opts.Seed == 0 {
+		opts.Seed = analyzer.RunSeed()
+	}
+	report := &Report{
+		Strategy:       strategy.Name(),
+		StrategyParams: analyzer.EncodeParams(strategy),
+		Seed:           util.DeriveSeed(opts.Seed, strategy.Name()),
+		BaseSeed:       opts.Seed,
+		From:           opts.From,
+		To:             opts.To,
+		RankCounts:     map[int]int{},
+	}
+	g := store.Game()
+	report.Game = g
+	if err := config.AppConfig.Constraints.For(g.Main).Validate(); err != nil {
+		return nil, fmt.Errorf("추천 조건 오류: %w", err)
+	}
+	history := analyzer.NewHistory(g, 0, nil)
+	history.Rules = &config.AppConfig.Constraints
+	history.PairAffinity = config.AppConfig.PairAffinity
+	history.Rand = util.NewRand(report.Seed)
+	results := []db.BacktestResult{}
+
+	for _, draw := range all {
+		if draw.Number >= opts.From && len(history.Draws) > 0 {
+			history.BaseDraw = draw.Number - 1
+			prediction := strategy.Predict(history, opts.SetsPerDraw)
+			params := analyzer.EncodePredictionParams(prediction)
+			bonusSets := history.SampleBonus(len(prediction.Sets))
+			for i, set := range prediction.Sets {
+				var bonus []int
+				if bonusSets != nil {
+					bonus = bonusSets[i]
+				}
+				m := g.Rank(set, bonus, draw.Numbers, draw.Bonus, draw.BonusNumbers)
+				rank := m.Rank
+				prize := config.PrizeAmount(g, rank)
+				if rank == common.RankFirst && draw.FirstPrize > 0 {
+					// 실제 당첨금 자료가 있으면 그 회차 1인당 당첨금 사용
+					prize = draw.FirstPrize
+				}
+
+				report.Sets++
+				report.HitCounts[m.Main]++
+				report.RankCounts[rank]++
+				report.TotalPrize += prize
+
+				results = append(results, db.BacktestResult{
+					DrawNumber:   draw.Number,
+					SetIndex:     i + 1,
+					Numbers:      set,
+					Bonus:        bonus,
+					Matched:      m.Main,
+					BonusMatched: m.Extra,
+					BonusMatches: m.Bonus,
+					Rank:         rank,
+					Prize:        prize,
+					Params:       params,
+				})
+			}
+			report.Draws++
+		}
+
+		// 예측이 끝난 뒤에야 해당 회차를 이력에 추가
+		history.Add(draw)
+	}
+
+	report.Cost = int64(report.Sets) * g.TicketPrice
+	if report.Sets > 0 {
+		report.ExpectedValue = float64(report.TotalPrize) / float64(report.Sets)
+	}
+
+	report.RunID, err = store.SaveBacktestRun(ctx, &db.BacktestRun{
+		Strategy:       report.Strategy,
+		StrategyParams: report.StrategyParams,
+		Seed:           report.Seed,
+		BaseSeed:       report.BaseSeed,
+		FromDraw:       report.From,
+		ToDraw:         report.To,
+		SetsPerDraw:    opts.SetsPerDraw,
+		TotalSets:      report.Sets,
+		TotalPrize:     report.TotalPrize,
+		ExpectedValue:  report.ExpectedValue,
+	}, results)
+	if err != nil {
+		return nil, fmt.Errorf("백테스트 결과 저장 실패: %w", err)
+	}
+	log.Printf("[Backtest] %s %d ~ %d 회차 완료 (run %d, 세트 %d개, 시드 %d)\n", report.Strategy, report.From, report.To, report.RunID, report.Sets, report.Seed)
+
+	return report, nil
+}
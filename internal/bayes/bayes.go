@@ -0,0 +1,126 @@
+// internal/bayes/bayes.go
+package bayes
+
+import (
+	"fmt"
+
+	"lottopredictor/internal/common"
+	"lottopredictor/internal/game"
+)
+
+// DefaultPrior 번호별 디리클레 사전 분포 모수 (모든 번호 같은 값, 1이면 균등 사전 분포)
+const DefaultPrior = 1.0
+
+// credibleLevel 신용구간 수준
+const credibleLevel = 0.95
+
+// Options 설정 파일 bayes 항목
+type Options struct {
+	Prior    float64 `json:"prior,omitempty"`     // 번호별 사전 모수 α (0이면 1). 클수록 균등 쪽으로 강하게 당긴다
+	HalfLife float64 `json:"half_life,omitempty"` // 시간 감쇠 반감기 (회차 수, 0이면 감쇠 없음)
+}
+
+// Validate 값 범위 확인
+func (o *Options) Validate() error {
+	if o.Prior < 0 || o.HalfLife < 0 {
+		return fmt.Errorf("bayes 설정 값은 0 이상이어야 함 (prior %v, half_life %v)", o.Prior, o.HalfLife)
+	}
+	return nil
+}
+
+func (o *Options) prior() float64 {
+	if o.Prior > 0 {
+		return o.Prior
+	}
+	return DefaultPrior
+}
+
+// Estimate 번호 하나의 사후 분포 요약. 확률은 기존 등장 확률과 같은 "회차당 출현 확률(%)" 단위 (번호 몫 × 세트 크기 × 100, 6/45 균등이면 13.33%)
+type Estimate struct {
+	Number        int     `json:"number"`
+	Mean          float64 `json:"mean"`
+	Lower         float64 `json:"lower"` // 95% 신용구간 하한
+	Upper         float64 `json:"upper"` // 95% 신용구간 상한
+	PAboveUniform float64 `json:"p_above_uniform"`
+}
+
+// Result 사후 분포 추정 결과와 사용한 설정
+type Result struct {
+	Prior     float64    `json:"prior"`
+	HalfLife  float64    `json:"half_life"`
+	Pool      game.Pool  `json:"pool"`
+	Estimates []Estimate `json:"estimates"` // index = 번호-1
+}
+
+// Uniform 균등 추첨일 때 회차당 출현 확률 (%)
+func (r *Result) Uniform() float64 { return float64(r.Pool.Picks) / float64(r.Pool.Max) * 100 }
+
+// Posterior p 번호 풀의 회차 순 당첨 번호(draws)로 디리클레-다항 사후 분포를 구한다.
+// 회차마다 번호 Picks개(6/45는 6개)를 다항 관측으로 보고, 반감기가 있으면 최근 회차일수록 큰 가중치(0.5^(경과 회차/반감기))를 준다.
+// 각 번호 몫의 주변 사후 분포는 Beta(α_i, A - α_i)이다.
+func Posterior(draws [][]int, p game.Pool, opts Options) *Result {
+	alpha := make([]float64, p.Max)
+	for i := range alpha {
+		alpha[i] = opts.prior()
+	}
+	for t, d := range draws {
+		w := common.DecayWeight(len(draws)-1-t, opts.HalfLife)
+		for _, n := range d {
+			alpha[n-1] += w
+		}
+	}
+	total := 0.0
+	for _, a := range alpha {
+		total += a
+	}
+
+	scale := float64(p.Picks) * 100
+	uniform := 1.0 / float64(p.Max)
+	tail := (1 - credibleLevel) / 2
+	estimates := make([]Estimate, p.Max)
+	for i, a := range alpha {
+		b := total - a
+		estimates[i] = Estimate{
+			Number:        i + 1,
+			Mean:          a / total * scale,
+			Lower:         betaQuantile(tail, a, b) * scale,
+			Upper:         betaQuantile(1-tail, a, b) * scale,
+			PAboveUniform: 1 - betaCDF(uniform, a, b),
+		}
+	}
+	return &Result{Prior: opts.prior(), HalfLife: opts.HalfLife, Pool: p, Estimates: estimates}
+}
+
+// Overlap 상위 번호 top개의 신용구간이 서로 얼마나 겹치는지
+type Overlap struct {
+	Top            int `json:"top"`
+	OverlapNext    int `json:"overlap_next"`    // 상위 top개 중 구간이 top+1위 번호 구간과 겹치는 번호 수
+	ContainUniform int `json:"contain_uniform"` // 상위 top개 중 구간이 균등 확률을 포함하는 번호 수
+	Distinct       int `json:"distinct"`        // 전체 번호 중 구간이 균등 확률을 포함하지 않는 번호 수
+}
+
+// Compare ranked(순위 순 번호)의 상위 top개 구간 겹침을 센다.
+func (r *Result) Compare(ranked []int, top int) Overlap {
+	estimates := r.Estimates
+	o := Overlap{Top: min(top, len(ranked))}
+	u := r.Uniform()
+	var next *Estimate
+	if top < len(ranked) {
+		next = &estimates[ranked[top]-1]
+	}
+	for _, n := range ranked[:o.Top] {
+		e := estimates[n-1]
+		if next != nil && e.Lower <= next.Upper && next.Lower <= e.Upper {
+			o.OverlapNext++
+		}
+		if e.Lower <= u && u <= e.Upper {
+			o.ContainUniform++
+		}
+	}
+	for _, e := range estimates {
+		if e.Lower > u || e.Upper < u {
+			o.Distinct++
+		}
+	}
+	return o
+}
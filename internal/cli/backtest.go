@@ -0,0 +1,112 @@
+// internal/cli/backtest.go
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"lottopredictor/internal/analyzer"
+	"lottopredictor/internal/backtest"
+	"lottopredictor/internal/common"
+	"lottopredictor/internal/config"
+)
+
+func runBacktest(args []string) error {
+	var opts options
+	fs := newFlagSet("backtest")
+	opts.bindDB(fs)
+	fs.Var(&opts.params, "param", "전략 파라미터 key=value (여러 번 지정 가능, 모든 전략에 적용)")
+	opts.bindRules(fs)
+	fs.Int64Var(&opts.seed, "seed", 0, "기준 시드 (0이면 설정 파일 값, 설정도 0이면 새 시드). 전략마다 이 시드와 전략 이름으로 시드를 파생하며 같은 기준 시드면 같은 세트")
+	names := fs.String("strategies", "", fmt.Sprintf("비교할 전략 목록, 쉼표 구분 (%s), 비어 있으면 설정 파일 전략", strings.Join(analyzer.StrategyNames(), ", ")))
+	from := fs.Int("from", 0, "첫 예측 대상 회차 (필수)")
+	to := fs.Int("to", 0, "마지막 예측 대상 회차 (0이면 DB 최신 회차)")
+	sets := fs.Int("sets", 0, "회차당 추천 세트 수 (0이면 설정 파일 값)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *from < 2 {
+		return fmt.Errorf("%w: -from 은 2 이상이어야 함", ErrUsage)
+	}
+
+	database, err := opts.openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	strategies, err := backtestStrategies(*names, opts.params)
+	if err != nil {
+		return err
+	}
+
+	// 전략마다 기준 시드(-seed, 없으면 설정 파일 값이나 새 시드)에서 파생한 시드를 써서 전략끼리 같은 난수열을 나눠 쓰지 않는다
+	seed := analyzer.RunSeed()
+	for _, strategy := range strategies {
+		report, err := backtest.Run(context.Background(), database, strategy, backtest.Options{From: *from, To: *to, SetsPerDraw: *sets, Seed: seed})
+		if err != nil {
+			return err
+		}
+		printBacktestReport(report)
+	}
+	fmt.Printf("\n같은 세트로 다시 실행하려면 -seed %d\n", seed)
+	return nil
+}
+
+// backtestStrategies 쉼표로 구분된 전략 이름들을 생성. 설정 파일 전략과 같으면 설정 파라미터를 이어받는다.
+func backtestStrategies(names string, params map[string]float64) ([]analyzer.Strategy, error) {
+	if names == "" {
+		names = config.AppConfig.Strategy
+		if names == "" {
+			names = analyzer.DefaultStrategy
+		}
+	}
+
+	strategies := []analyzer.Strategy{}
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		merged := map[string]float64{}
+		if name == config.AppConfig.Strategy {
+			for k, v := range config.AppConfig.StrategyParams {
+				merged[k] = v
+			}
+		}
+		for k, v := range params {
+			merged[k] = v
+		}
+		strategy, err := analyzer.NewStrategy(name, merged)
+		if err != nil {
+			return nil, err
+		}
+		strategies = append(strategies, strategy)
+	}
+	return strategies, nil
+}
+
+func printBacktestReport(r *backtest.Report) {
+	fmt.Printf("\n[백테스트 #%d] %s %s\n", r.RunID, r.Strategy, r.StrategyParams)
+	fmt.Printf("회차 %d ~ %d (%d회), 추천 세트 %d개, 시드 %d (기준 시드 %d)\n", r.From, r.To, r.Draws, r.Sets, r.Seed, r.BaseSeed)
+
+	fmt.Println("일치 개수 분포:")
+	for matched, n := range r.HitCounts[:r.Game.Main.Picks+1] {
+		fmt.Printf("  %d개: %6d (%6.3f%%)\n", matched, n, percentOf(n, r.Sets))
+	}
+
+	fmt.Println("등수별 빈도:")
+	for rank := 1; rank <= r.Game.Ranks(); rank++ {
+		n := r.RankCounts[rank]
+		fmt.Printf("  %d등: %6d (%6.3f%%)\n", rank, n, percentOf(n, r.Sets))
+	}
+	fmt.Printf("  낙첨: %6d (%6.3f%%)\n", r.RankCounts[common.RankNone], percentOf(r.RankCounts[common.RankNone], r.Sets))
+
+	fmt.Printf("총 당첨금: %s / 구매 비용: %s (ROI %.2f%%)\n", r.Game.FormatMoney(r.TotalPrize), r.Game.FormatMoney(r.Cost), r.ROI()*100)
+	fmt.Printf("세트당 기대 당첨금: %s\n", r.Game.FormatAverage(r.ExpectedValue))
+}
+
+func percentOf(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total) * 100
+}
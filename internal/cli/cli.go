@@ -0,0 +1,335 @@
+// internal/cli/cli.go
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"lottopredictor/internal/analyzer"
+	"lottopredictor/internal/config"
+	"lottopredictor/internal/constraint"
+	"lottopredictor/internal/db"
+	"lottopredictor/internal/fetcher"
+	"lottopredictor/internal/game"
+	"lottopredictor/internal/output"
+	"lottopredictor/internal/util"
+)
+
+// Command 하나의 서브커맨드. Subcommands가 있으면 다음 인자로 한 번 더 분기한다.
+type Command struct {
+	Name        string
+	Usage       string
+	Run         func(args []string) error
+	Subcommands []*Command
+}
+
+// ErrUsage 잘못된 명령/인자. 사용법을 출력한 뒤 반환된다.
+var ErrUsage = errors.New("잘못된 명령 사용")
+
+func commands() []*Command {
+	return []*Command{
+		{Name: "run", Usage: "sync → predict → report 전체 실행 (인자 없을 때 기본)", Run: runAll},
+		{Name: "sync", Usage: "새 회차 당첨 번호를 가져와 DB에 저장", Run: runSync},
+		{Name: "import", Usage: "CSV/JSON/XLSX 파일에서 당첨 번호 이력을 DB에 저장", Run: runImport},
+		{Name: "predict", Usage: "다음 회차(또는 -draw 회차) 추천 번호 생성", Run: runPredict},
+		{Name: "evaluate", Usage: "-draw 회차 당첨 번호로 저장된 예측을 평가", Run: runEvaluate},
+		{Name: "replay", Usage: "저장된 예측을 같은 시드로 다시 만들어 세트가 같은지 확인", Run: runReplay},
+		{Name: "report", Usage: "저장된 예측 결과를 HTML/TXT/JSON/CSV/Markdown/XLSX 파일로 출력", Run: runReport},
+		{Name: "backtest", Usage: "회차 구간을 순서대로 예측/평가", Run: runBacktest},
+		{Name: "simulate", Usage: "세트 묶음을 가상 추첨 수백만 회에 참여시켜 기대 손실/ROI/당첨금 분포 계산", Run: runSimulate},
+		{Name: "wheel", Usage: "번호 풀로 보장 조건을 만족하는 휠(조합표) 생성", Run: runWheel},
+		{Name: "serve", Usage: "당첨 번호/예측/평가를 조회하고 예측을 실행하는 JSON API 서버", Run: runServe},
+		{Name: "fake-api", Usage: "기록된 회차 JSON(또는 DB)을 동행복권 API 형식으로 응답하는 로컬 서버", Run: runFakeAPI},
+		{Name: "tickets", Usage: "직접 산 티켓 기록/당첨 확인/구매자·전략별 손익 명령", Subcommands: []*Command{
+			{Name: "add", Usage: "산 티켓 기록 (-set 번호 또는 -predicted 저장된 예측 세트)", Run: runTicketsAdd},
+			{Name: "list", Usage: "기록된 티켓과 당첨 확인 결과 출력", Run: runTicketsList},
+			{Name: "check", Usage: "-draw 회차(없으면 확인 전 모든 회차) 티켓 당첨 확인", Run: runTicketsCheck},
+			{Name: "totals", Usage: "구매자별/전략별 구매 금액, 당첨금, 손익, ROI 출력", Run: runTicketsTotals},
+		}},
+		{Name: "stats", Usage: "당첨 이력 통계 명령", Subcommands: []*Command{
+			{Name: "audit", Usage: "번호 빈도/홀짝 흐름/연속 회차/합계 분포의 무작위성 검정", Run: runStatsAudit},
+		}},
+		{Name: "db", Usage: "DB 관리 명령", Subcommands: []*Command{
+			{Name: "stats", Usage: "테이블별 데이터 현황 출력", Run: runDBStats},
+			{Name: "status", Usage: "스키마 마이그레이션 적용 상태 출력", Run: runDBStatus},
+			{Name: "migrate", Usage: "적용되지 않은 스키마 마이그레이션 적용", Run: runDBMigrate},
+		}},
+	}
+}
+
+// Run os.Args[1:]를 받아 해당 서브커맨드를 실행한다.
+func Run(args []string) error {
+	if len(args) == 0 {
+		return runAll(nil)
+	}
+	return dispatch("lottopredictor", commands(), args)
+}
+
+func dispatch(prog string, cmds []*Command, args []string) error {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage(prog, cmds)
+		if len(args) == 0 {
+			return ErrUsage
+		}
+		return nil
+	}
+	for _, cmd := range cmds {
+		if cmd.Name != args[0] {
+			continue
+		}
+		if len(cmd.Subcommands) > 0 {
+			return dispatch(prog+" "+cmd.Name, cmd.Subcommands, args[1:])
+		}
+		return cmd.Run(args[1:])
+	}
+	printUsage(prog, cmds)
+	return fmt.Errorf("%w: 알 수 없는 명령 %q", ErrUsage, args[0])
+}
+
+func printUsage(prog string, cmds []*Command) {
+	fmt.Fprintf(os.Stderr, "사용법: %s <명령> [옵션]\n\n명령:\n", prog)
+	for _, cmd := range cmds {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", cmd.Name, cmd.Usage)
+	}
+	fmt.Fprintf(os.Stderr, "\n각 명령의 옵션은 '%s <명령> -h'로 확인\n", prog)
+}
+
+// options 여러 명령이 공통으로 쓰는 플래그
+type options struct {
+	dbPath     string
+	configPath string
+	game       string
+	outDir     string
+	formats    string
+	outName    string
+	strategy   string
+	params     paramsFlag
+	rules      rulesFlag
+	portfolio  string
+	affinity   *float64
+	seed       int64
+	apiURL     string
+}
+
+func (o *options) bindDB(fs *flag.FlagSet) {
+	fs.StringVar(&o.dbPath, "db", "database/lotto.db", "SQLite DB 파일 경로")
+	fs.StringVar(&o.configPath, "config", "config.json", "설정 파일 경로")
+	fs.StringVar(&o.game, "game", "", fmt.Sprintf("DB 게임 (%s), 비어 있으면 설정 파일 값, 설정도 없으면 DB에 기록된 게임", strings.Join(game.IDs(), ", ")))
+}
+
+func (o *options) bindOut(fs *flag.FlagSet) {
+	fs.StringVar(&o.outDir, "out", "result", "결과 파일 저장 디렉터리")
+	fs.StringVar(&o.formats, "formats", "", fmt.Sprintf("결과 파일 형식, 쉼표로 구분 (%s), 비어 있으면 설정 파일 값", strings.Join(output.Formats(), ", ")))
+	fs.StringVar(&o.outName, "name", "", "결과 파일 이름 템플릿 ({draw}, {strategy} 치환), 비어 있으면 설정 파일 값")
+}
+
+func (o *options) bindStrategy(fs *flag.FlagSet) {
+	fs.StringVar(&o.strategy, "strategy", "", fmt.Sprintf("예측 전략 (%s), 비어 있으면 설정 파일 값", strings.Join(analyzer.StrategyNames(), ", ")))
+	fs.Var(&o.params, "param", "전략 파라미터 key=value (여러 번 지정 가능)")
+	fs.StringVar(&o.portfolio, "portfolio", "", "추천 세트를 함께 고르는 최적화 목표 (hit: 최저 등수 이상 적중 확률, coverage: 번호/번호 쌍 다양성, none: 끄기), 비어 있으면 설정 파일 값")
+	o.bindRules(fs)
+	o.bindSeed(fs)
+}
+
+func (o *options) bindSeed(fs *flag.FlagSet) {
+	fs.Int64Var(&o.seed, "seed", 0, "추천 세트 난수 시드 (0이면 설정 파일 값, 설정도 0이면 실행마다 새 시드)")
+}
+
+func (o *options) bindRules(fs *flag.FlagSet) {
+	fs.Func("pair-affinity", "번호 쌍 동시 출현 반영 강도 (0: 끄기, 양수: 함께 자주 나온 번호 선호, 음수: 드문 쌍 선호), 비어 있으면 설정 파일 값", func(v string) error {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("숫자가 아님: %q", v)
+		}
+		o.affinity = &f
+		return nil
+	})
+	fs.Var(&o.rules, "rule", fmt.Sprintf("추천 세트 조건 key=value, 설정 파일 값에 덮어씀 (%s; 예: sum=100-170, odd=2-4, include=7,13)", strings.Join(constraint.Names(), ", ")))
+}
+
+func (o *options) bindAPI(fs *flag.FlagSet) {
+	fs.StringVar(&o.apiURL, "api", "", "당첨 번호 API 주소 (비어 있으면 설정 파일 값, 설정도 없으면 동행복권)")
+}
+
+// drawSource 플래그/설정으로 DB 게임의 당첨 번호 소스를 만든다. openDB 이후에 호출
+func (o *options) drawSource(g *game.Game) (fetcher.DrawSource, error) {
+	baseURL := config.AppConfig.APIBaseURL
+	if o.apiURL != "" {
+		baseURL = o.apiURL
+	}
+	timeout := fetcher.DefaultTimeout
+	if config.AppConfig.APITimeoutSeconds > 0 {
+		timeout = time.Duration(config.AppConfig.APITimeoutSeconds) * time.Second
+	}
+	return fetcher.NewClient(g, baseURL, &http.Client{Timeout: timeout})
+}
+
+// openDB 설정 로드, 난수 시드 초기화 후 DB를 열고 -game 플래그(없으면 설정 파일 값) 게임으로 쓴다.
+func (o *options) openDB() (*db.Store, error) {
+	config.LoadConfig(o.configPath)
+	if err := o.applyStrategy(); err != nil {
+		return nil, err
+	}
+
+	util.SeedCryptoRand() // 안전한 시드 초기화
+
+	if dir := filepath.Dir(o.dbPath); dir != "" {
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			return nil, err
+		}
+	}
+	store, err := db.OpenStore(o.dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("DB 초기화 실패: %w", err)
+	}
+	if err := o.bindGame(store); err != nil {
+		store.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// bindGame DB를 -game 플래그(없으면 설정 파일 값) 게임으로 쓰고 추천 조건과 당첨금 설정을 그 게임 정의로 검증한다.
+func (o *options) bindGame(store *db.Store) error {
+	id := config.AppConfig.Game
+	if o.game != "" {
+		id = o.game
+	}
+	if err := store.BindGame(context.Background(), id); err != nil {
+		return err
+	}
+	if err := config.AppConfig.Constraints.For(store.Game().Main).Validate(); err != nil {
+		return fmt.Errorf("추천 조건 오류: %w", err)
+	}
+	return config.CheckPrizes(store.Game())
+}
+
+// applyStrategy -strategy / -param / -rule / -pair-affinity / -portfolio / -seed 플래그를 설정에 덮어쓰고 전략 이름, 포트폴리오 설정, 출력 형식을 검증한다.
+// 추천 조건은 게임 번호 풀에 따라 달라지므로 DB를 연 뒤 bindGame에서 검증한다.
+func (o *options) applyStrategy() error {
+	if o.strategy != "" && o.strategy != config.AppConfig.Strategy {
+		// 다른 전략의 파라미터가 섞이지 않도록 초기화
+		config.AppConfig.Strategy = o.strategy
+		config.AppConfig.StrategyParams = nil
+	}
+	if len(o.params) > 0 {
+		if config.AppConfig.StrategyParams == nil {
+			config.AppConfig.StrategyParams = map[string]float64{}
+		}
+		for k, v := range o.params {
+			config.AppConfig.StrategyParams[k] = v
+		}
+	}
+	if _, err := analyzer.StrategyFromConfig(); err != nil {
+		return err
+	}
+	for _, kv := range o.rules {
+		if err := config.AppConfig.Constraints.Set(kv[0], kv[1]); err != nil {
+			return err
+		}
+	}
+	if o.affinity != nil {
+		config.AppConfig.PairAffinity = *o.affinity
+	}
+	if o.seed != 0 {
+		config.AppConfig.Seed = o.seed
+	}
+	switch o.portfolio {
+	case "":
+	case "none":
+		config.AppConfig.Portfolio.Objective = ""
+	default:
+		config.AppConfig.Portfolio.Objective = o.portfolio
+	}
+	if err := config.AppConfig.Portfolio.Validate(); err != nil {
+		return err
+	}
+	// 예측을 저장한 뒤 결과 파일 단계에서 실패하지 않도록 형식을 미리 확인
+	for _, format := range o.outputFormats() {
+		if _, err := output.NewRenderer(format); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// outputFormats -formats 플래그, 없으면 설정 파일의 결과 파일 형식
+func (o *options) outputFormats() []string {
+	if o.formats != "" {
+		return strings.Split(o.formats, ",")
+	}
+	return config.AppConfig.OutputFormats
+}
+
+// writeReports 결과를 outDir 아래에 설정/플래그로 고른 형식과 파일 이름으로 저장
+func (o *options) writeReports(result *analyzer.PredictionResult) error {
+	name := config.AppConfig.OutputFilename
+	if o.outName != "" {
+		name = o.outName
+	}
+	paths, err := output.WriteAll(result, o.outDir, name, o.outputFormats())
+	for _, path := range paths {
+		fmt.Printf("결과 저장: %s\n", path)
+	}
+	return err
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "사용법: lottopredictor %s [옵션]\n", strings.ReplaceAll(name, ".", " "))
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// paramsFlag key=value 형식으로 여러 번 받는 숫자 파라미터 플래그
+type paramsFlag map[string]float64
+
+func (p *paramsFlag) String() string {
+	return fmt.Sprint(map[string]float64(*p))
+}
+
+func (p *paramsFlag) Set(value string) error {
+	key, raw, ok := strings.Cut(value, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("key=value 형식이 아님: %q", value)
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return fmt.Errorf("%s 값이 숫자가 아님: %w", key, err)
+	}
+	if *p == nil {
+		*p = paramsFlag{}
+	}
+	(*p)[key] = v
+	return nil
+}
+
+// rulesFlag key=value 형식으로 여러 번 받는 추천 조건 플래그 (지정 순서대로 적용)
+type rulesFlag [][2]string
+
+func (r *rulesFlag) String() string {
+	return fmt.Sprint([][2]string(*r))
+}
+
+func (r *rulesFlag) Set(value string) error {
+	key, raw, ok := strings.Cut(value, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("key=value 형식이 아님: %q", value)
+	}
+	// 잘못된 값은 플래그 파싱 단계에서 알린다
+	if err := new(constraint.Rules).Set(key, raw); err != nil {
+		return err
+	}
+	*r = append(*r, [2]string{key, raw})
+	return nil
+}
@@ -0,0 +1,68 @@
+// internal/cli/dbmigrate.go
+package cli
+
+import (
+	"database/sql"
+	"fmt"
+
+	"lottopredictor/internal/db"
+)
+
+// openRawDB 마이그레이션 없이 DB를 연다. db status / db migrate 전용
+func openRawDB(name string, args []string) (*sql.DB, string, error) {
+	var opts options
+	fs := newFlagSet(name)
+	fs.StringVar(&opts.dbPath, "db", "database/lotto.db", "SQLite DB 파일 경로")
+	if err := fs.Parse(args); err != nil {
+		return nil, "", err
+	}
+	database, err := db.Open(opts.dbPath)
+	return database, opts.dbPath, err
+}
+
+func runDBStatus(args []string) error {
+	database, path, err := openRawDB("db.status", args)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	states, err := db.MigrationStatus(database)
+	if err != nil {
+		return err
+	}
+	current, err := db.SchemaVersion(database)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("DB: %s\n스키마 버전: %d / %d\n", path, current, db.LatestSchemaVersion())
+	for _, s := range states {
+		mark := "[ ]"
+		if s.Applied {
+			mark = "[x]"
+		}
+		fmt.Printf("  %s %3d %-50s %s\n", mark, s.Version, s.Name, s.AppliedAt)
+	}
+	return nil
+}
+
+func runDBMigrate(args []string) error {
+	database, path, err := openRawDB("db.migrate", args)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	applied, err := db.Migrate(database)
+	for _, m := range applied {
+		fmt.Printf("적용: %3d %s\n", m.Version, m.Name)
+	}
+	if err != nil {
+		return err
+	}
+	if len(applied) == 0 {
+		fmt.Printf("%s: 이미 최신 스키마 (버전 %d)\n", path, db.LatestSchemaVersion())
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+// internal/cli/dbstats.go
+package cli
+
+import (
+	"context"
+	"fmt"
+)
+
+func runDBStats(args []string) error {
+	var opts options
+	fs := newFlagSet("db.stats")
+	opts.bindDB(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	database, err := opts.openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	stats, err := database.Stats(context.Background())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("DB: %s (스키마 버전 %d)\n", opts.dbPath, stats.SchemaVersion)
+	fmt.Printf("회차 범위: %d ~ %d\n", stats.FirstDraw, stats.LatestDraw)
+	for _, t := range stats.Tables {
+		fmt.Printf("  %-28s %d\n", t.Table, t.Rows)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+// internal/cli/evaluate.go
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"lottopredictor/internal/analyzer"
+	"lottopredictor/internal/db"
+	"lottopredictor/internal/evaluator"
+	"lottopredictor/internal/game"
+)
+
+func runEvaluate(args []string) error {
+	var opts options
+	fs := newFlagSet("evaluate")
+	opts.bindDB(fs)
+	draw := fs.Int("draw", 0, "평가할 회차 (0이면 당첨 번호가 있는데 평가 전인 모든 회차)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	database, err := opts.openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	ctx := context.Background()
+	if *draw == 0 {
+		return evaluatePending(ctx, database)
+	}
+
+	drawNo := *draw
+	summaries, err := evaluator.EvaluateDraw(ctx, database, drawNo)
+	if errors.Is(err, db.ErrNotFound) {
+		return fmt.Errorf("회차 %d 당첨 번호 없음: sync 먼저 실행", drawNo)
+	}
+	if err != nil {
+		return err
+	}
+	actual, err := database.GetDraw(ctx, drawNo)
+	if err != nil {
+		return err
+	}
+
+	result, err := analyzer.LoadLastPredictionResult(ctx, database, drawNo)
+	if err != nil {
+		return err
+	}
+	g := database.Game()
+	fmt.Printf("회차 %d 당첨 번호: %s\n", drawNo, formatDraw(g, actual))
+	for i, set := range result.SuggestionSets {
+		if i < len(result.BonusSets) {
+			fmt.Printf("추천 %2d: %v + %v  | 일치율: %5.1f%%, 등수: %d\n", i+1, set, result.BonusSets[i], result.Percentage[i], result.Ranks[i])
+			continue
+		}
+		fmt.Printf("추천 %2d: %v  | 일치율: %5.1f%%, 등수: %d\n", i+1, set, result.Percentage[i], result.Ranks[i])
+	}
+	printRunSummaries(g, summaries)
+	return nil
+}
+
+// evaluatePending 평가 전 예측을 모두 평가하고 실행별 요약을 출력 (sync 직후 자동 호출)
+func evaluatePending(ctx context.Context, database *db.Store) error {
+	summaries, err := evaluator.EvaluatePending(ctx, database)
+	printRunSummaries(database.Game(), summaries)
+	if err != nil {
+		return fmt.Errorf("예측 자동 평가 실패: %w", err)
+	}
+	if len(summaries) > 0 {
+		log.Printf("[Evaluate] 예측 %d건 평가 완료\n", len(summaries))
+	}
+	return nil
+}
+
+// formatDraw 당첨 번호와 보너스 번호(또는 보너스 풀 당첨 번호) 표시
+func formatDraw(g *game.Game, d *db.Draw) string {
+	switch {
+	case g.Extra > 0:
+		return fmt.Sprintf("%v + %d", d.Numbers, d.Bonus)
+	case g.Bonus.Picks > 0:
+		return fmt.Sprintf("%v + %v", d.Numbers, d.BonusNumbers)
+	}
+	return fmt.Sprint(d.Numbers)
+}
+
+func printRunSummaries(g *game.Game, summaries []evaluator.RunSummary) {
+	for _, s := range summaries {
+		best := "낙첨"
+		if s.BestRank > 0 {
+			best = fmt.Sprintf("%d등", s.BestRank)
+		}
+		hits := []string{}
+		for matched, n := range s.HitCounts {
+			if n > 0 {
+				hits = append(hits, fmt.Sprintf("%d개:%d", matched, n))
+			}
+		}
+		ranks := []int{}
+		for rank := range s.RankCounts {
+			if rank > 0 {
+				ranks = append(ranks, rank)
+			}
+		}
+		sort.Ints(ranks)
+		wins := []string{}
+		for _, rank := range ranks {
+			wins = append(wins, fmt.Sprintf("%d등 %d", rank, s.RankCounts[rank]))
+		}
+		if len(wins) == 0 {
+			wins = append(wins, "없음")
+		}
+		fmt.Printf("회차 %d 예측 #%d (%s): %d/%d세트 평가, 최고 %s, 당첨 %s, 일치 분포 [%s], 당첨금 %s\n",
+			s.DrawNumber, s.Idx, s.Strategy, s.Evaluated, s.Sets, best,
+			strings.Join(wins, ", "), strings.Join(hits, " "), g.FormatMoney(s.Prize))
+	}
+}
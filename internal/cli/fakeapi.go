@@ -0,0 +1,49 @@
+// internal/cli/fakeapi.go
+package cli
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"lottopredictor/internal/fetcher"
+)
+
+// runFakeAPI 오프라인 개발/테스트용 가짜 당첨 번호 API.
+// sync -api http://<addr>/common.do 로 연결한다.
+func runFakeAPI(args []string) error {
+	var opts options
+	fs := newFlagSet("fake-api")
+	opts.bindDB(fs)
+	addr := fs.String("addr", "127.0.0.1:8089", "서버 주소")
+	data := fs.String("data", "", "기록된 회차 JSON(DrawData 배열) 파일. 비어 있으면 -db의 lotto_results 사용")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var draws []*fetcher.DrawData
+	if *data != "" {
+		var err error
+		if draws, err = fetcher.LoadRecordedDraws(*data); err != nil {
+			return err
+		}
+	} else {
+		database, err := opts.openDB()
+		if err != nil {
+			return err
+		}
+		stored, err := database.ListDraws(context.Background(), 1, 0)
+		database.Close()
+		if err != nil {
+			return err
+		}
+		for _, d := range stored {
+			draws = append(draws, d.Data())
+		}
+	}
+
+	log.Printf("[FakeAPI] 회차 %d개 로드, http://%s/common.do 에서 응답\n", len(draws), *addr)
+	fmt.Printf("sync -api http://%s/common.do\n", *addr)
+	return http.ListenAndServe(*addr, fetcher.NewFakeHandler(draws))
+}
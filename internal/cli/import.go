@@ -0,0 +1,44 @@
+// internal/cli/import.go
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"lottopredictor/internal/importer"
+)
+
+func runImport(args []string) error {
+	var opts options
+	fs := newFlagSet("import")
+	opts.bindDB(fs)
+	file := fs.String("file", "", "가져올 파일 경로 (필수)")
+	format := fs.String("format", "", "파일 형식 csv, json, xlsx (비어 있으면 확장자로 판단)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *file == "" {
+		return fmt.Errorf("%w: -file 필요", ErrUsage)
+	}
+
+	database, err := opts.openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	ctx := context.Background()
+	n, err := importer.Import(ctx, database, *file, *format)
+	if err != nil {
+		return fmt.Errorf("%s 가져오기 실패: %w", *file, err)
+	}
+	latest, err := database.LatestDrawNumber(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("가져오기 완료: %d개 회차 저장 (최신 회차 %d)\n", n, latest)
+	if err := evaluatePending(ctx, database); err != nil {
+		return err
+	}
+	return checkPendingTickets(ctx, database)
+}
@@ -0,0 +1,60 @@
+// internal/cli/predict.go
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"lottopredictor/internal/analyzer"
+)
+
+func runPredict(args []string) error {
+	var opts options
+	fs := newFlagSet("predict")
+	opts.bindDB(fs)
+	opts.bindStrategy(fs)
+	opts.bindOut(fs)
+	draw := fs.Int("draw", 0, "예측 대상 회차 (0이면 DB 최신 회차 + 1)")
+	report := fs.Bool("report", true, "예측 후 결과 파일 저장 (-formats 형식)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	database, err := opts.openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	ctx := context.Background()
+	var result *analyzer.PredictionResult
+	if *draw == 0 {
+		latest, err := database.LatestDrawNumber(ctx)
+		if err != nil {
+			return err
+		}
+		if latest == 0 {
+			return fmt.Errorf("lotto_results가 비어 있음: sync 먼저 실행")
+		}
+		result, err = analyzer.Analyze(ctx, database)
+	} else {
+		result, err = analyzer.AnalyzeWithDrawNumber(ctx, database, *draw-1)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("전략: %s (시드 %d)\n", result.Strategy, result.Seed)
+	for i, set := range result.SuggestionSets {
+		if i < len(result.BonusSets) {
+			fmt.Printf("회차 %d 추천 %2d: %v + %v\n", result.DrawNumber, i+1, set, result.BonusSets[i])
+			continue
+		}
+		fmt.Printf("회차 %d 추천 %2d: %v\n", result.DrawNumber, i+1, set)
+	}
+
+	if !*report {
+		return nil
+	}
+	return opts.writeReports(result)
+}
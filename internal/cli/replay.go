@@ -0,0 +1,84 @@
+// internal/cli/replay.go
+package cli
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"lottopredictor/internal/analyzer"
+	"lottopredictor/internal/db"
+)
+
+func runReplay(args []string) error {
+	var opts options
+	fs := newFlagSet("replay")
+	opts.bindDB(fs)
+	draw := fs.Int("draw", 0, "다시 만들 예측 대상 회차 (0이면 예측이 저장된 가장 최근 회차)")
+	idx := fs.Int("idx", 0, "회차 내 예측 순번 (0이면 가장 최근 예측)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	database, err := opts.openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	ctx := context.Background()
+	drawNo := *draw
+	if drawNo == 0 {
+		if drawNo, err = database.LatestPredictionDraw(ctx); err != nil {
+			return err
+		}
+		if drawNo == 0 {
+			return fmt.Errorf("저장된 예측 없음: predict 먼저 실행")
+		}
+	}
+	var run *db.PredictionRun
+	if *idx == 0 {
+		run, err = database.LatestPredictionRun(ctx, drawNo)
+	} else {
+		run, err = database.GetPredictionRun(ctx, drawNo, *idx)
+	}
+	if err != nil {
+		return err
+	}
+
+	replay, err := analyzer.ReplayRun(ctx, database, run)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("회차 %d 예측 %d: %s %s, 시드 %d\n", run.DrawNumber, run.Idx, run.Strategy, run.StrategyParams, run.Seed)
+	if run.Params != "" {
+		fmt.Printf("예측별 전략 값: %s\n", run.Params)
+	}
+	for i, set := range run.Sets {
+		regenerated := []int{}
+		if i < len(replay.Sets) {
+			regenerated = replay.Sets[i]
+		}
+		mark := "="
+		if !slices.Equal(set.Numbers, regenerated) {
+			mark = "≠"
+		}
+		if set.Bonus != nil {
+			bonus := []int{}
+			if i < len(replay.Bonus) {
+				bonus = replay.Bonus[i]
+			}
+			if !slices.Equal(set.Bonus, bonus) {
+				mark = "≠"
+			}
+			fmt.Printf("추천 %2d: %v + %v %s %v + %v\n", i+1, set.Numbers, set.Bonus, mark, regenerated, bonus)
+			continue
+		}
+		fmt.Printf("추천 %2d: %v %s %v\n", i+1, set.Numbers, mark, regenerated)
+	}
+	if !replay.Identical {
+		return fmt.Errorf("회차 %d 예측 %d: 다시 만든 세트가 저장된 세트와 다름", run.DrawNumber, run.Idx)
+	}
+	fmt.Println("저장된 세트와 동일")
+	return nil
+}
@@ -0,0 +1,45 @@
+// internal/cli/report.go
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"lottopredictor/internal/analyzer"
+)
+
+func runReport(args []string) error {
+	var opts options
+	fs := newFlagSet("report")
+	opts.bindDB(fs)
+	opts.bindOut(fs)
+	draw := fs.Int("draw", 0, "출력할 예측 대상 회차 (0이면 DB 최신 회차 + 1)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	database, err := opts.openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	ctx := context.Background()
+	drawNo := *draw
+	if drawNo == 0 {
+		latest, err := database.LatestDrawNumber(ctx)
+		if err != nil {
+			return err
+		}
+		drawNo = latest + 1
+	}
+
+	result, err := analyzer.LoadPredictionReport(ctx, database, drawNo)
+	if err != nil {
+		return err
+	}
+	if len(result.SuggestionSets) == 0 {
+		return fmt.Errorf("회차 %d 저장된 예측 없음: predict 먼저 실행", drawNo)
+	}
+	return opts.writeReports(result)
+}
@@ -0,0 +1,57 @@
+// internal/cli/run.go
+package cli
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"lottopredictor/internal/analyzer"
+)
+
+// runAll 기존 단일 실행 흐름: 동기화 → 예측 → 결과 파일 저장
+func runAll(args []string) error {
+	var opts options
+	var sf syncFlags
+	fs := newFlagSet("run")
+	opts.bindDB(fs)
+	opts.bindStrategy(fs)
+	opts.bindOut(fs)
+	opts.bindAPI(fs)
+	sf.bind(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	database, err := opts.openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	// 동기화 실패는 sync_runs에 기록하고, 이미 저장된 이력으로 예측은 계속 진행
+	if _, err := syncDraws(database, &opts, &sf); err != nil {
+		log.Printf("[Sync] 동기화 실패: %v\n", err)
+	}
+	ctx := context.Background()
+	if err := evaluatePending(ctx, database); err != nil {
+		log.Println(err)
+	}
+	if err := checkPendingTickets(ctx, database); err != nil {
+		log.Println(err)
+	}
+	latest, err := database.LatestDrawNumber(ctx)
+	if err != nil {
+		return err
+	}
+	if latest == 0 {
+		return fmt.Errorf("lotto_results가 비어 있음: 동기화된 회차 없음")
+	}
+
+	predictions, err := analyzer.Analyze(ctx, database)
+	if err != nil {
+		return err
+	}
+
+	return opts.writeReports(predictions)
+}
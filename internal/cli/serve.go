@@ -0,0 +1,68 @@
+// internal/cli/serve.go
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"lottopredictor/internal/server"
+)
+
+// shutdownTimeout 종료 신호 후 처리 중인 요청을 기다리는 최대 시간
+const shutdownTimeout = 10 * time.Second
+
+// runServe JSON API 서버. Ctrl+C / SIGTERM을 받으면 처리 중인 요청을 마치고 종료한다.
+func runServe(args []string) error {
+	var opts options
+	fs := newFlagSet("serve")
+	opts.bindDB(fs)
+	addr := fs.String("addr", "127.0.0.1:8080", "서버 주소")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	database, err := opts.openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           server.New(database),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Printf("[Serve] http://%s/api 에서 응답\n", *addr)
+		errCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("서버 실행 실패: %w", err)
+	case <-ctx.Done():
+	}
+
+	log.Printf("[Serve] 종료 중...\n")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("서버 종료 실패: %w", err)
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
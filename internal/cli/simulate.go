@@ -0,0 +1,210 @@
+// internal/cli/simulate.go
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"lottopredictor/internal/analyzer"
+	"lottopredictor/internal/common"
+	"lottopredictor/internal/db"
+	"lottopredictor/internal/game"
+	"lottopredictor/internal/simulate"
+)
+
+func runSimulate(args []string) error {
+	var opts options
+	fs := newFlagSet("simulate")
+	opts.bindDB(fs)
+	opts.bindSeed(fs)
+	var sets setsFlag
+	fs.Var(&sets, "set", "시뮬레이션할 세트, 쉼표 구분 번호 (보너스 풀이 있는 게임은 '+' 뒤에 보너스 풀 번호, 예: 1,2,3,4,5+7) (여러 번 지정 가능, 없으면 저장된 예측 세트)")
+	draw := fs.Int("draw", 0, "세트를 가져올 예측 대상 회차 (0이면 예측이 저장된 가장 최근 회차)")
+	idx := fs.Int("idx", 0, "회차 내 예측 순번 (0이면 가장 최근 예측)")
+	model := fs.String("model", simulate.ModelUniform, fmt.Sprintf("가상 추첨 모델 (%s: 균등, %s: 이력으로 추정한 번호별 확률)", simulate.ModelUniform, simulate.ModelFitted))
+	trials := fs.Int("trials", simulate.DefaultTrials, "가상 시행 수")
+	rounds := fs.Int("rounds", simulate.DefaultRounds, "시행 하나에서 같은 세트로 참여하는 회차 수")
+	workers := fs.Int("workers", 0, "병렬 작업 수 (0이면 CPU 수)")
+	var prizes paramsFlag
+	fs.Var(&prizes, "prize", "당첨금 덮어쓰기 등수=금액 (통화 최소 단위, 고정 당첨금 등수 제외) (여러 번 지정 가능, 없으면 설정 값, 1등은 설정도 없으면 이력 평균)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	database, err := opts.openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	ctx := context.Background()
+	g := database.Game()
+	tickets, bonus, baseDraw, err := simulationTickets(ctx, database, sets, *draw, *idx)
+	if err != nil {
+		return err
+	}
+
+	table, err := analyzer.PrizeTable(ctx, database, baseDraw)
+	if err != nil {
+		return err
+	}
+	for key, amount := range prizes {
+		rank, err := strconv.Atoi(key)
+		if err != nil || rank < common.RankFirst || rank > g.Ranks() {
+			return fmt.Errorf("%w: -prize 등수는 1~%d: %q", ErrUsage, g.Ranks(), key)
+		}
+		if g.FixedPrize(rank) {
+			return fmt.Errorf("%w: -prize %d등은 고정 당첨금(%s)이라 바꿀 수 없음", ErrUsage, rank, g.FormatMoney(g.Prize(rank)))
+		}
+		table[rank] = int64(amount)
+	}
+
+	simOpts := simulate.Options{
+		Game:    g,
+		Bonus:   bonus,
+		Model:   *model,
+		Trials:  *trials,
+		Rounds:  *rounds,
+		Workers: *workers,
+		Seed:    analyzer.RunSeed(),
+		Prizes:  table,
+	}
+	if *model == simulate.ModelFitted {
+		if simOpts.Weights, err = analyzer.FittedWeights(ctx, database, baseDraw); err != nil {
+			return err
+		}
+	}
+	result, err := simulate.Run(tickets, simOpts)
+	if err != nil {
+		return err
+	}
+	printSimulation(g, tickets, result)
+	return nil
+}
+
+// simulationTickets -set 세트, 없으면 저장된 예측 세트와 세트별 보너스 풀 번호, 당첨금/모델 추정 기준 회차
+func simulationTickets(ctx context.Context, store *db.Store, sets setsFlag, drawNo, idx int) ([][]int, [][]int, int, error) {
+	latest, err := store.LatestDrawNumber(ctx)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if len(sets.main) > 0 {
+		if store.Game().Bonus.Picks == 0 {
+			return sets.main, nil, latest, nil
+		}
+		return sets.main, sets.bonus, latest, nil
+	}
+
+	if drawNo == 0 {
+		if drawNo, err = store.LatestPredictionDraw(ctx); err != nil {
+			return nil, nil, 0, err
+		}
+		if drawNo == 0 {
+			return nil, nil, 0, fmt.Errorf("저장된 예측 없음: -set 으로 세트를 주거나 predict 먼저 실행")
+		}
+	}
+	var run *db.PredictionRun
+	if idx == 0 {
+		run, err = store.LatestPredictionRun(ctx, drawNo)
+	} else {
+		run, err = store.GetPredictionRun(ctx, drawNo, idx)
+	}
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	tickets := make([][]int, len(run.Sets))
+	var bonus [][]int
+	for i, set := range run.Sets {
+		tickets[i] = set.Numbers
+		if set.Bonus != nil {
+			bonus = append(bonus, set.Bonus)
+		}
+	}
+	fmt.Printf("회차 %d 예측 %d (%s) 세트 %d개\n", run.DrawNumber, run.Idx, run.Strategy, len(tickets))
+	return tickets, bonus, min(latest, run.DrawNumber-1), nil
+}
+
+func printSimulation(g *game.Game, tickets [][]int, r *simulate.Result) {
+	fmt.Printf("\n[시뮬레이션] %s 모델 %s, 세트 %d개 × %d회차, 시행 %d회 (작업 %d개, 시드 %d)\n",
+		g.Name, r.Model, r.Tickets, r.Rounds, r.Trials, r.Workers, r.Seed)
+	fmt.Print("당첨금:")
+	for rank := 1; rank <= g.Ranks(); rank++ {
+		fmt.Printf(" %d등 %s", rank, g.FormatMoney(r.Prizes[rank]))
+	}
+	fmt.Println()
+
+	plays := int64(r.Trials) * int64(r.Rounds) * int64(len(tickets))
+	fmt.Println("등수별 빈도 (세트 × 회차):")
+	for rank := 1; rank <= g.Ranks(); rank++ {
+		n := r.RankCounts[rank]
+		fmt.Printf("  %2d등: %10d (%8.5f%%, 균등 추첨 이론 %8.5f%%)\n", rank, n, float64(n)/float64(plays)*100, g.Odds(rank)*100)
+	}
+
+	price := g.FormatMoney(g.TicketPrice)
+	fmt.Printf("시행당 구매 비용: %s, 평균 당첨금: %s (표준편차 %s, ROI %.2f%%)\n", g.FormatMoney(r.Cost), g.FormatAverage(r.MeanReturn), g.FormatAverage(r.StdDev), r.ROI()*100)
+	fmt.Printf("%s당 기대 손실: %s (균등 추첨 이론값 %s)\n", price, g.FormatAverage(r.LossPerTicket), g.FormatAverage(float64(g.TicketPrice)-analyzer.ExpectedValue(g, r.Prizes)))
+	fmt.Printf("당첨금 ≥ 구매 비용 확률: %.4f%%\n", r.BreakEven*100)
+
+	fmt.Println("구매 비용 대비 당첨금 분포:")
+	for _, b := range r.Histogram(simulate.DefaultEdges) {
+		var label string
+		switch {
+		case b.ZeroOnly:
+			label = g.FormatMoney(0)
+		case b.To == 0:
+			label = fmt.Sprintf("%g배 이상", b.From)
+		case b.From == 0:
+			label = fmt.Sprintf("0 초과 ~ %g배 미만", b.To)
+		default:
+			label = fmt.Sprintf("%g ~ %g배 미만", b.From, b.To)
+		}
+		fmt.Printf("  %-18s %8.4f%%\n", label, b.Probability*100)
+	}
+	parts := []string{}
+	for _, p := range r.Percentiles {
+		parts = append(parts, fmt.Sprintf("%g%% %s", p.P*100, g.FormatMoney(p.Return)))
+	}
+	fmt.Printf("분위수: %s\n", strings.Join(parts, ", "))
+}
+
+// setsFlag 쉼표로 구분한 번호를 여러 번 받는 세트 플래그. '+' 뒤는 보너스 풀 번호
+// 번호 개수와 범위는 DB 게임을 안 뒤 시뮬레이션에서 확인한다.
+type setsFlag struct {
+	main, bonus [][]int
+}
+
+func (s *setsFlag) String() string {
+	return fmt.Sprint(s.main, s.bonus)
+}
+
+func (s *setsFlag) Set(value string) error {
+	mainPart, bonusPart, _ := strings.Cut(value, "+")
+	main, err := parseNumbers(mainPart)
+	if err != nil {
+		return err
+	}
+	bonus := []int{}
+	if bonusPart != "" {
+		if bonus, err = parseNumbers(bonusPart); err != nil {
+			return err
+		}
+	}
+	s.main = append(s.main, main)
+	s.bonus = append(s.bonus, bonus)
+	return nil
+}
+
+// parseNumbers 쉼표로 구분한 번호
+func parseNumbers(value string) ([]int, error) {
+	nums := []int{}
+	for _, part := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("번호가 잘못됨: %q", part)
+		}
+		nums = append(nums, n)
+	}
+	return nums, nil
+}
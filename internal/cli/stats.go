@@ -0,0 +1,70 @@
+// internal/cli/stats.go
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"lottopredictor/internal/analyzer"
+	"lottopredictor/internal/audit"
+)
+
+func runStatsAudit(args []string) error {
+	var opts options
+	fs := newFlagSet("stats.audit")
+	opts.bindDB(fs)
+	from := fs.Int("from", 1, "검정 시작 회차")
+	to := fs.Int("to", 0, "검정 끝 회차 (0이면 DB 최신 회차)")
+	alpha := fs.Float64("alpha", audit.DefaultAlpha, "유의수준 (다중 비교 보정 후 p값과 비교)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *alpha <= 0 || *alpha >= 1 {
+		return fmt.Errorf("%w: -alpha는 0과 1 사이여야 함", ErrUsage)
+	}
+
+	database, err := opts.openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	history, err := database.ListDraws(context.Background(), *from, *to)
+	if err != nil {
+		return err
+	}
+	if len(history) == 0 {
+		return fmt.Errorf("%d ~ %d 회차 당첨 번호가 없음", *from, *to)
+	}
+	printAudit(analyzer.AuditDraws(database.Game(), history, *alpha))
+	return nil
+}
+
+func printAudit(r *audit.Report) {
+	fmt.Printf("무작위성 검정: %d ~ %d회 (%d회차), 유의수준 %.2f, Holm 보정\n\n", r.FromDraw, r.ToDraw, r.Draws, r.Alpha)
+	for _, t := range r.Tests {
+		mark := " "
+		if t.Significant {
+			mark = "*"
+		}
+		fmt.Printf("%s %s\n", mark, t.Title)
+		if !t.Skipped {
+			df := ""
+			if t.DF > 0 {
+				df = fmt.Sprintf(", 자유도 %g", t.DF)
+			}
+			fmt.Printf("    통계량 %.3f%s, p = %.4f, 보정 p = %.4f\n", t.Statistic, df, t.PValue, t.AdjustedP)
+		}
+		fmt.Printf("    → %s\n", t.Verdict)
+	}
+
+	fmt.Printf("\n번호별 등장 횟수 편차 (|z| 상위 10, %d개 번호 Holm 보정):\n", len(r.Numbers))
+	for _, d := range r.Numbers[:min(10, len(r.Numbers))] {
+		mark := " "
+		if d.Significant {
+			mark = "*"
+		}
+		fmt.Printf("%s %2d번: %d회 (기대 %.1f), z = %+.2f, p = %.4f, 보정 p = %.4f\n", mark, d.Number, d.Count, d.Expected, d.Z, d.PValue, d.AdjustedP)
+	}
+	fmt.Printf("\n종합: %s\n", r.Verdict)
+}
@@ -0,0 +1,103 @@
+// internal/cli/sync.go
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"lottopredictor/internal/config"
+	"lottopredictor/internal/db"
+	"lottopredictor/internal/syncer"
+)
+
+// syncFlags sync / run 명령이 공유하는 동기화 옵션
+type syncFlags struct {
+	retries int
+	rps     float64
+	repair  bool
+}
+
+func (f *syncFlags) bind(fs *flag.FlagSet) {
+	fs.IntVar(&f.retries, "retries", 0, "네트워크 오류 재시도 횟수 (0이면 설정 파일 값, 기본 3)")
+	fs.Float64Var(&f.rps, "rps", 0, "초당 최대 요청 수 (0이면 설정 파일 값, 기본 2)")
+	fs.BoolVar(&f.repair, "repair", true, "저장된 회차 사이 빠진 회차 복구")
+}
+
+func runSync(args []string) error {
+	var opts options
+	var sf syncFlags
+	fs := newFlagSet("sync")
+	opts.bindDB(fs)
+	opts.bindAPI(fs)
+	sf.bind(fs)
+	history := fs.Int("history", 0, "동기화 대신 최근 N개 실행 기록 출력")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	database, err := opts.openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	if *history > 0 {
+		return printSyncHistory(database, *history)
+	}
+
+	result, err := syncDraws(database, &opts, &sf)
+	if result != nil {
+		fmt.Printf("동기화 #%d: %d개 회차 추가, %d개 회차 복구, 오류 %d건 (최신 회차 %d)\n",
+			result.RunID, len(result.Added), len(result.Repaired), len(result.Errors), result.LatestDraw)
+	}
+	// 동기화가 중간에 실패해도 이미 저장된 회차의 예측 평가와 티켓 확인은 한다
+	ctx := context.Background()
+	if evalErr := evaluatePending(ctx, database); evalErr != nil && err == nil {
+		err = evalErr
+	}
+	if checkErr := checkPendingTickets(ctx, database); checkErr != nil && err == nil {
+		err = checkErr
+	}
+	return err
+}
+
+// syncDraws 설정/플래그로 Syncer를 만들어 실행. Ctrl+C로 중단하면 지금까지 저장한 회차는 유지된다.
+func syncDraws(database *db.Store, opts *options, sf *syncFlags) (*syncer.Result, error) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	so := syncer.Options{
+		MaxRetries:        config.AppConfig.SyncMaxRetries,
+		RequestsPerSecond: config.AppConfig.SyncRequestsPerSecond,
+		RepairGaps:        sf.repair,
+	}
+	if sf.retries > 0 {
+		so.MaxRetries = sf.retries
+	}
+	if sf.rps > 0 {
+		so.RequestsPerSecond = sf.rps
+	}
+	source, err := opts.drawSource(database.Game())
+	if err != nil {
+		return nil, err
+	}
+	return syncer.New(database, source, so).Run(ctx)
+}
+
+func printSyncHistory(database *db.Store, limit int) error {
+	runs, err := database.RecentSyncRuns(context.Background(), limit)
+	if err != nil {
+		return err
+	}
+	for _, run := range runs {
+		fmt.Printf("#%d %s ~ %s [%s] 추가 %d, 복구 %d, 오류 %d\n",
+			run.ID, run.StartedAt, run.FinishedAt, run.Status, run.DrawsAdded, run.DrawsRepaired, len(run.Errors))
+		for _, e := range run.Errors {
+			fmt.Printf("    %s\n", e)
+		}
+	}
+	return nil
+}
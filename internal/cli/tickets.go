@@ -0,0 +1,304 @@
+// internal/cli/tickets.go
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"lottopredictor/internal/common"
+	"lottopredictor/internal/db"
+	"lottopredictor/internal/game"
+	"lottopredictor/internal/tickets"
+)
+
+func runTicketsAdd(args []string) error {
+	var opts options
+	fs := newFlagSet("tickets.add")
+	opts.bindDB(fs)
+	buyer := fs.String("buyer", "", "구매자 (필수)")
+	draw := fs.Int("draw", 0, "구매한 회차 (0이면 DB 최신 회차 다음 회차)")
+	strategy := fs.String("strategy", "", "번호를 고른 방법 (비어 있으면 -set은 manual, -predicted는 예측 전략 이름)")
+	cost := fs.Int64("cost", 0, "티켓 1장 구매 금액 (통화 최소 단위, 0이면 게임 1장 가격)")
+	var sets setsFlag
+	fs.Var(&sets, "set", "산 번호, 쉼표 구분 (보너스 풀이 있는 게임은 '+' 뒤에 보너스 풀 번호, 예: 1,2,3,4,5+7) (여러 번 지정 가능)")
+	predicted := fs.Bool("predicted", false, "-set 대신 -draw 회차에 저장된 예측 세트로 산 티켓 기록")
+	idx := fs.Int("idx", 0, "-predicted 예측 순번 (0이면 가장 최근 예측)")
+	pick := fs.String("pick", "", "-predicted 세트 중 산 세트 번호, 쉼표 구분 (비어 있으면 전부)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *buyer == "" {
+		return fmt.Errorf("%w: -buyer 필요", ErrUsage)
+	}
+	if len(sets.main) == 0 && !*predicted {
+		return fmt.Errorf("%w: -set 또는 -predicted 필요", ErrUsage)
+	}
+	if len(sets.main) > 0 && *predicted {
+		return fmt.Errorf("%w: -set과 -predicted는 함께 쓸 수 없음", ErrUsage)
+	}
+
+	database, err := opts.openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	ctx := context.Background()
+	drawNo := *draw
+	if drawNo == 0 {
+		latest, err := database.LatestDrawNumber(ctx)
+		if err != nil {
+			return err
+		}
+		drawNo = latest + 1
+	}
+
+	var list []db.Ticket
+	if *predicted {
+		if list, err = predictedTickets(ctx, database, drawNo, *idx, *pick); err != nil {
+			return err
+		}
+	} else {
+		for i, nums := range sets.main {
+			t := db.Ticket{Numbers: nums}
+			if database.Game().Bonus.Picks > 0 {
+				t.Bonus = sets.bonus[i]
+			}
+			list = append(list, t)
+		}
+	}
+	for i := range list {
+		list[i].DrawNumber = drawNo
+		list[i].Buyer = *buyer
+		list[i].Cost = *cost
+		if *strategy != "" {
+			list[i].Strategy = *strategy
+		}
+	}
+	if err := tickets.Add(ctx, database, list); err != nil {
+		return err
+	}
+
+	g := database.Game()
+	for _, t := range list {
+		fmt.Printf("티켓 #%d 기록: %s\n", t.ID, formatTicket(g, t))
+	}
+	// 이미 추첨이 끝난 회차면 바로 확인
+	return checkPendingTickets(ctx, database)
+}
+
+// predictedTickets drawNo 회차 예측(idx가 0이면 가장 최근)의 세트 중 pick 세트(비어 있으면 전부)를 티켓으로
+func predictedTickets(ctx context.Context, store *db.Store, drawNo, idx int, pick string) ([]db.Ticket, error) {
+	var run *db.PredictionRun
+	var err error
+	if idx == 0 {
+		run, err = store.LatestPredictionRun(ctx, drawNo)
+	} else {
+		run, err = store.GetPredictionRun(ctx, drawNo, idx)
+	}
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, fmt.Errorf("회차 %d 예측 없음: predict 먼저 실행하거나 -set 으로 번호 지정", drawNo)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	picked := map[int]bool{}
+	if pick != "" {
+		nums, err := parseNumbers(pick)
+		if err != nil {
+			return nil, fmt.Errorf("%w: -pick %v", ErrUsage, err)
+		}
+		for _, n := range nums {
+			if n > len(run.Sets) {
+				return nil, fmt.Errorf("%w: 회차 %d 예측 #%d에는 세트가 %d개뿐: %d", ErrUsage, drawNo, run.Idx, len(run.Sets), n)
+			}
+			picked[n] = true
+		}
+	}
+	list := []db.Ticket{}
+	for _, set := range run.Sets {
+		if len(picked) > 0 && !picked[set.SetIndex] {
+			continue
+		}
+		list = append(list, db.Ticket{Strategy: run.Strategy, Numbers: set.Numbers, Bonus: set.Bonus})
+	}
+	return list, nil
+}
+
+func runTicketsList(args []string) error {
+	var opts options
+	fs := newFlagSet("tickets.list")
+	opts.bindDB(fs)
+	var filter db.TicketFilter
+	fs.IntVar(&filter.DrawNumber, "draw", 0, "회차 (0이면 전체)")
+	fs.StringVar(&filter.Buyer, "buyer", "", "구매자 (비어 있으면 전체)")
+	fs.StringVar(&filter.Strategy, "strategy", "", "번호를 고른 방법 (비어 있으면 전체)")
+	fs.BoolVar(&filter.Pending, "pending", false, "당첨 확인 전 티켓만")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	database, err := opts.openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	list, err := database.ListTickets(context.Background(), filter)
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		fmt.Println("조건에 맞는 티켓 없음")
+		return nil
+	}
+	g := database.Game()
+	var cost, prize int64
+	for _, t := range list {
+		fmt.Printf("#%-5d %s\n", t.ID, formatTicket(g, t))
+		cost += t.Cost
+		if t.Check != nil {
+			prize += t.Check.Prize
+		}
+	}
+	fmt.Printf("티켓 %d장, 구매 %s, 당첨금 %s\n", len(list), g.FormatMoney(cost), g.FormatMoney(prize))
+	return nil
+}
+
+func runTicketsCheck(args []string) error {
+	var opts options
+	fs := newFlagSet("tickets.check")
+	opts.bindDB(fs)
+	draw := fs.Int("draw", 0, "확인할 회차 (0이면 당첨 번호가 있는데 확인 전 티켓이 남은 모든 회차)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	database, err := opts.openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	ctx := context.Background()
+	if *draw == 0 {
+		return checkPendingTickets(ctx, database)
+	}
+	summary, err := tickets.Check(ctx, database, *draw)
+	if errors.Is(err, db.ErrNotFound) {
+		return fmt.Errorf("회차 %d 당첨 번호 없음: sync 먼저 실행", *draw)
+	}
+	if err != nil {
+		return err
+	}
+	printTicketSummaries(database.Game(), []tickets.DrawSummary{*summary})
+	return nil
+}
+
+// checkPendingTickets 확인 전 티켓을 모두 당첨 확인하고 회차별 요약을 출력 (sync / import 직후 자동 호출)
+func checkPendingTickets(ctx context.Context, database *db.Store) error {
+	summaries, err := tickets.CheckPending(ctx, database)
+	printTicketSummaries(database.Game(), summaries)
+	if err != nil {
+		return fmt.Errorf("티켓 자동 확인 실패: %w", err)
+	}
+	if len(summaries) > 0 {
+		log.Printf("[Tickets] %d개 회차 티켓 확인 완료\n", len(summaries))
+	}
+	return nil
+}
+
+func printTicketSummaries(g *game.Game, summaries []tickets.DrawSummary) {
+	for _, s := range summaries {
+		ranks := []int{}
+		for rank := range s.RankCounts {
+			if rank != common.RankNone {
+				ranks = append(ranks, rank)
+			}
+		}
+		sort.Ints(ranks)
+		wins := []string{}
+		for _, rank := range ranks {
+			wins = append(wins, fmt.Sprintf("%d등 %d", rank, s.RankCounts[rank]))
+		}
+		if len(wins) == 0 {
+			wins = append(wins, "없음")
+		}
+		fmt.Printf("회차 %d 티켓 %d장 확인: 당첨 %s, 구매 %s, 당첨금 %s\n",
+			s.DrawNumber, s.Tickets, strings.Join(wins, ", "), g.FormatMoney(s.Cost), g.FormatMoney(s.Prize))
+		for _, t := range s.Winners {
+			fmt.Printf("  #%-5d %s\n", t.ID, formatTicket(g, t))
+		}
+	}
+}
+
+func runTicketsTotals(args []string) error {
+	var opts options
+	fs := newFlagSet("tickets.totals")
+	opts.bindDB(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	database, err := opts.openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	ctx := context.Background()
+	g := database.Game()
+	for _, group := range []struct{ by, title string }{
+		{"buyer", "구매자별"},
+		{"strategy", "전략별"},
+	} {
+		totals, err := database.TicketTotals(ctx, group.by)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("[%s]\n", group.title)
+		if len(totals) == 0 {
+			fmt.Println("  기록된 티켓 없음")
+			continue
+		}
+		var all db.TicketTotal
+		for _, t := range totals {
+			printTicketTotal(g, t)
+			all.Tickets += t.Tickets
+			all.Checked += t.Checked
+			all.Spend += t.Spend
+			all.CheckedCost += t.CheckedCost
+			all.Winnings += t.Winnings
+		}
+		all.Key = "합계"
+		printTicketTotal(g, all)
+	}
+	fmt.Println("손익/ROI는 당첨 확인한 티켓만 계산 (추첨 전 티켓은 구매 금액에만 포함)")
+	return nil
+}
+
+func printTicketTotal(g *game.Game, t db.TicketTotal) {
+	fmt.Printf("  %-12s 티켓 %4d장 (확인 %4d장), 구매 %s, 당첨금 %s, 손익 %s, ROI %.2f%%\n",
+		t.Key, t.Tickets, t.Checked, g.FormatMoney(t.Spend), g.FormatMoney(t.Winnings), g.FormatMoney(t.Net()), t.ROI()*100)
+}
+
+// formatTicket 회차, 구매자, 전략, 번호, 구매 금액과 당첨 확인 결과
+func formatTicket(g *game.Game, t db.Ticket) string {
+	nums := fmt.Sprint(t.Numbers)
+	if len(t.Bonus) > 0 {
+		nums += fmt.Sprintf(" + %v", t.Bonus)
+	}
+	result := "확인 전"
+	if c := t.Check; c != nil {
+		result = fmt.Sprintf("%d개 일치, 낙첨", c.Matched)
+		if c.Rank != common.RankNone {
+			result = fmt.Sprintf("%d개 일치, %d등 %s", c.Matched, c.Rank, g.FormatMoney(c.Prize))
+		}
+	}
+	return fmt.Sprintf("회차 %d %s (%s) %s %s | %s", t.DrawNumber, t.Buyer, t.Strategy, nums, g.FormatMoney(t.Cost), result)
+}
@@ -0,0 +1,121 @@
+// internal/cli/wheel.go
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"lottopredictor/internal/analyzer"
+	"lottopredictor/internal/db"
+	"lottopredictor/internal/wheel"
+)
+
+func runWheel(args []string) error {
+	var opts options
+	fs := newFlagSet("wheel")
+	opts.bindDB(fs)
+	poolFlag := fs.String("pool", "", "번호 풀, 쉼표 구분 (비어 있으면 등장 확률 상위 -size개)")
+	size := fs.Int("size", 12, fmt.Sprintf("자동으로 고를 풀 크기 (본 번호 수 + 1 ~ %d)", wheel.MaxPool))
+	match := fs.Int("match", 4, "보장 조건: 풀에 들어온 당첨 번호 개수")
+	hit := fs.Int("hit", 3, "보장 조건: 최소 한 세트의 일치 개수")
+	full := fs.Bool("full", false, "풀의 모든 본 번호 수 개 조합 (완전 휠)")
+	draw := fs.Int("draw", 0, "예측 대상 회차 (0이면 DB 최신 회차 + 1)")
+	save := fs.Bool("save", false, "휠 세트를 예측 대상 회차 예측으로 저장 (추첨 후 자동 평가)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	database, err := opts.openDB()
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+	g := database.Game()
+	if err := wheel.Supports(g); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	target := *draw
+	if target == 0 {
+		latest, err := database.LatestDrawNumber(ctx)
+		if err != nil {
+			return err
+		}
+		target = latest + 1
+	}
+	stats, err := analyzer.ComputeStats(ctx, database, target-1)
+	if err != nil {
+		return err
+	}
+
+	var pool []int
+	if *poolFlag != "" {
+		for _, part := range strings.Split(*poolFlag, ",") {
+			n, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				return fmt.Errorf("%w: -pool 번호가 잘못됨 %q", ErrUsage, part)
+			}
+			pool = append(pool, n)
+		}
+	} else {
+		pool = analyzer.TopProbable(stats.Probabilities, *size)
+	}
+
+	var w *wheel.Wheel
+	if *full {
+		w, err = wheel.FullWheel(g, pool)
+	} else {
+		w, err = wheel.Abbreviated(g, pool, wheel.Guarantee{Match: *match, Hit: *hit}, stats.Probabilities)
+	}
+	if err != nil {
+		return err
+	}
+	printWheel(target, w)
+
+	if !*save {
+		return nil
+	}
+	idx, err := saveWheel(ctx, database, target, w)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("회차 %d 예측 #%d로 저장\n", target, idx)
+	return nil
+}
+
+func printWheel(target int, w *wheel.Wheel) {
+	kind := "축약 휠"
+	if w.Full {
+		kind = "완전 휠"
+	}
+	fmt.Printf("회차 %d %s, 번호 풀 %d개: %v\n", target, kind, len(w.Pool), w.Pool)
+	fmt.Printf("보장: %s\n", w.Guarantee)
+	fmt.Printf("실제 추첨에서 풀에 당첨 번호 %d개 이상이 들어올 확률: %.4f%%\n", w.Guarantee.Match, w.PoolHitProbability(w.Guarantee.Match)*100)
+
+	fmt.Println("모든 경우 계산으로 확인한 보장:")
+	for _, l := range w.Levels() {
+		fmt.Printf("  풀에 당첨 번호 %d개 → 최소 %d개 일치\n", l.InPool, l.MinMatched)
+	}
+
+	for i, t := range w.Tickets {
+		fmt.Printf("세트 %3d: %v\n", i+1, t)
+	}
+	fmt.Printf("세트 %d개, 구매 비용 %s\n", len(w.Tickets), w.FormatCost())
+}
+
+// saveWheel 휠 세트를 "wheel" 전략 예측으로 저장. 풀과 보장 조건은 파라미터로 남긴다.
+func saveWheel(ctx context.Context, database *db.Store, target int, w *wheel.Wheel) (int, error) {
+	params, err := json.Marshal(map[string]any{"pool": w.Pool, "full": w.Full, "match": w.Guarantee.Match, "hit": w.Guarantee.Hit})
+	if err != nil {
+		return 0, err
+	}
+	run := &db.PredictionRun{DrawNumber: target, Strategy: "wheel", StrategyParams: string(params)}
+	for _, t := range w.Tickets {
+		run.Sets = append(run.Sets, db.PredictionSet{Numbers: t})
+	}
+	return database.SavePredictionRun(ctx, run)
+}
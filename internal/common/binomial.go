@@ -0,0 +1,14 @@
+package common
+
+// Binomial n개 중 k개를 고르는 조합 수 (k < 0 또는 k > n이면 0)
+func Binomial(n, k int) int64 {
+	if k < 0 || k > n {
+		return 0
+	}
+	k = min(k, n-k)
+	c := int64(1)
+	for i := 1; i <= k; i++ {
+		c = c * int64(n-k+i) / int64(i)
+	}
+	return c
+}
@@ -3,7 +3,6 @@ package common
 const (
 	MaxLottoNum = 45 // 1 ~ 45
 	SetSize     = 6  // 당첨번호 set
-	Lookback    = 10 // 당첨 예상 추첨번호 개수
 
 	// 예측 등수 상수 (등수별 의미)
 	RankNone   = 0
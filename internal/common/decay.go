@@ -0,0 +1,11 @@
+package common
+
+import "math"
+
+// DecayWeight age 회차 전 당첨 번호의 시간 감쇠 가중치 0.5^(age/halfLife). halfLife가 0 이하면 감쇠 없이 1
+func DecayWeight(age int, halfLife float64) float64 {
+	if halfLife <= 0 {
+		return 1
+	}
+	return math.Pow(0.5, float64(age)/halfLife)
+}
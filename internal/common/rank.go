@@ -0,0 +1,71 @@
+package common
+
+// 당첨금 (원). 4, 5등은 고정 금액, 1~3등은 회차별로 달라 평균에 가까운 기본값을 둔다.
+const (
+	TicketPrice = 1000 // 1게임 가격
+
+	PrizeFirst  = 2000000000
+	PrizeSecond = 60000000
+	PrizeThird  = 1500000
+	PrizeFourth = 50000
+	PrizeFifth  = 5000
+)
+
+// DefaultPrizes 등수별 기본 당첨금
+var DefaultPrizes = map[int]int64{
+	RankFirst:  PrizeFirst,
+	RankSecond: PrizeSecond,
+	RankThird:  PrizeThird,
+	RankFourth: PrizeFourth,
+	RankFifth:  PrizeFifth,
+}
+
+// TotalCombinations 45개 중 6개를 고르는 조합 수 (1게임이 1등일 확률의 역수)
+const TotalCombinations = 8145060
+
+// RankCombinations 등수별로 해당하는 조합 수. 확률 = RankCombinations[rank] / TotalCombinations
+var RankCombinations = map[int]int64{
+	RankFirst:  1,
+	RankSecond: 6,
+	RankThird:  228,
+	RankFourth: 11115,
+	RankFifth:  182780,
+}
+
+// Rank 추천 번호 nums를 당첨 번호 actual, 보너스 번호 bonus와 비교해
+// 일치 개수, 보너스 일치 여부, 등수를 반환한다.
+func Rank(nums []int, actual []int, bonus int) (matched int, bonusMatched bool, rank int) {
+	set := make(map[int]bool)
+	for _, n := range actual {
+		set[n] = true
+	}
+
+	for _, n := range nums {
+		if set[n] {
+			matched++
+		}
+		if n == bonus {
+			bonusMatched = true
+		}
+	}
+	return matched, bonusMatched, RankOf(matched, bonusMatched)
+}
+
+// RankOf 일치 개수와 보너스 일치 여부로 정한 등수 (Rank와 같은 규칙)
+func RankOf(matched int, bonusMatched bool) int {
+	switch matched {
+	case 6:
+		return RankFirst
+	case 5:
+		if bonusMatched {
+			return RankSecond
+		}
+		return RankThird
+	case 4:
+		return RankFourth
+	case 3:
+		return RankFifth
+	default:
+		return RankNone
+	}
+}
@@ -2,15 +2,50 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
 	"os"
+
+	"lottopredictor/internal/bayes"
+	"lottopredictor/internal/constraint"
+	"lottopredictor/internal/game"
+	"lottopredictor/internal/portfolio"
+	"lottopredictor/internal/recency"
 )
 
 type Config struct {
+	Game string `json:"game"` // 게임 id lotto645, lotto649, euromillions, powerball (비어 있으면 DB에 기록된 게임, 새 DB는 lotto645)
+
 	SuggestionSetCount int     `json:"suggestion_set_count"`
 	LookbackRounds     int     `json:"lookback_rounds"`
 	GAPBoostMultiplier float64 `json:"gap_boost_multiplier"` // 확률 계산에 영향 (보정 가중치)
 	GapThreshold       int     `json:"gap_threshold"`        // 분석 통계에 영향 (미등장 번호 표시용)
+
+	Strategy       string             `json:"strategy"`        // 예측 전략 이름 (비어 있으면 frequency_gap)
+	StrategyParams map[string]float64 `json:"strategy_params"` // 전략별 파라미터, 없는 값은 전략 기본값 사용
+
+	Constraints constraint.Rules `json:"constraints"` // 추천 세트 조건 (합계, 홀짝, 고저, 연속 번호, AC값, 포함/제외 번호, 이전 1등 조합 제외)
+
+	Recency recency.Options `json:"recency"` // 시간 감쇠 출현 확률 (half_life: 반감기, horizons: 최근 회차 수 목록, 0은 전체)
+
+	Bayes bayes.Options `json:"bayes"` // 베이즈 번호별 확률 추정 (prior: 사전 모수, half_life: 시간 감쇠 반감기)
+
+	PairAffinity float64 `json:"pair_affinity"` // 세트 생성 시 번호 쌍 동시 출현 반영 강도 (0이면 사용 안 함, 음수면 드문 쌍 선호)
+
+	Seed int64 `json:"seed"` // 추천 세트 난수 시드 (0이면 실행마다 새로 만들고 prediction_meta에 기록)
+
+	Portfolio portfolio.Options `json:"portfolio"` // 추천 세트를 함께 고르는 최적화 (objective: hit, coverage, 비어 있으면 사용 안 함)
+
+	Prizes map[string]map[int]int64 `json:"prizes"` // 게임 id별 등수별 당첨금 덮어쓰기 (게임 통화 최소 단위, 로또 6/45는 원), 없는 등수는 게임 기본 당첨금, 고정 당첨금 등수는 지정 불가
+
+	APIBaseURL        string `json:"api_base_url"`        // 당첨 번호 API 주소 (비어 있으면 동행복권)
+	APITimeoutSeconds int    `json:"api_timeout_seconds"` // 요청당 타임아웃 (0이면 기본 10초)
+
+	SyncMaxRetries        int     `json:"sync_max_retries"`         // 동기화 네트워크 오류 재시도 횟수 (0이면 기본 3)
+	SyncRequestsPerSecond float64 `json:"sync_requests_per_second"` // 동기화 초당 최대 요청 수 (0이면 기본 2)
+
+	OutputFormats  []string `json:"output_formats"`  // 결과 파일 형식 txt, html, json, csv, markdown, xlsx (비어 있으면 html, txt)
+	OutputFilename string   `json:"output_filename"` // 결과 파일 이름 템플릿 {draw}, {strategy} (비어 있으면 lotto_analysis_{draw})
 }
 
 var AppConfig Config
@@ -28,3 +63,30 @@ func LoadConfig(path string) {
 		log.Fatalf("설정 파일 파싱 실패: %v", err)
 	}
 }
+
+// CheckPrizes g 게임의 당첨금 설정 확인. 없는 등수나 게임 정의상 고정 당첨금인 등수를 덮어쓰면 오류
+func CheckPrizes(g *game.Game) error {
+	for rank, v := range AppConfig.Prizes[g.ID] {
+		if rank < 1 || rank > g.Ranks() {
+			return fmt.Errorf("%s 당첨금 설정: 없는 등수 %d (1~%d)", g.ID, rank, g.Ranks())
+		}
+		if g.FixedPrize(rank) {
+			return fmt.Errorf("%s 당첨금 설정: %d등은 고정 당첨금(%s)이라 바꿀 수 없음", g.ID, rank, g.FormatMoney(g.Prize(rank)))
+		}
+		if v < 0 {
+			return fmt.Errorf("%s 당첨금 설정: %d등 금액이 음수: %d", g.ID, rank, v)
+		}
+	}
+	return nil
+}
+
+// PrizeAmount g 게임 등수별 당첨금. 변동 당첨금 등수는 그 게임의 설정 값이 있으면 우선, 고정 당첨금 등수는 게임 정의 금액, 낙첨은 0
+func PrizeAmount(g *game.Game, rank int) int64 {
+	if rank == 0 {
+		return 0
+	}
+	if v, ok := AppConfig.Prizes[g.ID][rank]; ok && !g.FixedPrize(rank) {
+		return v
+	}
+	return g.Prize(rank)
+}
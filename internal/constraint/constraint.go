@@ -0,0 +1,303 @@
+// internal/constraint/constraint.go
+package constraint
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"lottopredictor/internal/game"
+)
+
+// Range 최소 ~ 최대 (둘 다 포함)
+type Range struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+func (r *Range) contains(v int) bool { return r == nil || (v >= r.Min && v <= r.Max) }
+
+func (r *Range) String() string { return fmt.Sprintf("%d~%d", r.Min, r.Max) }
+
+// Rules 추천 세트가 지켜야 할 조건. 값이 없는(nil, 0, 빈) 규칙은 검사하지 않는다.
+// 번호 풀은 For로 정하고, 정하지 않으면 로또 6/45 (1~45 중 6개)
+type Rules struct {
+	Sum                *Range `json:"sum,omitempty"`             // 번호 합계
+	Odd                *Range `json:"odd,omitempty"`             // 홀수 개수 (짝수 = 세트 크기 - 홀수)
+	High               *Range `json:"high,omitempty"`            // 고번호(6/45는 23 이상) 개수 (저번호 = 세트 크기 - 고번호)
+	MaxConsecutive     int    `json:"max_consecutive,omitempty"` // 연속 번호 최대 길이 (1이면 연속 번호 없음)
+	MinAC              int    `json:"min_ac,omitempty"`          // AC값(번호 차이 종류 수 - 5) 최소
+	Include            []int  `json:"include,omitempty"`         // 반드시 포함할 번호
+	Exclude            []int  `json:"exclude,omitempty"`         // 제외할 번호
+	ExcludePastWinners bool   `json:"exclude_past_winners,omitempty"`
+
+	pool game.Pool
+}
+
+// For 같은 규칙을 p 번호 풀에 적용한 복사본 (r이 nil이면 규칙 없음)
+func (r *Rules) For(p game.Pool) *Rules {
+	c := Rules{}
+	if r != nil {
+		c = *r
+	}
+	c.pool = p
+	return &c
+}
+
+// Pool 규칙을 적용하는 번호 풀
+func (r *Rules) Pool() game.Pool {
+	if r == nil || r.pool.Max == 0 {
+		return game.Lotto645.Main
+	}
+	return r.pool
+}
+
+// highStart 고번호 시작 (6/45는 1~22 저번호, 23~45 고번호)
+func (r *Rules) highStart() int { return r.Pool().Max/2 + 1 }
+
+// Rule 이름 (위반 보고, -rule 플래그 키)
+const (
+	RuleSum            = "sum"
+	RuleOdd            = "odd"
+	RuleHigh           = "high"
+	RuleMaxConsecutive = "max_consecutive"
+	RuleMinAC          = "min_ac"
+	RuleInclude        = "include"
+	RuleExclude        = "exclude"
+	RulePastWinner     = "exclude_past_winners"
+)
+
+// Violation 세트가 어긴 규칙 하나
+type Violation struct {
+	Rule   string `json:"rule"`
+	Detail string `json:"detail"`
+}
+
+func (v Violation) String() string { return v.Rule + ": " + v.Detail }
+
+// IsZero 검사할 규칙이 하나도 없는지
+func (r *Rules) IsZero() bool {
+	return r == nil || (r.Sum == nil && r.Odd == nil && r.High == nil && r.MaxConsecutive == 0 && r.MinAC == 0 &&
+		len(r.Include) == 0 && len(r.Exclude) == 0 && !r.ExcludePastWinners)
+}
+
+// Set key=value 하나를 규칙에 반영한다. (-rule 플래그용)
+// 범위는 "100-170", 번호 목록은 "3,7,12", 불리언은 true/false
+func (r *Rules) Set(key, value string) error {
+	var err error
+	switch key {
+	case RuleSum:
+		r.Sum, err = parseRange(value)
+	case RuleOdd:
+		r.Odd, err = parseRange(value)
+	case RuleHigh:
+		r.High, err = parseRange(value)
+	case RuleMaxConsecutive:
+		r.MaxConsecutive, err = strconv.Atoi(value)
+	case RuleMinAC:
+		r.MinAC, err = strconv.Atoi(value)
+	case RuleInclude:
+		r.Include, err = parseNumbers(value)
+	case RuleExclude:
+		r.Exclude, err = parseNumbers(value)
+	case RulePastWinner:
+		r.ExcludePastWinners, err = strconv.ParseBool(value)
+	default:
+		return fmt.Errorf("알 수 없는 규칙 %q (사용 가능: %s)", key, strings.Join(Names(), ", "))
+	}
+	if err != nil {
+		return fmt.Errorf("규칙 %s 값 %q 해석 실패: %w", key, value, err)
+	}
+	return nil
+}
+
+// Names 규칙 이름 목록
+func Names() []string {
+	return []string{RuleSum, RuleOdd, RuleHigh, RuleMaxConsecutive, RuleMinAC, RuleInclude, RuleExclude, RulePastWinner}
+}
+
+func parseRange(s string) (*Range, error) {
+	lo, hi, ok := strings.Cut(s, "-")
+	if !ok {
+		hi = lo
+	}
+	min, err := strconv.Atoi(strings.TrimSpace(lo))
+	if err != nil {
+		return nil, err
+	}
+	max, err := strconv.Atoi(strings.TrimSpace(hi))
+	if err != nil {
+		return nil, err
+	}
+	return &Range{Min: min, Max: max}, nil
+}
+
+func parseNumbers(s string) ([]int, error) {
+	nums := []int{}
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, err
+		}
+		nums = append(nums, n)
+	}
+	return nums, nil
+}
+
+// Validate 규칙 값 범위와 서로 모순되는 규칙을 확인한다.
+func (r *Rules) Validate() error {
+	if r == nil {
+		return nil
+	}
+	p := r.Pool()
+	for _, c := range []struct {
+		name   string
+		rng    *Range
+		lo, hi int
+	}{
+		{RuleSum, r.Sum, 0, p.Max * p.Picks},
+		{RuleOdd, r.Odd, 0, p.Picks},
+		{RuleHigh, r.High, 0, p.Picks},
+	} {
+		if c.rng != nil && (c.rng.Min > c.rng.Max || c.rng.Min < c.lo || c.rng.Max > c.hi) {
+			return fmt.Errorf("규칙 %s 범위 %s가 잘못됨 (%d~%d 안에서 최소 <= 최대)", c.name, c.rng, c.lo, c.hi)
+		}
+	}
+	if r.MaxConsecutive < 0 || r.MaxConsecutive > p.Picks {
+		return fmt.Errorf("규칙 %s 값 %d가 잘못됨 (0~%d)", RuleMaxConsecutive, r.MaxConsecutive, p.Picks)
+	}
+	if maxAC := p.Picks*(p.Picks-1)/2 - (p.Picks - 1); r.MinAC < 0 || r.MinAC > maxAC {
+		return fmt.Errorf("규칙 %s 값 %d가 잘못됨 (0~%d)", RuleMinAC, r.MinAC, maxAC)
+	}
+	for _, list := range [][]int{r.Include, r.Exclude} {
+		for _, n := range list {
+			if n < 1 || n > p.Max {
+				return fmt.Errorf("번호 %d가 1~%d 범위를 벗어남", n, p.Max)
+			}
+		}
+	}
+	if len(r.Include) > p.Picks {
+		return fmt.Errorf("포함 번호 %d개가 세트 크기 %d를 넘음", len(r.Include), p.Picks)
+	}
+	for _, n := range r.Include {
+		if slices.Contains(r.Exclude, n) {
+			return fmt.Errorf("번호 %d가 포함/제외 규칙에 모두 있음", n)
+		}
+	}
+	if p.Max-len(r.Exclude) < p.Picks {
+		return fmt.Errorf("제외 번호가 너무 많음: 남은 번호 %d개", p.Max-len(r.Exclude))
+	}
+	if !newBuilder(r, nil).feasible() {
+		return fmt.Errorf("포함/제외 번호와 합계, 홀짝, 고저, 연속 번호 규칙을 함께 만족하는 세트가 없음")
+	}
+	return nil
+}
+
+// Check 세트가 어긴 규칙 목록. pastWinner는 이전 1등 번호 조합인지 확인하는 함수 (nil이면 검사 생략)
+func (r *Rules) Check(nums []int, pastWinner func([]int) bool) []Violation {
+	if r.IsZero() {
+		return nil
+	}
+	sorted := slices.Sorted(slices.Values(nums))
+	vs := []Violation{}
+	if s := sum(sorted); !r.Sum.contains(s) {
+		vs = append(vs, Violation{RuleSum, fmt.Sprintf("합계 %d (허용 %s)", s, r.Sum)})
+	}
+	if odd := countOdd(sorted); !r.Odd.contains(odd) {
+		vs = append(vs, Violation{RuleOdd, fmt.Sprintf("홀수 %d개 (허용 %s)", odd, r.Odd)})
+	}
+	if high := countHigh(sorted, r.highStart()); !r.High.contains(high) {
+		vs = append(vs, Violation{RuleHigh, fmt.Sprintf("고번호 %d개 (허용 %s)", high, r.High)})
+	}
+	if run := longestRun(sorted); r.MaxConsecutive > 0 && run > r.MaxConsecutive {
+		vs = append(vs, Violation{RuleMaxConsecutive, fmt.Sprintf("연속 번호 %d개 (최대 %d)", run, r.MaxConsecutive)})
+	}
+	if ac := ACValue(sorted); ac < r.MinAC {
+		vs = append(vs, Violation{RuleMinAC, fmt.Sprintf("AC값 %d (최소 %d)", ac, r.MinAC)})
+	}
+	for _, n := range r.Include {
+		if !slices.Contains(sorted, n) {
+			vs = append(vs, Violation{RuleInclude, fmt.Sprintf("번호 %d 없음", n)})
+		}
+	}
+	for _, n := range r.Exclude {
+		if slices.Contains(sorted, n) {
+			vs = append(vs, Violation{RuleExclude, fmt.Sprintf("번호 %d 포함", n)})
+		}
+	}
+	if r.ExcludePastWinners && pastWinner != nil && pastWinner(sorted) {
+		vs = append(vs, Violation{RulePastWinner, "이전 1등 당첨 번호와 같음"})
+	}
+	return vs
+}
+
+// ACValue 번호 쌍 차이의 서로 다른 값 개수 - (세트 크기 - 1). 번호가 고르게 흩어질수록 크다.
+func ACValue(nums []int) int {
+	diffs := map[int]bool{}
+	for i := range nums {
+		for j := i + 1; j < len(nums); j++ {
+			d := nums[i] - nums[j]
+			if d < 0 {
+				d = -d
+			}
+			diffs[d] = true
+		}
+	}
+	return max(0, len(diffs)-(len(nums)-1))
+}
+
+func sum(nums []int) int {
+	s := 0
+	for _, n := range nums {
+		s += n
+	}
+	return s
+}
+
+func countOdd(nums []int) int {
+	c := 0
+	for _, n := range nums {
+		if n%2 == 1 {
+			c++
+		}
+	}
+	return c
+}
+
+func countHigh(nums []int, highStart int) int {
+	c := 0
+	for _, n := range nums {
+		if n >= highStart {
+			c++
+		}
+	}
+	return c
+}
+
+// longestRun 정렬된 번호에서 가장 긴 연속 번호 길이
+func longestRun(sorted []int) int {
+	best, run := 0, 0
+	for i, n := range sorted {
+		if i > 0 && n == sorted[i-1]+1 {
+			run++
+		} else {
+			run = 1
+		}
+		best = max(best, run)
+	}
+	return best
+}
+
+// Key 번호 조합을 비교용 문자열로 ("1,2,3,4,5,6")
+func Key(nums []int) string {
+	sorted := slices.Sorted(slices.Values(nums))
+	parts := make([]string, len(sorted))
+	for i, n := range sorted {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,204 @@
+// internal/constraint/sample.go
+package constraint
+
+import (
+	"slices"
+)
+
+// maxAttempts 막다른 선택으로 세트를 끝내지 못했을 때 처음부터 다시 뽑는 최대 횟수
+const maxAttempts = 100
+
+// Sample 번호별 가중치로 한 번호씩 뽑아 규칙을 만족하는 세트를 만든다.
+// 매 단계에서 남은 번호로 규칙을 지킬 수 없게 되는 후보는 미리 제외하고(합계/홀짝/고저 범위, 연속 번호),
+// 마지막 번호는 세트 전체 검사(AC값, 이전 1등 조합 포함)를 통과하는 후보 중에서만 고르므로
+// 다 뽑은 뒤 버리고 다시 뽑는 일은 거의 없다.
+// 끝내 만족하는 세트를 찾지 못하면 위반이 가장 적은 세트와 위반 규칙을 반환한다.
+func (r *Rules) Sample(weights map[int]float64, random func() float64, pastWinner func([]int) bool) ([]int, []Violation) {
+	return r.SampleAffinity(weights, nil, random, pastWinner)
+}
+
+// Affinity 이미 고른 번호에 따라 후보 n의 가중치에 곱하는 값 (번호 쌍 동시 출현 등)
+type Affinity func(chosen []int, n int) float64
+
+// SampleAffinity Sample과 같지만 두 번째 번호부터 affinity를 가중치에 곱한다. affinity가 nil이면 Sample과 같다.
+func (r *Rules) SampleAffinity(weights map[int]float64, affinity Affinity, random func() float64, pastWinner func([]int) bool) ([]int, []Violation) {
+	if r.IsZero() {
+		return newBuilder(r, nil).build(weights, affinity, random), nil
+	}
+	var best []int
+	var bestViolations []Violation
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		set := newBuilder(r, pastWinner).build(weights, affinity, random)
+		if set == nil {
+			continue
+		}
+		vs := r.Check(set, pastWinner)
+		if len(vs) == 0 {
+			return set, nil
+		}
+		if best == nil || len(vs) < len(bestViolations) {
+			best, bestViolations = set, vs
+		}
+	}
+	if best == nil {
+		best = newBuilder(r.clear(), nil).build(weights, affinity, random)
+		bestViolations = r.Check(best, pastWinner)
+	}
+	return best, bestViolations
+}
+
+// clear 번호 풀만 남기고 규칙을 모두 지운다.
+func (r *Rules) clear() *Rules {
+	return &Rules{pool: r.pool}
+}
+
+// builder 규칙을 지키며 번호를 하나씩 추가하는 세트
+type builder struct {
+	rules      *Rules
+	pastWinner func([]int) bool
+	pool       int // 번호 풀 최대 번호
+	picks      int // 세트 크기
+	chosen     []int
+	in         []bool // index = 번호
+	banned     []bool
+}
+
+// newBuilder r이 nil이면 규칙 없이 로또 6/45 풀
+func newBuilder(r *Rules, pastWinner func([]int) bool) *builder {
+	if r == nil {
+		r = &Rules{}
+	}
+	p := r.Pool()
+	b := &builder{rules: r, pastWinner: pastWinner, pool: p.Max, picks: p.Picks,
+		in: make([]bool, p.Max+1), banned: make([]bool, p.Max+1)}
+	for _, n := range r.Exclude {
+		b.banned[n] = true
+	}
+	for _, n := range r.Include {
+		if !b.in[n] {
+			b.add(n)
+		}
+	}
+	return b
+}
+
+func (b *builder) add(n int) {
+	b.in[n] = true
+	b.chosen = append(b.chosen, n)
+}
+
+func (b *builder) pop() {
+	n := b.chosen[len(b.chosen)-1]
+	b.in[n] = false
+	b.chosen = b.chosen[:len(b.chosen)-1]
+}
+
+// build 가중치 비례로 번호를 뽑아 정렬된 세트를 반환. 더 고를 후보가 없으면 nil
+func (b *builder) build(weights map[int]float64, affinity Affinity, random func() float64) []int {
+	check := !b.rules.IsZero()
+	for len(b.chosen) < b.picks {
+		cands, ws := []int{}, []float64{}
+		total := 0.0
+		for n := 1; n <= b.pool; n++ {
+			if b.in[n] || b.banned[n] {
+				continue
+			}
+			if check && !b.canAdd(n) {
+				continue
+			}
+			w := max(weights[n], 0)
+			if affinity != nil && len(b.chosen) > 0 {
+				w *= affinity(b.chosen, n)
+			}
+			cands = append(cands, n)
+			ws = append(ws, w)
+			total += w
+		}
+		if len(cands) == 0 {
+			return nil
+		}
+		b.add(pick(cands, ws, total, random))
+	}
+	return slices.Sorted(slices.Values(b.chosen))
+}
+
+// pick 가중치 비례 추출. 후보 가중치가 모두 0이면 균등 추출
+func pick(cands []int, ws []float64, total float64, random func() float64) int {
+	if total <= 0 {
+		return cands[min(int(random()*float64(len(cands))), len(cands)-1)]
+	}
+	r := random() * total
+	acc := 0.0
+	for i, n := range cands {
+		acc += ws[i]
+		if r < acc {
+			return n
+		}
+	}
+	// 부동소수점 오차로 끝까지 온 경우 마지막 양수 가중치 후보
+	for i := len(cands) - 1; i >= 0; i-- {
+		if ws[i] > 0 {
+			return cands[i]
+		}
+	}
+	return cands[len(cands)-1]
+}
+
+func (b *builder) canAdd(n int) bool {
+	b.add(n)
+	ok := b.feasible()
+	b.pop()
+	return ok
+}
+
+// feasible 지금까지 고른 번호에 남은 번호를 더해 규칙을 지킬 수 있는지.
+// 세트가 다 찼으면 전체 검사, 아니면 각 규칙별로 남은 번호로 도달 가능한 최소/최대를 비교한다.
+func (b *builder) feasible() bool {
+	r := b.rules
+	k := b.picks - len(b.chosen)
+	if k < 0 {
+		return false
+	}
+	if k == 0 {
+		return len(r.Check(b.chosen, b.pastWinner)) == 0
+	}
+	if r.MaxConsecutive > 0 && longestRun(slices.Sorted(slices.Values(b.chosen))) > r.MaxConsecutive {
+		return false
+	}
+
+	avail := []int{}
+	availOdd, availHigh := 0, 0
+	highStart := r.highStart()
+	for n := 1; n <= b.pool; n++ {
+		if b.in[n] || b.banned[n] {
+			continue
+		}
+		avail = append(avail, n)
+		if n%2 == 1 {
+			availOdd++
+		}
+		if n >= highStart {
+			availHigh++
+		}
+	}
+	if len(avail) < k {
+		return false
+	}
+	if r.Sum != nil {
+		s := sum(b.chosen)
+		lo, hi := sum(avail[:k]), sum(avail[len(avail)-k:])
+		if s+lo > r.Sum.Max || s+hi < r.Sum.Min {
+			return false
+		}
+	}
+	return countFeasible(r.Odd, countOdd(b.chosen), k, availOdd, len(avail)-availOdd) &&
+		countFeasible(r.High, countHigh(b.chosen, highStart), k, availHigh, len(avail)-availHigh)
+}
+
+// countFeasible 현재 c개에서 남은 k개를 (해당 yes개, 아닌 no개) 중에 골라 범위 안에 들 수 있는지
+func countFeasible(rng *Range, c, k, yes, no int) bool {
+	if rng == nil {
+		return true
+	}
+	return c+min(k, yes) >= rng.Min && c+max(0, k-no) <= rng.Max
+}
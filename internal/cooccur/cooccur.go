@@ -0,0 +1,170 @@
+// internal/cooccur/cooccur.go
+package cooccur
+
+import (
+	"math"
+	"sort"
+
+	"lottopredictor/internal/common"
+	"lottopredictor/internal/game"
+)
+
+// pairProb 무작위 추첨에서 특정 번호 쌍이 한 회차에 함께 나올 확률
+func pairProb(p game.Pool) float64 {
+	return float64(p.Picks*(p.Picks-1)) / float64(p.Max*(p.Max-1))
+}
+
+// tripleProb 무작위 추첨에서 특정 세 번호가 한 회차에 함께 나올 확률
+func tripleProb(p game.Pool) float64 {
+	return pairProb(p) * float64(p.Picks-2) / float64(p.Max-2)
+}
+
+// DefaultTop 상위/하위 번호 쌍, 세 번호 조합 표시 개수
+const DefaultTop = 10
+
+// Pair 번호 쌍(A < B)의 동시 출현 횟수와 독립 가정 대비 비율.
+// Lift = 횟수 / 기대 횟수, Z = (횟수 - 기대) / 표준편차 (이항 근사)
+type Pair struct {
+	A        int     `json:"a"`
+	B        int     `json:"b"`
+	Count    int     `json:"count"`
+	Expected float64 `json:"expected"`
+	Lift     float64 `json:"lift"`
+	Z        float64 `json:"z"`
+}
+
+// Triple 세 번호 조합(오름차순)의 동시 출현 횟수
+type Triple struct {
+	Numbers  [3]int  `json:"numbers"`
+	Count    int     `json:"count"`
+	Expected float64 `json:"expected"`
+	Lift     float64 `json:"lift"`
+	Z        float64 `json:"z"`
+}
+
+// Matrix 번호 쌍 동시 출현 횟수 (풀 크기 × 풀 크기 대칭, 6/45는 45×45)
+type Matrix struct {
+	Draws  int
+	pool   game.Pool
+	counts []int // index = a*(Max+1) + b
+}
+
+// NewMatrix p 번호 풀의 당첨 번호 목록으로 번호 쌍 행렬을 만든다.
+func NewMatrix(draws [][]int, p game.Pool) *Matrix {
+	n := p.Max + 1
+	m := &Matrix{Draws: len(draws), pool: p, counts: make([]int, n*n)}
+	for _, d := range draws {
+		for i, a := range d {
+			for _, b := range d[i+1:] {
+				m.counts[a*n+b]++
+				m.counts[b*n+a]++
+			}
+		}
+	}
+	return m
+}
+
+// Count a, b가 함께 나온 회차 수
+func (m *Matrix) Count(a, b int) int { return m.counts[a*(m.pool.Max+1)+b] }
+
+// Pair a, b 쌍의 통계
+func (m *Matrix) Pair(a, b int) Pair {
+	a, b = min(a, b), max(a, b)
+	p := Pair{A: a, B: b, Count: m.Count(a, b)}
+	p.Expected, p.Lift, p.Z = compare(p.Count, m.Draws, pairProb(m.pool))
+	return p
+}
+
+// Pairs 모든 번호 쌍 (A, B 순, 6/45는 990개)
+func (m *Matrix) Pairs() []Pair {
+	pairs := make([]Pair, 0, m.pool.Max*(m.pool.Max-1)/2)
+	for a := 1; a <= m.pool.Max; a++ {
+		for b := a + 1; b <= m.pool.Max; b++ {
+			pairs = append(pairs, m.Pair(a, b))
+		}
+	}
+	return pairs
+}
+
+// Affinity 이미 고른 번호와 n의 동시 출현 가중치. 각 쌍의 평활화한 lift((횟수+1)/(기대+1))를 곱한 값의 strength 제곱.
+// strength가 0이면 1, 양수면 함께 자주 나온 번호를, 음수면 함께 드물게 나온 번호를 선호한다.
+func (m *Matrix) Affinity(chosen []int, n int, strength float64) float64 {
+	if strength == 0 || len(chosen) == 0 {
+		return 1
+	}
+	expected := float64(m.Draws) * pairProb(m.pool)
+	logSum := 0.0
+	for _, c := range chosen {
+		logSum += math.Log((float64(m.Count(c, n)) + 1) / (expected + 1))
+	}
+	return math.Exp(strength * logSum)
+}
+
+// Analysis 번호 쌍 전체와 자주/드물게 함께 나온 쌍, 세 번호 조합
+type Analysis struct {
+	Draws         int      `json:"draws"`
+	Pairs         []Pair   `json:"pairs"` // 모든 번호 쌍 (A, B 순)
+	TopPairs      []Pair   `json:"top_pairs"`
+	BottomPairs   []Pair   `json:"bottom_pairs"`
+	TopTriples    []Triple `json:"top_triples"`
+	BottomTriples []Triple `json:"bottom_triples"`
+}
+
+// Analyze p 번호 풀에서 번호 쌍과 세 번호 조합의 동시 출현을 독립 추첨 기대값과 비교한다.
+// 상위/하위는 z값 기준으로 top개씩 고르고, 같은 z값이면 번호 순이다.
+func Analyze(draws [][]int, p game.Pool, top int) *Analysis {
+	m := NewMatrix(draws, p)
+	a := &Analysis{Draws: len(draws), Pairs: m.Pairs()}
+
+	sorted := append([]Pair(nil), a.Pairs...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Z > sorted[j].Z })
+	a.TopPairs = append([]Pair(nil), sorted[:min(top, len(sorted))]...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Z < sorted[j].Z })
+	a.BottomPairs = append([]Pair(nil), sorted[:min(top, len(sorted))]...)
+
+	triples := countTriples(draws, p)
+	sort.SliceStable(triples, func(i, j int) bool { return triples[i].Z > triples[j].Z })
+	a.TopTriples = append([]Triple(nil), triples[:min(top, len(triples))]...)
+	sort.SliceStable(triples, func(i, j int) bool { return triples[i].Z < triples[j].Z })
+	a.BottomTriples = append([]Triple(nil), triples[:min(top, len(triples))]...)
+	return a
+}
+
+// countTriples 모든 세 번호 조합(6/45는 14,190개)의 동시 출현 통계 (번호 순)
+func countTriples(draws [][]int, p game.Pool) []Triple {
+	n := p.Max + 1
+	counts := make([]int, n*n*n)
+	for _, d := range draws {
+		d = append([]int(nil), d...)
+		sort.Ints(d)
+		for i, a := range d {
+			for j := i + 1; j < len(d); j++ {
+				for _, c := range d[j+1:] {
+					counts[(a*n+d[j])*n+c]++
+				}
+			}
+		}
+	}
+	triples := make([]Triple, 0, common.Binomial(p.Max, 3))
+	for a := 1; a <= p.Max; a++ {
+		for b := a + 1; b <= p.Max; b++ {
+			for c := b + 1; c <= p.Max; c++ {
+				t := Triple{Numbers: [3]int{a, b, c}, Count: counts[(a*n+b)*n+c]}
+				t.Expected, t.Lift, t.Z = compare(t.Count, len(draws), tripleProb(p))
+				triples = append(triples, t)
+			}
+		}
+	}
+	return triples
+}
+
+// compare draws회 중 확률 p로 기대되는 횟수와 lift, z값. 회차가 없으면 lift 1, z 0
+func compare(count, draws int, p float64) (expected, lift, z float64) {
+	expected = float64(draws) * p
+	if draws == 0 {
+		return 0, 1, 0
+	}
+	lift = float64(count) / expected
+	z = (float64(count) - expected) / math.Sqrt(expected*(1-p))
+	return expected, lift, z
+}
@@ -0,0 +1,113 @@
+// db/backtest.go
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// BacktestRun 백테스트 1회 실행 요약 (backtest_runs 행)
+type BacktestRun struct {
+	Strategy       string
+	StrategyParams string
+	Seed           int64 // 추천 세트 난수 시드
+	BaseSeed       int64 // 실행에 준 기준 시드 (backtest -seed로 같은 실행을 다시 만든다)
+	FromDraw       int
+	ToDraw         int
+	SetsPerDraw    int
+	TotalSets      int
+	TotalPrize     int64
+	ExpectedValue  float64 // 세트당 평균 당첨금
+}
+
+// BacktestResult 백테스트에서 생성/평가된 추천 세트 1개 (backtest_results 행)
+type BacktestResult struct {
+	DrawNumber   int
+	SetIndex     int
+	Numbers      []int
+	Bonus        []int // 보너스 풀 번호 (보너스 풀이 있는 게임만)
+	Matched      int
+	BonusMatched bool
+	BonusMatches int // 일치한 보너스 풀 번호 수
+	Rank         int
+	Prize        int64
+	Params       string // 이 회차 예측에서 전략이 정한 값 JSON (없으면 빈 문자열)
+}
+
+// SaveBacktestRun 실행 요약과 세트별 결과를 한 트랜잭션으로 저장하고 run id를 반환
+func (s *Store) SaveBacktestRun(ctx context.Context, run *BacktestRun, results []BacktestResult) (int64, error) {
+	var runID int64
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, `
+			INSERT INTO backtest_runs
+			(strategy, strategy_params, seed, base_seed, from_draw, to_draw, sets_per_draw, total_sets, total_prize, expected_value, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, datetime('now'))`,
+			run.Strategy, run.StrategyParams, run.Seed, run.BaseSeed, run.FromDraw, run.ToDraw, run.SetsPerDraw,
+			run.TotalSets, run.TotalPrize, run.ExpectedValue)
+		if err != nil {
+			return err
+		}
+		if runID, err = res.LastInsertId(); err != nil {
+			return err
+		}
+
+		stmt, err := tx.PrepareContext(ctx, `
+			INSERT INTO backtest_results
+			(run_id, draw_number, set_index, num1, num2, num3, num4, num5, num6, bonus_numbers,
+				matched, bonus_matched, bonus_matches, rank, prize, prediction_params)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		g := s.Game()
+		for _, r := range results {
+			if len(r.Numbers) != g.Main.Picks || len(r.Bonus) != g.Bonus.Picks {
+				return fmt.Errorf("invalid set length: %v %v", r.Numbers, r.Bonus)
+			}
+			args := append([]any{runID, r.DrawNumber, r.SetIndex}, numberArgs(r.Numbers)...)
+			args = append(args, joinNumbers(r.Bonus), r.Matched, r.BonusMatched, r.BonusMatches, r.Rank, r.Prize, r.Params)
+			_, err := stmt.ExecContext(ctx, args...)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return runID, nil
+}
+
+// BacktestResults run id의 세트별 결과를 회차, 세트 순으로 반환
+func (s *Store) BacktestResults(ctx context.Context, runID int64) ([]BacktestResult, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT draw_number, set_index, num1, num2, num3, num4, num5, num6, bonus_numbers,
+			matched, bonus_matched, COALESCE(bonus_matches, 0), rank, prize, COALESCE(prediction_params, '')
+		FROM backtest_results
+		WHERE run_id = ?
+		ORDER BY draw_number, set_index`, runID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []BacktestResult{}
+	for rows.Next() {
+		var r BacktestResult
+		var nums numberColumns
+		var bonus sql.NullString
+		dest := append([]any{&r.DrawNumber, &r.SetIndex}, nums.dest()...)
+		dest = append(dest, &bonus, &r.Matched, &r.BonusMatched, &r.BonusMatches, &r.Rank, &r.Prize, &r.Params)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		r.Numbers = nums.numbers()
+		r.Bonus = splitNumbers(bonus)
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
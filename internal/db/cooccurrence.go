@@ -0,0 +1,79 @@
+// db/cooccurrence.go
+package db
+
+import (
+	"context"
+	"database/sql"
+	"math"
+)
+
+// SaveCooccurrence drawNo 회차 기준 번호 쌍/세 번호 동시 출현 스냅샷을 저장. 같은 회차의 이전 스냅샷은 지운다.
+func (s *Store) SaveCooccurrence(ctx context.Context, drawNo int, pairs []PairCount, triples []TripleCount) error {
+	round := func(v float64) float64 { return math.Round(v*10000) / 10000 }
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		for _, table := range []string{"pair_cooccurrence", "triple_cooccurrence"} {
+			if _, err := tx.ExecContext(ctx, "DELETE FROM "+table+" WHERE draw_number = ?", drawNo); err != nil {
+				return err
+			}
+		}
+		stmt, err := tx.PrepareContext(ctx, "INSERT INTO pair_cooccurrence(draw_number, a, b, count, lift, z) VALUES (?, ?, ?, ?, ?, ?)")
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+		for _, p := range pairs {
+			if _, err := stmt.ExecContext(ctx, drawNo, p.A, p.B, p.Count, round(p.Lift), round(p.Z)); err != nil {
+				return err
+			}
+		}
+		tstmt, err := tx.PrepareContext(ctx, "INSERT OR REPLACE INTO triple_cooccurrence(draw_number, n1, n2, n3, count, lift, z) VALUES (?, ?, ?, ?, ?, ?, ?)")
+		if err != nil {
+			return err
+		}
+		defer tstmt.Close()
+		for _, t := range triples {
+			if _, err := tstmt.ExecContext(ctx, drawNo, t.Numbers[0], t.Numbers[1], t.Numbers[2], t.Count, round(t.Lift), round(t.Z)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetPairCooccurrence drawNo 회차 기준으로 저장된 번호 쌍 통계 (A, B 순, 없으면 빈 목록)
+func (s *Store) GetPairCooccurrence(ctx context.Context, drawNo int) ([]PairCount, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT a, b, count, lift, z FROM pair_cooccurrence WHERE draw_number = ? ORDER BY a, b", drawNo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pairs := []PairCount{}
+	for rows.Next() {
+		var p PairCount
+		if err := rows.Scan(&p.A, &p.B, &p.Count, &p.Lift, &p.Z); err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, p)
+	}
+	return pairs, rows.Err()
+}
+
+// GetTripleCooccurrence drawNo 회차 기준으로 저장된 세 번호 조합 (z값 내림차순, 없으면 빈 목록)
+func (s *Store) GetTripleCooccurrence(ctx context.Context, drawNo int) ([]TripleCount, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT n1, n2, n3, count, lift, z FROM triple_cooccurrence WHERE draw_number = ? ORDER BY z DESC, n1, n2, n3", drawNo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	triples := []TripleCount{}
+	for rows.Next() {
+		var t TripleCount
+		if err := rows.Scan(&t.Numbers[0], &t.Numbers[1], &t.Numbers[2], &t.Count, &t.Lift, &t.Z); err != nil {
+			return nil, err
+		}
+		triples = append(triples, t)
+	}
+	return triples, rows.Err()
+}
@@ -0,0 +1,139 @@
+// db/game.go
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"lottopredictor/internal/game"
+)
+
+// Game DB가 담는 게임 (store_meta에 기록이 없으면 로또 6/45)
+func (s *Store) Game() *game.Game {
+	if s.game == nil {
+		return game.Default
+	}
+	return s.game
+}
+
+// loadGame store_meta에 기록된 게임을 읽는다.
+func (s *Store) loadGame(ctx context.Context) error {
+	id, err := s.recordedGame(ctx)
+	if err != nil {
+		return err
+	}
+	g, err := game.Get(id)
+	if err != nil {
+		return fmt.Errorf("DB 게임: %w", err)
+	}
+	s.game = g
+	return nil
+}
+
+func (s *Store) recordedGame(ctx context.Context) (string, error) {
+	var id string
+	err := s.db.QueryRowContext(ctx, "SELECT value FROM store_meta WHERE key = 'game'").Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return id, err
+}
+
+// BindGame DB를 id 게임용으로 쓴다. 한 DB에는 한 게임만 담는다.
+//   - id가 비어 있으면 DB에 기록된 게임(없으면 로또 6/45)을 그대로 쓴다.
+//   - 기록된 게임과 다르면 오류
+//   - 기록이 없으면 id를 기록한다. 단, 이미 회차가 있는 DB(게임 기록 전의 6/45 DB)는 로또 6/45만 기록할 수 있다.
+func (s *Store) BindGame(ctx context.Context, id string) error {
+	if id == "" {
+		return nil
+	}
+	g, err := game.Get(id)
+	if err != nil {
+		return err
+	}
+	recorded, err := s.recordedGame(ctx)
+	if err != nil {
+		return err
+	}
+	if recorded == g.ID {
+		s.game = g
+		return nil
+	}
+	if recorded != "" {
+		return fmt.Errorf("이 DB는 %s 게임 DB라 %s로 쓸 수 없음 (게임마다 DB를 따로 사용)", recorded, g.ID)
+	}
+	if g != game.Default {
+		latest, err := s.LatestDrawNumber(ctx)
+		if err != nil {
+			return err
+		}
+		if latest > 0 {
+			return fmt.Errorf("게임 기록 없이 회차가 저장된 DB는 %s DB라 %s로 쓸 수 없음", game.Default.ID, g.ID)
+		}
+	}
+	if _, err := s.db.ExecContext(ctx, "INSERT INTO store_meta(key, value) VALUES ('game', ?)", g.ID); err != nil {
+		return err
+	}
+	s.game = g
+	return nil
+}
+
+// numberArgs 번호를 n1~n6 (num1~num6) 컬럼 값으로. 번호가 6개보다 적으면 남는 컬럼은 NULL
+func numberArgs(nums []int) []any {
+	args := make([]any, game.MaxMainPicks)
+	for i, n := range nums {
+		args[i] = n
+	}
+	return args
+}
+
+// numberColumns n1~n6 (num1~num6) 컬럼을 읽는 대상
+type numberColumns [game.MaxMainPicks]sql.NullInt64
+
+func (c *numberColumns) dest() []any {
+	dest := make([]any, len(c))
+	for i := range c {
+		dest[i] = &c[i]
+	}
+	return dest
+}
+
+// numbers NULL이 아닌 컬럼의 번호
+func (c *numberColumns) numbers() []int {
+	nums := make([]int, 0, len(c))
+	for _, v := range c {
+		if v.Valid {
+			nums = append(nums, int(v.Int64))
+		}
+	}
+	return nums
+}
+
+// joinNumbers 보너스 풀 번호를 bonus_numbers 컬럼 값으로 (없으면 NULL)
+func joinNumbers(nums []int) any {
+	if len(nums) == 0 {
+		return nil
+	}
+	parts := make([]string, len(nums))
+	for i, n := range nums {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ",")
+}
+
+// splitNumbers bonus_numbers 컬럼 값을 번호로 (NULL이면 nil)
+func splitNumbers(s sql.NullString) []int {
+	if !s.Valid || s.String == "" {
+		return nil
+	}
+	nums := []int{}
+	for _, part := range strings.Split(s.String, ",") {
+		n, _ := strconv.Atoi(part)
+		nums = append(nums, n)
+	}
+	return nums
+}
@@ -3,37 +3,26 @@ package db
 
 import (
 	"database/sql"
-	"errors"
 
 	_ "modernc.org/sqlite"
 )
 
+// InitDB DB를 열고 적용되지 않은 스키마 마이그레이션을 모두 적용한다. (기존 DB도 그대로 업그레이드)
 func InitDB(path string) (*sql.DB, error) {
-	db, err := sql.Open("sqlite", path)
+	db, err := Open(path)
 	if err != nil {
 		return nil, err
 	}
 
-	// 모든 테이블 생성
-	if err := CreatePredictionMetaTable(db); err != nil {
-		return nil, errors.New("CreatePredictionMetaTable 실패: " + err.Error())
-	}
-
-	if err := CreateLottoResultsTable(db); err != nil {
-		return nil, errors.New("CreateLottoResultsTable 실패: " + err.Error())
-	}
-
-	if err := CreateDrawProbabilitiesTable(db); err != nil {
-		return nil, errors.New("CreateDrawProbabilitiesTable 실패: " + err.Error())
-	}
-
-	if err := CreateReappearanceProbabilitiesTable(db); err != nil {
-		return nil, errors.New("CreateReappearanceProbabilitiesTable 실패: " + err.Error())
-	}
-
-	if err := CreatePredictionResultsTable(db); err != nil {
-		return nil, errors.New("CreatePredictionResultsTable 실패: " + err.Error())
+	if _, err := Migrate(db); err != nil {
+		db.Close()
+		return nil, err
 	}
 
 	return db, nil
 }
+
+// Open 마이그레이션 없이 DB만 연다. (db status / db migrate 용)
+func Open(path string) (*sql.DB, error) {
+	return sql.Open("sqlite", path)
+}
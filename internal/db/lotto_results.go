@@ -2,48 +2,154 @@
 package db
 
 import (
+	"context"
 	"database/sql"
-	"log"
-
-	"lottopredictor/internal/fetcher"
+	"fmt"
 )
 
-func CreateLottoResultsTable(db *sql.DB) error {
-	_, err := db.Exec(`
-		CREATE TABLE IF NOT EXISTS lotto_results (
-			draw_number INTEGER PRIMARY KEY,
-			draw_date TEXT,
-			n1 INTEGER,
-			n2 INTEGER,
-			n3 INTEGER,
-			n4 INTEGER,
-			n5 INTEGER,
-			n6 INTEGER,
-			bonus INTEGER
-		)`)
-	return err
-}
-
-func SaveDrawResult(db *sql.DB, data *fetcher.DrawData) {
-	_, err := db.Exec(`
-		INSERT OR IGNORE INTO lotto_results(
-			draw_number, draw_date, n1, n2, n3, n4, n5, n6, bonus
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
-		data.DrwNo, data.DrwNoDate,
-		data.DrwtNo1, data.DrwtNo2, data.DrwtNo3, data.DrwtNo4, data.DrwtNo5, data.DrwtNo6,
-		data.BnusNo)
+// drawColumns lotto_results 공통 조회 컬럼 (당첨금 정보가 없던 행은 0)
+const drawColumns = `draw_number, draw_date, n1, n2, n3, n4, n5, n6, COALESCE(bonus, 0), bonus_numbers,
+	COALESCE(total_sales, 0), COALESCE(first_winners, 0), COALESCE(first_prize, 0), COALESCE(first_total, 0)`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanDraw(row scanner) (Draw, error) {
+	var d Draw
+	var date, bonus sql.NullString
+	var nums numberColumns
+	dest := append([]any{&d.Number, &date}, nums.dest()...)
+	dest = append(dest, &d.Bonus, &bonus, &d.TotalSales, &d.FirstWinners, &d.FirstPrize, &d.FirstTotal)
+	err := row.Scan(dest...)
+	d.Date = date.String
+	d.Numbers = nums.numbers()
+	d.BonusNumbers = splitNumbers(bonus)
+	return d, err
+}
+
+// LatestDrawNumber 저장된 최신 회차 (없으면 0)
+func (s *Store) LatestDrawNumber(ctx context.Context) (int, error) {
+	var max sql.NullInt64
+	if err := s.db.QueryRowContext(ctx, "SELECT MAX(draw_number) FROM lotto_results").Scan(&max); err != nil {
+		return 0, err
+	}
+	return int(max.Int64), nil
+}
+
+// GetDraw drawNo 회차 당첨 번호. 없으면 ErrNotFound
+func (s *Store) GetDraw(ctx context.Context, drawNo int) (*Draw, error) {
+	row := s.db.QueryRowContext(ctx, `
+		SELECT `+drawColumns+`
+		FROM lotto_results
+		WHERE draw_number = ?`, drawNo)
+	d, err := scanDraw(row)
 	if err != nil {
-		log.Printf("[DB] 저장 실패 (회차 %d): %v\n", data.DrwNo, err)
-	} else {
-		if data.DrwNo%100 == 0 {
-			log.Printf("[DB] insering(drawnumber: ~%d)\n", data.DrwNo)
+		return nil, fmt.Errorf("회차 %d: %w", drawNo, notFound(err))
+	}
+	return &d, nil
+}
+
+// ListDraws from ~ to 회차를 회차 순으로 반환 (to가 0이면 끝까지)
+func (s *Store) ListDraws(ctx context.Context, from, to int) ([]Draw, error) {
+	if to == 0 {
+		var err error
+		if to, err = s.LatestDrawNumber(ctx); err != nil {
+			return nil, err
 		}
 	}
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+drawColumns+`
+		FROM lotto_results
+		WHERE draw_number BETWEEN ? AND ?
+		ORDER BY draw_number`, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	draws := []Draw{}
+	for rows.Next() {
+		d, err := scanDraw(rows)
+		if err != nil {
+			return nil, err
+		}
+		draws = append(draws, d)
+	}
+	return draws, rows.Err()
+}
+
+// SaveDraws 여러 회차를 한 트랜잭션으로 저장. 이미 있는 회차는 새 값으로 덮어쓴다.
+// 번호 개수가 DB 게임과 맞지 않는 회차가 있으면 아무것도 저장하지 않는다.
+func (s *Store) SaveDraws(ctx context.Context, draws []Draw) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		stmt, err := tx.PrepareContext(ctx, `
+			INSERT INTO lotto_results(
+				draw_number, draw_date, n1, n2, n3, n4, n5, n6, bonus, bonus_numbers,
+				total_sales, first_winners, first_prize, first_total
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT(draw_number) DO UPDATE SET
+				draw_date = excluded.draw_date,
+				n1 = excluded.n1, n2 = excluded.n2, n3 = excluded.n3,
+				n4 = excluded.n4, n5 = excluded.n5, n6 = excluded.n6,
+				bonus = excluded.bonus, bonus_numbers = excluded.bonus_numbers,
+				total_sales = excluded.total_sales,
+				first_winners = excluded.first_winners,
+				first_prize = excluded.first_prize,
+				first_total = excluded.first_total`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		g := s.Game()
+		for _, d := range draws {
+			if len(d.Numbers) != g.Main.Picks || len(d.BonusNumbers) != g.Bonus.Picks {
+				return fmt.Errorf("회차 %d: %s 번호 형식이 아님: %v %v", d.Number, g.Name, d.Numbers, d.BonusNumbers)
+			}
+			args := append([]any{d.Number, d.Date}, numberArgs(d.Numbers)...)
+			args = append(args, d.Bonus, joinNumbers(d.BonusNumbers), d.TotalSales, d.FirstWinners, d.FirstPrize, d.FirstTotal)
+			_, err := stmt.ExecContext(ctx, args...)
+			if err != nil {
+				return fmt.Errorf("회차 %d 저장 실패: %w", d.Number, err)
+			}
+		}
+		return nil
+	})
 }
 
-func GetLatestDrawNumber(db *sql.DB) int {
-	row := db.QueryRow("SELECT MAX(draw_number) FROM lotto_results")
-	var max int
-	row.Scan(&max)
-	return max
+// AverageFirstPrize upTo 회차까지 1등 당첨자가 있었던 회차의 1인당 평균 당첨금 (자료 없으면 0)
+func (s *Store) AverageFirstPrize(ctx context.Context, upTo int) (int64, error) {
+	var avg sql.NullFloat64
+	row := s.db.QueryRowContext(ctx, `
+		SELECT AVG(first_prize)
+		FROM lotto_results
+		WHERE draw_number <= ? AND first_winners > 0 AND first_prize > 0`, upTo)
+	if err := row.Scan(&avg); err != nil {
+		return 0, err
+	}
+	return int64(avg.Float64), nil
+}
+
+// FindMissingDraws 1회부터 저장된 최신 회차 사이에 빠진 회차 번호 목록
+func (s *Store) FindMissingDraws(ctx context.Context) ([]int, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT draw_number FROM lotto_results ORDER BY draw_number")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	missing := []int{}
+	expected := 1
+	for rows.Next() {
+		var drawNo int
+		if err := rows.Scan(&drawNo); err != nil {
+			return nil, err
+		}
+		for ; expected < drawNo; expected++ {
+			missing = append(missing, expected)
+		}
+		expected = drawNo + 1
+	}
+	return missing, rows.Err()
 }
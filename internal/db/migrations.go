@@ -0,0 +1,399 @@
+// db/migrations.go
+package db
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Migration 번호가 붙은 스키마 변경 1건. Up은 트랜잭션 안에서 실행된다.
+// 이미 배포된 마이그레이션은 수정하지 말고 새 번호로 추가해야 한다.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(tx *sql.Tx) error
+}
+
+// MigrationState db status 출력용 마이그레이션 적용 상태
+type MigrationState struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt string
+}
+
+// migrations 전체 스키마 변경 이력 (Version 오름차순)
+// 1~5번은 schema_version 도입 전에 만들어진 DB도 그대로 올라가도록 IF NOT EXISTS / 컬럼 존재 여부를 확인한다.
+var migrations = []Migration{
+	{Version: 1, Name: "initial schema", Up: execAll(`
+		CREATE TABLE IF NOT EXISTS prediction_meta (
+			draw_number INTEGER,
+			idx INTEGER,
+			created_at TEXT,
+			PRIMARY KEY (draw_number, idx)
+		)`, `
+		CREATE TABLE IF NOT EXISTS lotto_results (
+			draw_number INTEGER PRIMARY KEY,
+			draw_date TEXT,
+			n1 INTEGER,
+			n2 INTEGER,
+			n3 INTEGER,
+			n4 INTEGER,
+			n5 INTEGER,
+			n6 INTEGER,
+			bonus INTEGER
+		)`, `
+		CREATE TABLE IF NOT EXISTS draw_probabilities (
+			draw_number INTEGER,
+			number INTEGER,
+			probability REAL
+		)`, `
+		CREATE TABLE IF NOT EXISTS reappearance_probabilities (
+			draw_number INTEGER,
+			number INTEGER,
+			probability REAL
+		)`, `
+		CREATE TABLE IF NOT EXISTS prediction_results (
+			draw_number INTEGER,
+			meta_idx INTEGER,
+			set_index INTEGER,
+			num1 INTEGER,
+			num2 INTEGER,
+			num3 INTEGER,
+			num4 INTEGER,
+			num5 INTEGER,
+			num6 INTEGER,
+			percentage REAL,
+			rank INTEGER,
+			created_at TEXT,
+			PRIMARY KEY (draw_number, meta_idx, set_index)
+		)`)},
+	{Version: 2, Name: "prediction_meta strategy", Up: func(tx *sql.Tx) error {
+		if err := addColumnIfMissing(tx, "prediction_meta", "strategy", "TEXT"); err != nil {
+			return err
+		}
+		return addColumnIfMissing(tx, "prediction_meta", "strategy_params", "TEXT")
+	}},
+	{Version: 3, Name: "backtest tables", Up: execAll(`
+		CREATE TABLE IF NOT EXISTS backtest_runs (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			strategy TEXT,
+			strategy_params TEXT,
+			from_draw INTEGER,
+			to_draw INTEGER,
+			sets_per_draw INTEGER,
+			total_sets INTEGER,
+			total_prize INTEGER,
+			expected_value REAL,
+			created_at TEXT
+		)`, `
+		CREATE TABLE IF NOT EXISTS backtest_results (
+			run_id INTEGER,
+			draw_number INTEGER,
+			set_index INTEGER,
+			num1 INTEGER,
+			num2 INTEGER,
+			num3 INTEGER,
+			num4 INTEGER,
+			num5 INTEGER,
+			num6 INTEGER,
+			matched INTEGER,
+			bonus_matched INTEGER,
+			rank INTEGER,
+			prize INTEGER,
+			PRIMARY KEY (run_id, draw_number, set_index)
+		)`)},
+	{Version: 4, Name: "sync_runs", Up: execAll(`
+		CREATE TABLE IF NOT EXISTS sync_runs (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			started_at TEXT,
+			finished_at TEXT,
+			draws_added INTEGER DEFAULT 0,
+			draws_repaired INTEGER DEFAULT 0,
+			error_count INTEGER DEFAULT 0,
+			errors TEXT,
+			status TEXT
+		)`)},
+	{Version: 5, Name: "lotto_results prize columns", Up: func(tx *sql.Tx) error {
+		for _, col := range []string{"total_sales", "first_winners", "first_prize", "first_total"} {
+			if err := addColumnIfMissing(tx, "lotto_results", col, "INTEGER"); err != nil {
+				return err
+			}
+		}
+		return nil
+	}},
+	{Version: 6, Name: "probability tables real values and primary key", Up: func(tx *sql.Tx) error {
+		// 예전 코드는 확률을 "%.3f" 문자열로 넣었고, reappearance_probabilities는 실행할 때마다 중복 행이 쌓였다.
+		// (draw_number, number) 기본 키로 다시 만들면서 가장 나중에 저장된 값만 REAL로 남긴다.
+		for _, table := range []string{"draw_probabilities", "reappearance_probabilities"} {
+			err := execAll(fmt.Sprintf(`
+				CREATE TABLE %[1]s_new (
+					draw_number INTEGER,
+					number INTEGER,
+					probability REAL,
+					PRIMARY KEY (draw_number, number)
+				)`, table), fmt.Sprintf(`
+				INSERT OR REPLACE INTO %[1]s_new (draw_number, number, probability)
+				SELECT draw_number, number, CAST(probability AS REAL) FROM %[1]s ORDER BY rowid`, table),
+				fmt.Sprintf(`DROP TABLE %s`, table),
+				fmt.Sprintf(`ALTER TABLE %[1]s_new RENAME TO %[1]s`, table),
+			)(tx)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}},
+	{Version: 7, Name: "prediction_results evaluation details", Up: func(tx *sql.Tx) error {
+		// evaluated_at이 NULL인 행은 평가 대기. 예전에 회차가 어긋나게 평가된 행도 다음 평가 때 다시 채점된다.
+		for _, col := range []struct{ name, typ string }{
+			{"matched", "INTEGER"},
+			{"bonus_matched", "INTEGER"},
+			{"evaluated_at", "TEXT"},
+		} {
+			if err := addColumnIfMissing(tx, "prediction_results", col.name, col.typ); err != nil {
+				return err
+			}
+		}
+		return nil
+	}},
+	{Version: 8, Name: "co-occurrence snapshots", Up: execAll(`
+		CREATE TABLE pair_cooccurrence (
+			draw_number INTEGER,
+			a INTEGER,
+			b INTEGER,
+			count INTEGER,
+			lift REAL,
+			z REAL,
+			PRIMARY KEY (draw_number, a, b)
+		)`, `
+		CREATE TABLE triple_cooccurrence (
+			draw_number INTEGER,
+			n1 INTEGER,
+			n2 INTEGER,
+			n3 INTEGER,
+			count INTEGER,
+			lift REAL,
+			z REAL,
+			PRIMARY KEY (draw_number, n1, n2, n3)
+		)`)},
+	{Version: 9, Name: "strategy_probabilities", Up: execAll(`
+		CREATE TABLE strategy_probabilities (
+			draw_number INTEGER,
+			strategy TEXT,
+			number INTEGER,
+			probability REAL,
+			PRIMARY KEY (draw_number, strategy, number)
+		)`)},
+	{Version: 10, Name: "draw_probabilities bayes estimates", Up: func(tx *sql.Tx) error {
+		for _, col := range []string{"posterior_mean", "lower95", "upper95", "p_above_uniform"} {
+			if err := addColumnIfMissing(tx, "draw_probabilities", col, "REAL"); err != nil {
+				return err
+			}
+		}
+		return nil
+	}},
+	{Version: 11, Name: "run seeds", Up: func(tx *sql.Tx) error {
+		if err := addColumnIfMissing(tx, "prediction_meta", "seed", "INTEGER"); err != nil {
+			return err
+		}
+		if err := addColumnIfMissing(tx, "prediction_meta", "settings", "TEXT"); err != nil {
+			return err
+		}
+		return addColumnIfMissing(tx, "backtest_runs", "seed", "INTEGER")
+	}},
+	{Version: 12, Name: "games and bonus pools", Up: func(tx *sql.Tx) error {
+		// store_meta의 game 행이 DB가 담는 게임. 행이 없는 기존 DB는 로또 6/45
+		// 본 번호가 6개보다 적은 게임은 남는 n/num 컬럼이 NULL, 보너스 풀 번호는 쉼표 구분 문자열
+		if _, err := tx.Exec(`
+			CREATE TABLE store_meta (
+				key TEXT PRIMARY KEY,
+				value TEXT
+			)`); err != nil {
+			return err
+		}
+		for _, table := range []string{"lotto_results", "prediction_results", "backtest_results"} {
+			if err := addColumnIfMissing(tx, table, "bonus_numbers", "TEXT"); err != nil {
+				return err
+			}
+		}
+		if err := addColumnIfMissing(tx, "prediction_results", "bonus_matches", "INTEGER"); err != nil {
+			return err
+		}
+		return addColumnIfMissing(tx, "backtest_results", "bonus_matches", "INTEGER")
+	}},
+	// 직접 산 티켓 장부. checked_at이 NULL인 행은 당첨 확인 대기, prize는 확인할 때의 당첨금
+	{Version: 13, Name: "tickets", Up: execAll(`
+		CREATE TABLE tickets (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			draw_number INTEGER NOT NULL,
+			buyer TEXT NOT NULL,
+			strategy TEXT NOT NULL,
+			num1 INTEGER,
+			num2 INTEGER,
+			num3 INTEGER,
+			num4 INTEGER,
+			num5 INTEGER,
+			num6 INTEGER,
+			bonus_numbers TEXT,
+			cost INTEGER NOT NULL,
+			created_at TEXT,
+			matched INTEGER,
+			bonus_matched INTEGER,
+			bonus_matches INTEGER,
+			rank INTEGER,
+			prize INTEGER,
+			checked_at TEXT
+		)`)},
+	// 예측마다 전략이 정한 값 (markov의 selected_order 등). 전략 설정인 strategy_params와 따로 둔다.
+	{Version: 14, Name: "prediction params", Up: func(tx *sql.Tx) error {
+		if err := addColumnIfMissing(tx, "prediction_meta", "prediction_params", "TEXT"); err != nil {
+			return err
+		}
+		return addColumnIfMissing(tx, "backtest_results", "prediction_params", "TEXT")
+	}},
+	// 백테스트 기준 시드. seed는 기준 시드와 전략 이름으로 파생한 값이라 그것만으로는 backtest -seed에 다시 줄 수 없다.
+	{Version: 15, Name: "backtest base seed", Up: func(tx *sql.Tx) error {
+		return addColumnIfMissing(tx, "backtest_runs", "base_seed", "INTEGER")
+	}},
+}
+
+// LatestSchemaVersion 코드가 알고 있는 최신 스키마 버전
+func LatestSchemaVersion() int {
+	return migrations[len(migrations)-1].Version
+}
+
+func createSchemaVersionTable(db *sql.DB) error {
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS schema_version (
+			version INTEGER PRIMARY KEY,
+			name TEXT,
+			applied_at TEXT
+		)`)
+	return err
+}
+
+// SchemaVersion 현재 DB에 적용된 가장 높은 마이그레이션 번호 (없으면 0)
+func SchemaVersion(db *sql.DB) (int, error) {
+	if err := createSchemaVersionTable(db); err != nil {
+		return 0, err
+	}
+	var v sql.NullInt64
+	if err := db.QueryRow("SELECT MAX(version) FROM schema_version").Scan(&v); err != nil {
+		return 0, err
+	}
+	return int(v.Int64), nil
+}
+
+// Migrate 적용되지 않은 마이그레이션을 번호 순으로 하나씩 트랜잭션으로 적용하고, 적용한 목록을 반환
+func Migrate(db *sql.DB) ([]Migration, error) {
+	current, err := SchemaVersion(db)
+	if err != nil {
+		return nil, err
+	}
+	if current > LatestSchemaVersion() {
+		return nil, fmt.Errorf("DB 스키마 버전 %d가 프로그램이 아는 버전 %d보다 높음", current, LatestSchemaVersion())
+	}
+
+	applied := []Migration{}
+	for _, m := range migrations {
+		if m.Version <= current {
+			continue
+		}
+		if err := applyMigration(db, m); err != nil {
+			return applied, fmt.Errorf("마이그레이션 %d (%s) 실패: %w", m.Version, m.Name, err)
+		}
+		applied = append(applied, m)
+	}
+	return applied, nil
+}
+
+func applyMigration(db *sql.DB, m Migration) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := m.Up(tx); err != nil {
+		return err
+	}
+	_, err = tx.Exec("INSERT INTO schema_version(version, name, applied_at) VALUES (?, ?, datetime('now'))", m.Version, m.Name)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// MigrationStatus 모든 마이그레이션의 적용 여부와 적용 시각
+func MigrationStatus(db *sql.DB) ([]MigrationState, error) {
+	if err := createSchemaVersionTable(db); err != nil {
+		return nil, err
+	}
+	rows, err := db.Query("SELECT version, applied_at FROM schema_version")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	appliedAt := map[int]string{}
+	for rows.Next() {
+		var v int
+		var at sql.NullString
+		if err := rows.Scan(&v, &at); err != nil {
+			return nil, err
+		}
+		appliedAt[v] = at.String
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	states := []MigrationState{}
+	for _, m := range migrations {
+		at, ok := appliedAt[m.Version]
+		states = append(states, MigrationState{Version: m.Version, Name: m.Name, Applied: ok, AppliedAt: at})
+	}
+	return states, nil
+}
+
+// execAll 여러 SQL 문을 순서대로 실행하는 Up 함수
+func execAll(stmts ...string) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		for _, stmt := range stmts {
+			if _, err := tx.Exec(stmt); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// addColumnIfMissing 기존 테이블에 column이 없으면 ALTER TABLE로 추가한다.
+// schema_version 도입 전 코드가 이미 컬럼을 추가했을 수 있어 존재 여부를 먼저 확인한다.
+func addColumnIfMissing(tx *sql.Tx, table, column, decl string) error {
+	rows, err := tx.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, colType string
+		var dflt sql.NullString
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dflt, &pk); err != nil {
+			return err
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	_, err = tx.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, decl))
+	return err
+}
@@ -0,0 +1,108 @@
+// db/models.go
+package db
+
+import (
+	"lottopredictor/internal/fetcher"
+)
+
+// Draw 회차별 당첨 번호와 판매/1등 당첨금 정보 (lotto_results 행)
+type Draw struct {
+	Number       int    `json:"number"`
+	Date         string `json:"date"`
+	Numbers      []int  `json:"numbers"`                 // 당첨 번호 (로또 6/45는 6개, 오름차순)
+	Bonus        int    `json:"bonus"`                   // 본 번호 풀에서 뽑은 보너스 번호 (없는 게임은 0)
+	BonusNumbers []int  `json:"bonus_numbers,omitempty"` // 따로 뽑는 보너스 풀 번호 (EuroMillions 별 번호 등)
+	TotalSales   int64  `json:"total_sales"`
+	FirstWinners int    `json:"first_winners"`
+	FirstPrize   int64  `json:"first_prize"` // 1등 1인당 당첨금
+	FirstTotal   int64  `json:"first_total"` // 1등 총 당첨금
+}
+
+// DrawFromData API 응답을 Draw로 변환
+func DrawFromData(d *fetcher.DrawData) Draw {
+	return Draw{
+		Number:       d.DrwNo,
+		Date:         d.DrwNoDate,
+		Numbers:      d.Numbers(),
+		Bonus:        d.BnusNo,
+		BonusNumbers: d.BnusNos,
+		TotalSales:   d.TotSellamnt,
+		FirstWinners: d.FirstPrzwnerCo,
+		FirstPrize:   d.FirstWinamnt,
+		FirstTotal:   d.FirstAccumamnt,
+	}
+}
+
+// Data API 응답 형식으로 변환 (가짜 API 서버 등)
+func (d Draw) Data() *fetcher.DrawData {
+	data := &fetcher.DrawData{
+		ReturnValue:    "success",
+		DrwNo:          d.Number,
+		DrwNoDate:      d.Date,
+		BnusNo:         d.Bonus,
+		BnusNos:        d.BonusNumbers,
+		TotSellamnt:    d.TotalSales,
+		FirstPrzwnerCo: d.FirstWinners,
+		FirstWinamnt:   d.FirstPrize,
+		FirstAccumamnt: d.FirstTotal,
+	}
+	data.SetNumbers(d.Numbers)
+	return data
+}
+
+// PredictionRun 예측 1회 실행 (prediction_meta 행 + 추천 세트)
+type PredictionRun struct {
+	DrawNumber     int             `json:"draw_number"` // 예측 대상 회차
+	Idx            int             `json:"idx"`         // 같은 회차 내 실행 순번 (1부터)
+	CreatedAt      string          `json:"created_at"`
+	Strategy       string          `json:"strategy"`
+	StrategyParams string          `json:"strategy_params"`    // 전략 파라미터 JSON
+	Params         string          `json:"params,omitempty"`   // 이 예측에서 전략이 정한 값 JSON (markov의 selected_order 등)
+	Seed           int64           `json:"seed,omitempty"`     // 추천 세트 난수 시드 (시드 기록 전 실행은 0)
+	Settings       string          `json:"settings,omitempty"` // 세트 생성 설정 JSON (추천 조건, 포트폴리오, 번호 쌍 반영 강도)
+	Sets           []PredictionSet `json:"sets"`
+}
+
+// PredictionSet 추천 번호 세트 1개 (prediction_results 행)
+type PredictionSet struct {
+	SetIndex   int         `json:"set_index"` // 1부터
+	Numbers    []int       `json:"numbers"`
+	Bonus      []int       `json:"bonus,omitempty"`      // 보너스 풀 번호 (보너스 풀이 있는 게임만)
+	Evaluation *Evaluation `json:"evaluation,omitempty"` // 아직 평가 전이면 nil
+}
+
+// Evaluation 추천 세트를 실제 당첨 번호와 비교한 결과
+type Evaluation struct {
+	Matched      int     `json:"matched"`
+	BonusMatched bool    `json:"bonus_matched"`
+	BonusMatches int     `json:"bonus_matches,omitempty"` // 일치한 보너스 풀 번호 수
+	Percentage   float64 `json:"percentage"`              // 일치 개수 / 본 번호 수 × 100
+	Rank         int     `json:"rank"`                    // 게임 등수 (로또 6/45는 1~5등), 낙첨이면 0
+	EvaluatedAt  string  `json:"evaluated_at"`
+}
+
+// PairCount 기준 회차까지 번호 쌍(A < B)이 함께 나온 횟수와 독립 추첨 대비 lift, z값 (pair_cooccurrence 행)
+type PairCount struct {
+	A     int     `json:"a"`
+	B     int     `json:"b"`
+	Count int     `json:"count"`
+	Lift  float64 `json:"lift"`
+	Z     float64 `json:"z"`
+}
+
+// TripleCount 세 번호 조합의 동시 출현 (triple_cooccurrence 행, 상위/하위 조합만 저장)
+type TripleCount struct {
+	Numbers [3]int  `json:"numbers"`
+	Count   int     `json:"count"`
+	Lift    float64 `json:"lift"`
+	Z       float64 `json:"z"`
+}
+
+// NumberEstimate 번호별 베이즈 사후 평균과 95% 신용구간 (draw_probabilities 행의 추가 열, 단위 %)
+type NumberEstimate struct {
+	Number        int     `json:"number"`
+	Mean          float64 `json:"mean"`
+	Lower         float64 `json:"lower"`
+	Upper         float64 `json:"upper"`
+	PAboveUniform float64 `json:"p_above_uniform"`
+}
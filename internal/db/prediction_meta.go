@@ -1,45 +1,151 @@
+// db/prediction_meta.go
 package db
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
 )
 
-func CreatePredictionMetaTable(db *sql.DB) error {
-	_, err := db.Exec(`
-		CREATE TABLE IF NOT EXISTS prediction_meta (
-			draw_number INTEGER,
-			idx INTEGER,
-			created_at TEXT,
-			PRIMARY KEY (draw_number, idx)
-		)`)
-	return err
-}
+// SavePredictionRun 예측 메타와 추천 세트를 한 트랜잭션으로 저장하고 새 idx를 반환
+// run.Idx, run.CreatedAt은 저장 후 채워진다.
+func (s *Store) SavePredictionRun(ctx context.Context, run *PredictionRun) (int, error) {
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		var currentMax sql.NullInt64
+		row := tx.QueryRowContext(ctx, "SELECT MAX(idx) FROM prediction_meta WHERE draw_number = ?", run.DrawNumber)
+		if err := row.Scan(&currentMax); err != nil {
+			return err
+		}
+		run.Idx = int(currentMax.Int64) + 1
+
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO prediction_meta(draw_number, idx, created_at, strategy, strategy_params, prediction_params, seed, settings)
+			VALUES (?, ?, datetime('now'), ?, ?, ?, ?, ?)`,
+			run.DrawNumber, run.Idx, run.Strategy, run.StrategyParams, run.Params, run.Seed, run.Settings)
+		if err != nil {
+			return err
+		}
+
+		stmt, err := tx.PrepareContext(ctx, `
+			INSERT INTO prediction_results
+			(draw_number, meta_idx, set_index, num1, num2, num3, num4, num5, num6, bonus_numbers, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, datetime('now'))`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
 
-func InsertPredictionMeta(db *sql.DB, drawNo int) (int, error) {
-	var currentMax sql.NullInt64
-	row := db.QueryRow("SELECT MAX(idx) FROM prediction_meta WHERE draw_number = ?", drawNo)
-	err := row.Scan(&currentMax)
+		g := s.Game()
+		for i := range run.Sets {
+			set := &run.Sets[i]
+			if len(set.Numbers) != g.Main.Picks || len(set.Bonus) != g.Bonus.Picks {
+				return fmt.Errorf("invalid set length: %v %v", set.Numbers, set.Bonus)
+			}
+			set.SetIndex = i + 1
+			args := append([]any{run.DrawNumber, run.Idx, set.SetIndex}, numberArgs(set.Numbers)...)
+			if _, err := stmt.ExecContext(ctx, append(args, joinNumbers(set.Bonus))...); err != nil {
+				return err
+			}
+		}
+
+		return tx.QueryRowContext(ctx, "SELECT created_at FROM prediction_meta WHERE draw_number = ? AND idx = ?",
+			run.DrawNumber, run.Idx).Scan(&run.CreatedAt)
+	})
 	if err != nil {
 		return 0, err
 	}
+	return run.Idx, nil
+}
 
-	newIdx := 1
-	if currentMax.Valid {
-		newIdx = int(currentMax.Int64) + 1
+// GetPredictionRun drawNo 회차 idx번째 예측과 추천 세트(평가 포함). 없으면 ErrNotFound
+func (s *Store) GetPredictionRun(ctx context.Context, drawNo, idx int) (*PredictionRun, error) {
+	run := &PredictionRun{DrawNumber: drawNo, Idx: idx}
+	var createdAt, strategy, params, predictionParams, settings sql.NullString
+	var seed sql.NullInt64
+	row := s.db.QueryRowContext(ctx, `
+		SELECT created_at, strategy, strategy_params, prediction_params, seed, settings
+		FROM prediction_meta
+		WHERE draw_number = ? AND idx = ?`, drawNo, idx)
+	if err := row.Scan(&createdAt, &strategy, &params, &predictionParams, &seed, &settings); err != nil {
+		return nil, fmt.Errorf("회차 %d 예측 %d: %w", drawNo, idx, notFound(err))
 	}
+	run.CreatedAt = createdAt.String
+	run.Strategy = strategy.String
+	run.StrategyParams = params.String
+	run.Params = predictionParams.String
+	run.Seed = seed.Int64
+	run.Settings = settings.String
 
-	stmt, err := db.Prepare(`
-		INSERT INTO prediction_meta(draw_number, idx, created_at)
-		VALUES (?, ?, datetime('now'))
-	`)
+	sets, err := s.listPredictionSets(ctx, drawNo, idx)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	defer stmt.Close()
+	run.Sets = sets
+	return run, nil
+}
+
+// LatestPredictionRun drawNo 회차의 가장 최근 예측. 없으면 ErrNotFound
+func (s *Store) LatestPredictionRun(ctx context.Context, drawNo int) (*PredictionRun, error) {
+	var idx sql.NullInt64
+	row := s.db.QueryRowContext(ctx, "SELECT MAX(idx) FROM prediction_meta WHERE draw_number = ?", drawNo)
+	if err := row.Scan(&idx); err != nil {
+		return nil, err
+	}
+	if !idx.Valid {
+		return nil, fmt.Errorf("회차 %d 예측: %w", drawNo, ErrNotFound)
+	}
+	return s.GetPredictionRun(ctx, drawNo, int(idx.Int64))
+}
 
-	_, err = stmt.Exec(drawNo, newIdx)
+// ListPredictionRuns drawNo 회차의 예측 (drawNo가 0이면 전체)을 최신 순으로 limit개 (0이면 전부)
+func (s *Store) ListPredictionRuns(ctx context.Context, drawNo, limit int) ([]PredictionRun, error) {
+	query := "SELECT draw_number, idx FROM prediction_meta"
+	args := []any{}
+	if drawNo > 0 {
+		query += " WHERE draw_number = ?"
+		args = append(args, drawNo)
+	}
+	query += " ORDER BY draw_number DESC, idx DESC"
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
+		return nil, err
+	}
+	type key struct{ draw, idx int }
+	keys := []key{}
+	for rows.Next() {
+		var k key
+		if err := rows.Scan(&k.draw, &k.idx); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	runs := []PredictionRun{}
+	for _, k := range keys {
+		run, err := s.GetPredictionRun(ctx, k.draw, k.idx)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, *run)
+	}
+	return runs, nil
+}
+
+// LatestPredictionDraw 예측이 저장된 가장 최근 대상 회차 (없으면 0)
+func (s *Store) LatestPredictionDraw(ctx context.Context) (int, error) {
+	var max sql.NullInt64
+	if err := s.db.QueryRowContext(ctx, "SELECT MAX(draw_number) FROM prediction_meta").Scan(&max); err != nil {
 		return 0, err
 	}
-	return newIdx, nil
+	return int(max.Int64), nil
 }
@@ -2,119 +2,132 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
-	"lottopredictor/internal/common"
-)
 
-func CreatePredictionResultsTable(db *sql.DB) error {
-	_, err := db.Exec(`
-		CREATE TABLE IF NOT EXISTS prediction_results (
-			draw_number INTEGER,
-			meta_idx INTEGER,
-			set_index INTEGER,
-			num1 INTEGER,
-			num2 INTEGER,
-			num3 INTEGER,
-			num4 INTEGER,
-			num5 INTEGER,
-			num6 INTEGER,
-			percentage REAL,
-			rank INTEGER,
-			created_at TEXT,
-			PRIMARY KEY (draw_number, meta_idx, set_index)
-		)`)
-	return err
-}
+	"lottopredictor/internal/game"
+)
 
-func SavePredictionResults(db *sql.DB, metaID int64, drawNo int, predictions [][]int) error {
-	stmt, err := db.Prepare(`
-		INSERT INTO prediction_results
-		(draw_number, meta_idx, set_index, num1, num2, num3, num4, num5, num6, created_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, datetime('now'))`)
+func (s *Store) listPredictionSets(ctx context.Context, drawNo, metaIdx int) ([]PredictionSet, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT set_index, num1, num2, num3, num4, num5, num6, bonus_numbers,
+			matched, bonus_matched, bonus_matches, percentage, rank, evaluated_at
+		FROM prediction_results
+		WHERE draw_number = ? AND meta_idx = ?
+		ORDER BY set_index ASC`, drawNo, metaIdx)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer stmt.Close()
+	defer rows.Close()
 
-	for i, set := range predictions {
-		if len(set) != 6 {
-			return fmt.Errorf("invalid set length: %v", set)
+	sets := []PredictionSet{}
+	for rows.Next() {
+		var set PredictionSet
+		var nums numberColumns
+		var matched, bonusMatches, rank sql.NullInt64 // 아직 평가 전이면 NULL
+		var bonus sql.NullBool
+		var perc sql.NullFloat64
+		var bonusNumbers, evaluatedAt sql.NullString
+		dest := append([]any{&set.SetIndex}, nums.dest()...)
+		dest = append(dest, &bonusNumbers, &matched, &bonus, &bonusMatches, &perc, &rank, &evaluatedAt)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
 		}
-		_, err := stmt.Exec(metaID, drawNo, i+1, set[0], set[1], set[2], set[3], set[4], set[5])
-		if err != nil {
-			return err
+		set.Numbers = nums.numbers()
+		set.Bonus = splitNumbers(bonusNumbers)
+		if evaluatedAt.Valid {
+			set.Evaluation = &Evaluation{
+				Matched:      int(matched.Int64),
+				BonusMatched: bonus.Bool,
+				BonusMatches: int(bonusMatches.Int64),
+				Percentage:   perc.Float64,
+				Rank:         int(rank.Int64),
+				EvaluatedAt:  evaluatedAt.String,
+			}
 		}
+		sets = append(sets, set)
 	}
-	return nil
+	return sets, rows.Err()
 }
 
-func UpdatePredictionEvaluations(db *sql.DB, drawNo int, actual []int, bonus int) error {
-	set := make(map[int]bool)
-	for _, n := range actual {
-		set[n] = true
-	}
-
-	query := `
-		SELECT meta_idx, set_index, num1, num2, num3, num4, num5, num6
-		FROM prediction_results
-		WHERE draw_number = ?
-	`
-	rows, err := db.Query(query, drawNo-1)
+// PendingEvaluationDraws 당첨 번호가 저장됐지만 아직 평가되지 않은 추천 세트가 있는 회차 목록 (오름차순)
+func (s *Store) PendingEvaluationDraws(ctx context.Context) ([]int, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT DISTINCT p.draw_number
+		FROM prediction_results p
+		JOIN lotto_results l ON l.draw_number = p.draw_number
+		WHERE p.evaluated_at IS NULL
+		ORDER BY p.draw_number`)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer rows.Close()
 
-	updateStmt, err := db.Prepare(`
-		UPDATE prediction_results
-		SET percentage = ?, rank = ?
-		WHERE draw_number = ? AND meta_idx = ? AND set_index = ?
-	`)
-	if err != nil {
-		return err
-	}
-	defer updateStmt.Close()
-
+	draws := []int{}
 	for rows.Next() {
-		var metaIdx, setIdx, n1, n2, n3, n4, n5, n6 int
-		rows.Scan(&metaIdx, &setIdx, &n1, &n2, &n3, &n4, &n5, &n6)
+		var drawNo int
+		if err := rows.Scan(&drawNo); err != nil {
+			return nil, err
+		}
+		draws = append(draws, drawNo)
+	}
+	return draws, rows.Err()
+}
 
-		nums := []int{n1, n2, n3, n4, n5, n6}
-		matched := 0
-		bonusMatched := false
+// EvaluatePredictions draw 회차를 대상으로 저장된 추천 세트 중 평가 전인 것을 DB 게임의 등수 규칙으로 당첨 번호와 비교해
+// 일치 개수, 보너스 일치, 일치율, 등수, 평가 시각을 기록하고 평가한 세트 수를 반환
+func (s *Store) EvaluatePredictions(ctx context.Context, draw *Draw) (int, error) {
+	evaluated := 0
+	err := s.withTx(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, `
+			SELECT meta_idx, set_index, num1, num2, num3, num4, num5, num6, bonus_numbers
+			FROM prediction_results
+			WHERE draw_number = ? AND evaluated_at IS NULL`, draw.Number)
+		if err != nil {
+			return err
+		}
 
-		for _, n := range nums {
-			if set[n] {
-				matched++
-			}
-			if n == bonus {
-				bonusMatched = true
+		type eval struct {
+			metaIdx, setIdx int
+			match           game.Match
+			percent         float64
+		}
+		g := s.Game()
+		evals := []eval{}
+		for rows.Next() {
+			var metaIdx, setIdx int
+			var nums numberColumns
+			var bonus sql.NullString
+			dest := append([]any{&metaIdx, &setIdx}, nums.dest()...)
+			if err := rows.Scan(append(dest, &bonus)...); err != nil {
+				rows.Close()
+				return err
 			}
+
+			m := g.Rank(nums.numbers(), splitNumbers(bonus), draw.Numbers, draw.Bonus, draw.BonusNumbers)
+
+			// 퍼센트 계산
+			percent := float64(m.Main) / float64(g.Main.Picks) * 100
+			evals = append(evals, eval{metaIdx, setIdx, m, percent})
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
 		}
 
-		// 퍼센트 계산
-		percent := float64(matched) / 6.0 * 100
-		rank := common.RankNone
-		switch matched {
-		case 6:
-			rank = common.RankFirst
-		case 5:
-			if bonusMatched {
-				rank = common.RankSecond
-			} else {
-				rank = common.RankThird
+		for _, e := range evals {
+			_, err := tx.ExecContext(ctx, `
+				UPDATE prediction_results
+				SET matched = ?, bonus_matched = ?, bonus_matches = ?, percentage = ?, rank = ?, evaluated_at = datetime('now')
+				WHERE draw_number = ? AND meta_idx = ? AND set_index = ?`,
+				e.match.Main, e.match.Extra, e.match.Bonus, e.percent, e.match.Rank, draw.Number, e.metaIdx, e.setIdx)
+			if err != nil {
+				return fmt.Errorf("회차 %d 예측 %d-%d 평가 저장 실패: %w", draw.Number, e.metaIdx, e.setIdx, err)
 			}
-		case 4:
-			rank = common.RankFourth
-		case 3:
-			rank = common.RankFifth
-		default:
-			rank = common.RankNone
 		}
-
-		updateStmt.Exec(percent, rank, drawNo-1, metaIdx, setIdx)
-	}
-
-	return nil
+		evaluated = len(evals)
+		return nil
+	})
+	return evaluated, err
 }
@@ -2,48 +2,134 @@
 package db
 
 import (
+	"context"
 	"database/sql"
-	"fmt"
+	"math"
 )
 
-func CreateDrawProbabilitiesTable(db *sql.DB) error {
-	_, err := db.Exec(`
-		CREATE TABLE IF NOT EXISTS draw_probabilities (
-			draw_number INTEGER,
-			number INTEGER,
-			probability REAL
-		)`)
-	return err
-}
-
-func CreateReappearanceProbabilitiesTable(db *sql.DB) error {
-	_, err := db.Exec(`
-		CREATE TABLE IF NOT EXISTS reappearance_probabilities (
-			draw_number INTEGER,
-			number INTEGER,
-			probability REAL
-		)`)
-	return err
-}
-
-func SaveDrawProbabilities(db *sql.DB, drawNo int, probs map[int]float64) {
-	row := db.QueryRow("SELECT COUNT(1) FROM draw_probabilities WHERE draw_number = ?", drawNo)
-	var exists int
-	row.Scan(&exists)
-
-	stmt, _ := db.Prepare("INSERT INTO draw_probabilities(draw_number, number, probability) VALUES (?, ?, ?)")
-	defer stmt.Close()
-	for num, prob := range probs {
-		if exists == 0 {
-			stmt.Exec(drawNo, num, fmt.Sprintf("%.3f", prob))
+func (s *Store) SaveDrawProbabilities(ctx context.Context, drawNo int, probs map[int]float64) error {
+	return s.saveProbabilities(ctx, "draw_probabilities", drawNo, probs)
+}
+
+func (s *Store) SaveReappearanceProbabilities(ctx context.Context, drawNo int, probs map[int]float64) error {
+	return s.saveProbabilities(ctx, "reappearance_probabilities", drawNo, probs)
+}
+
+// SaveStrategyProbabilities drawNo 회차 기준으로 전략 모델이 예측한 다음 회차 번호별 출현 확률 (전략별로 덮어씀)
+func (s *Store) SaveStrategyProbabilities(ctx context.Context, drawNo int, strategy string, probs map[int]float64) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		stmt, err := tx.PrepareContext(ctx, "INSERT OR REPLACE INTO strategy_probabilities(draw_number, strategy, number, probability) VALUES (?, ?, ?, ?)")
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+		for num, prob := range probs {
+			if _, err := stmt.ExecContext(ctx, drawNo, strategy, num, math.Round(prob*1000)/1000); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetStrategyProbabilities drawNo 회차 기준으로 저장된 strategy 전략의 번호별 확률 (없으면 빈 map)
+func (s *Store) GetStrategyProbabilities(ctx context.Context, drawNo int, strategy string) (map[int]float64, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT number, probability FROM strategy_probabilities WHERE draw_number = ? AND strategy = ?", drawNo, strategy)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	probs := map[int]float64{}
+	for rows.Next() {
+		var num int
+		var prob float64
+		if err := rows.Scan(&num, &prob); err != nil {
+			return nil, err
 		}
+		probs[num] = prob
 	}
+	return probs, rows.Err()
+}
+
+// SaveDrawEstimates drawNo 회차 기준 번호별 베이즈 추정을 draw_probabilities 행에 함께 저장 (행이 없으면 확률 없이 추가)
+func (s *Store) SaveDrawEstimates(ctx context.Context, drawNo int, estimates []NumberEstimate) error {
+	round := func(v float64) float64 { return math.Round(v*10000) / 10000 }
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		stmt, err := tx.PrepareContext(ctx, `
+			INSERT INTO draw_probabilities(draw_number, number, posterior_mean, lower95, upper95, p_above_uniform) VALUES (?, ?, ?, ?, ?, ?)
+			ON CONFLICT(draw_number, number) DO UPDATE SET
+				posterior_mean = excluded.posterior_mean,
+				lower95 = excluded.lower95,
+				upper95 = excluded.upper95,
+				p_above_uniform = excluded.p_above_uniform`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+		for _, e := range estimates {
+			if _, err := stmt.ExecContext(ctx, drawNo, e.Number, round(e.Mean), round(e.Lower), round(e.Upper), round(e.PAboveUniform)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
-func SaveReappearanceProbabilities(db *sql.DB, drawNo int, probs map[int]float64) {
-	stmt, _ := db.Prepare("INSERT INTO reappearance_probabilities(draw_number, number, probability) VALUES (?, ?, ?)")
-	defer stmt.Close()
-	for num, prob := range probs {
-		stmt.Exec(drawNo, num, fmt.Sprintf("%.3f", prob))
+// GetDrawEstimates drawNo 회차 기준으로 저장된 번호별 베이즈 추정 (번호 순, 없으면 빈 목록)
+func (s *Store) GetDrawEstimates(ctx context.Context, drawNo int) ([]NumberEstimate, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT number, posterior_mean, lower95, upper95, p_above_uniform FROM draw_probabilities
+		WHERE draw_number = ? AND posterior_mean IS NOT NULL ORDER BY number`, drawNo)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
+
+	estimates := []NumberEstimate{}
+	for rows.Next() {
+		var e NumberEstimate
+		if err := rows.Scan(&e.Number, &e.Mean, &e.Lower, &e.Upper, &e.PAboveUniform); err != nil {
+			return nil, err
+		}
+		estimates = append(estimates, e)
+	}
+	return estimates, rows.Err()
+}
+
+// GetDrawProbabilities drawNo 회차 기준으로 저장된 번호별 등장 확률 (없으면 빈 map)
+func (s *Store) GetDrawProbabilities(ctx context.Context, drawNo int) (map[int]float64, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT number, probability FROM draw_probabilities WHERE draw_number = ?", drawNo)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	probs := map[int]float64{}
+	for rows.Next() {
+		var num int
+		var prob float64
+		if err := rows.Scan(&num, &prob); err != nil {
+			return nil, err
+		}
+		probs[num] = prob
+	}
+	return probs, rows.Err()
+}
+
+// saveProbabilities (draw_number, number) 기준으로 덮어쓴다. 소수점 셋째 자리까지 저장
+func (s *Store) saveProbabilities(ctx context.Context, table string, drawNo int, probs map[int]float64) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		stmt, err := tx.PrepareContext(ctx, "INSERT OR REPLACE INTO "+table+"(draw_number, number, probability) VALUES (?, ?, ?)")
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+		for num, prob := range probs {
+			if _, err := stmt.ExecContext(ctx, drawNo, num, math.Round(prob*1000)/1000); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
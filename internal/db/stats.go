@@ -0,0 +1,79 @@
+// db/stats.go
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// TableCount 테이블별 행 개수
+type TableCount struct {
+	Table string
+	Rows  int
+}
+
+// DBStats db stats 명령에서 출력하는 DB 요약 정보
+type DBStats struct {
+	SchemaVersion int
+	FirstDraw     int
+	LatestDraw    int
+	Tables        []TableCount
+}
+
+var statsTables = []string{
+	"lotto_results",
+	"draw_probabilities",
+	"reappearance_probabilities",
+	"pair_cooccurrence",
+	"triple_cooccurrence",
+	"strategy_probabilities",
+	"prediction_meta",
+	"prediction_results",
+	"backtest_runs",
+	"backtest_results",
+	"sync_runs",
+	"tickets",
+}
+
+// Stats 스키마 버전, 저장된 회차 범위, 테이블별 행 개수
+func (s *Store) Stats(ctx context.Context) (*DBStats, error) {
+	stats := &DBStats{}
+
+	version, err := SchemaVersion(s.db)
+	if err != nil {
+		return nil, err
+	}
+	stats.SchemaVersion = version
+
+	var first, latest sql.NullInt64
+	row := s.db.QueryRowContext(ctx, "SELECT MIN(draw_number), MAX(draw_number) FROM lotto_results")
+	if err := row.Scan(&first, &latest); err != nil {
+		return nil, err
+	}
+	stats.FirstDraw = int(first.Int64)
+	stats.LatestDraw = int(latest.Int64)
+
+	for _, table := range statsTables {
+		var n int
+		if err := s.db.QueryRowContext(ctx, fmt.Sprintf("SELECT COUNT(1) FROM %s", table)).Scan(&n); err != nil {
+			return nil, fmt.Errorf("%s 조회 실패: %w", table, err)
+		}
+		stats.Tables = append(stats.Tables, TableCount{Table: table, Rows: n})
+	}
+	return stats, nil
+}
+
+// CountRows 테이블 전체 행 개수 (statsTables에 있는 테이블만)
+func (s *Store) CountRows(ctx context.Context, table string) (int, error) {
+	stats, err := s.Stats(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, t := range stats.Tables {
+		if t.Table == table {
+			return t.Rows, nil
+		}
+	}
+	return 0, fmt.Errorf("알 수 없는 테이블: %s", table)
+}
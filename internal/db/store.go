@@ -0,0 +1,68 @@
+// db/store.go
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"lottopredictor/internal/game"
+)
+
+// ErrNotFound 조회 대상 행이 없음
+var ErrNotFound = errors.New("데이터 없음")
+
+// Store DB 접근 계층. 다른 패키지는 SQL 대신 Store 메서드를 사용한다.
+type Store struct {
+	db   *sql.DB
+	game *game.Game // nil이면 game.Default
+}
+
+func NewStore(db *sql.DB) *Store {
+	return &Store{db: db}
+}
+
+// OpenStore DB를 열고 마이그레이션을 적용한 Store를 반환. 게임은 DB에 기록된 게임
+func OpenStore(path string) (*Store, error) {
+	db, err := InitDB(path)
+	if err != nil {
+		return nil, err
+	}
+	s := NewStore(db)
+	if err := s.loadGame(context.Background()); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// DB 내부 *sql.DB (마이그레이션 등 Store 밖 작업용)
+func (s *Store) DB() *sql.DB {
+	return s.db
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// withTx fn을 트랜잭션 안에서 실행. fn이 오류를 반환하면 롤백
+func (s *Store) withTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// notFound sql.ErrNoRows를 ErrNotFound로 바꾼다.
+func notFound(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,76 @@
+// db/sync_runs.go
+package db
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+)
+
+// 동기화 실행 상태
+const (
+	SyncStatusRunning = "running"
+	SyncStatusSuccess = "success"
+	SyncStatusFailed  = "failed"
+)
+
+// SyncRun 동기화 1회 실행 기록 (sync_runs 행)
+type SyncRun struct {
+	ID            int64
+	StartedAt     string
+	FinishedAt    string
+	DrawsAdded    int
+	DrawsRepaired int
+	Errors        []string
+	Status        string
+}
+
+// StartSyncRun 실행 중 상태의 sync_runs 행을 만들고 id 반환
+func (s *Store) StartSyncRun(ctx context.Context) (int64, error) {
+	res, err := s.db.ExecContext(ctx, `
+		INSERT INTO sync_runs(started_at, status)
+		VALUES (datetime('now'), ?)`, SyncStatusRunning)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+// FinishSyncRun 종료 시각, 추가/복구 회차 수, 오류 목록, 상태를 기록
+func (s *Store) FinishSyncRun(ctx context.Context, run *SyncRun) error {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE sync_runs
+		SET finished_at = datetime('now'), draws_added = ?, draws_repaired = ?,
+			error_count = ?, errors = ?, status = ?
+		WHERE id = ?`,
+		run.DrawsAdded, run.DrawsRepaired, len(run.Errors), strings.Join(run.Errors, "\n"), run.Status, run.ID)
+	return err
+}
+
+// RecentSyncRuns 최근 sync_runs 기록을 최신 순으로 limit개 반환
+func (s *Store) RecentSyncRuns(ctx context.Context, limit int) ([]SyncRun, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, started_at, finished_at, draws_added, draws_repaired, errors, status
+		FROM sync_runs
+		ORDER BY id DESC
+		LIMIT ?`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runs := []SyncRun{}
+	for rows.Next() {
+		var run SyncRun
+		var finished, errs sql.NullString
+		if err := rows.Scan(&run.ID, &run.StartedAt, &finished, &run.DrawsAdded, &run.DrawsRepaired, &errs, &run.Status); err != nil {
+			return nil, err
+		}
+		run.FinishedAt = finished.String
+		if errs.String != "" {
+			run.Errors = strings.Split(errs.String, "\n")
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
@@ -0,0 +1,243 @@
+// db/tickets.go
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Ticket 직접 산 티켓 1장 (tickets 행)
+type Ticket struct {
+	ID         int64        `json:"id"`
+	DrawNumber int          `json:"draw_number"` // 구매한 회차
+	Buyer      string       `json:"buyer"`
+	Strategy   string       `json:"strategy"` // 번호를 고른 방법 (manual 또는 예측 전략 이름)
+	Numbers    []int        `json:"numbers"`
+	Bonus      []int        `json:"bonus,omitempty"` // 보너스 풀 번호 (보너스 풀이 있는 게임만)
+	Cost       int64        `json:"cost"`            // 구매 금액 (통화 최소 단위)
+	CreatedAt  string       `json:"created_at"`
+	Check      *TicketCheck `json:"check,omitempty"` // 아직 당첨 확인 전이면 nil
+}
+
+// TicketCheck 티켓을 당첨 번호와 비교한 결과
+type TicketCheck struct {
+	Matched      int    `json:"matched"`
+	BonusMatched bool   `json:"bonus_matched"`
+	BonusMatches int    `json:"bonus_matches,omitempty"` // 일치한 보너스 풀 번호 수
+	Rank         int    `json:"rank"`                    // 게임 등수, 낙첨이면 0
+	Prize        int64  `json:"prize"`                   // 확인할 때의 당첨금
+	CheckedAt    string `json:"checked_at"`
+}
+
+// TicketFilter ListTickets 조건 (0, 빈 값은 조건 없음)
+type TicketFilter struct {
+	DrawNumber int
+	Buyer      string
+	Strategy   string
+	Pending    bool // 당첨 확인 전 티켓만
+}
+
+// TicketTotal 구매자 또는 전략별 티켓 합계
+type TicketTotal struct {
+	Key         string `json:"key"`
+	Tickets     int    `json:"tickets"`
+	Checked     int    `json:"checked"`      // 당첨 확인한 티켓 수
+	Spend       int64  `json:"spend"`        // 전체 구매 금액
+	CheckedCost int64  `json:"checked_cost"` // 당첨 확인한 티켓의 구매 금액
+	Winnings    int64  `json:"winnings"`
+}
+
+// Net 당첨 확인한 티켓의 손익 (추첨 전 티켓은 빠진다)
+func (t TicketTotal) Net() int64 {
+	return t.Winnings - t.CheckedCost
+}
+
+// ROI 당첨 확인한 티켓의 구매 금액 대비 손익 (확인한 티켓이 없으면 0)
+func (t TicketTotal) ROI() float64 {
+	if t.CheckedCost == 0 {
+		return 0
+	}
+	return float64(t.Net()) / float64(t.CheckedCost)
+}
+
+// AddTickets 티켓을 한 트랜잭션으로 저장하고 각 티켓의 ID를 채운다.
+func (s *Store) AddTickets(ctx context.Context, tickets []Ticket) error {
+	return s.withTx(ctx, func(tx *sql.Tx) error {
+		stmt, err := tx.PrepareContext(ctx, `
+			INSERT INTO tickets
+			(draw_number, buyer, strategy, num1, num2, num3, num4, num5, num6, bonus_numbers, cost, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, datetime('now'))`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		g := s.Game()
+		for i := range tickets {
+			t := &tickets[i]
+			if len(t.Numbers) != g.Main.Picks || len(t.Bonus) != g.Bonus.Picks {
+				return fmt.Errorf("티켓 번호 개수가 %s 게임과 맞지 않음: %v %v", g.ID, t.Numbers, t.Bonus)
+			}
+			args := append([]any{t.DrawNumber, t.Buyer, t.Strategy}, numberArgs(t.Numbers)...)
+			args = append(args, joinNumbers(t.Bonus), t.Cost)
+			res, err := stmt.ExecContext(ctx, args...)
+			if err != nil {
+				return err
+			}
+			if t.ID, err = res.LastInsertId(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+const ticketColumns = `id, draw_number, buyer, strategy, num1, num2, num3, num4, num5, num6, bonus_numbers, cost, created_at,
+	matched, bonus_matched, bonus_matches, rank, prize, checked_at`
+
+func scanTicket(rows *sql.Rows) (Ticket, error) {
+	var t Ticket
+	var nums numberColumns
+	var bonus, createdAt, checkedAt sql.NullString
+	var matched, bonusMatches, rank, prize sql.NullInt64
+	var bonusMatched sql.NullBool
+	dest := append([]any{&t.ID, &t.DrawNumber, &t.Buyer, &t.Strategy}, nums.dest()...)
+	dest = append(dest, &bonus, &t.Cost, &createdAt, &matched, &bonusMatched, &bonusMatches, &rank, &prize, &checkedAt)
+	if err := rows.Scan(dest...); err != nil {
+		return t, err
+	}
+	t.Numbers = nums.numbers()
+	t.Bonus = splitNumbers(bonus)
+	t.CreatedAt = createdAt.String
+	if checkedAt.Valid {
+		t.Check = &TicketCheck{
+			Matched:      int(matched.Int64),
+			BonusMatched: bonusMatched.Bool,
+			BonusMatches: int(bonusMatches.Int64),
+			Rank:         int(rank.Int64),
+			Prize:        prize.Int64,
+			CheckedAt:    checkedAt.String,
+		}
+	}
+	return t, nil
+}
+
+// ListTickets 조건에 맞는 티켓을 회차, 저장 순으로 반환
+func (s *Store) ListTickets(ctx context.Context, f TicketFilter) ([]Ticket, error) {
+	query := "SELECT " + ticketColumns + " FROM tickets WHERE 1 = 1"
+	args := []any{}
+	if f.DrawNumber > 0 {
+		query += " AND draw_number = ?"
+		args = append(args, f.DrawNumber)
+	}
+	if f.Buyer != "" {
+		query += " AND buyer = ?"
+		args = append(args, f.Buyer)
+	}
+	if f.Strategy != "" {
+		query += " AND strategy = ?"
+		args = append(args, f.Strategy)
+	}
+	if f.Pending {
+		query += " AND checked_at IS NULL"
+	}
+	query += " ORDER BY draw_number, id"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tickets := []Ticket{}
+	for rows.Next() {
+		t, err := scanTicket(rows)
+		if err != nil {
+			return nil, err
+		}
+		tickets = append(tickets, t)
+	}
+	return tickets, rows.Err()
+}
+
+// PendingTicketDraws 당첨 번호가 저장됐지만 아직 확인하지 않은 티켓이 있는 회차 목록 (오름차순)
+func (s *Store) PendingTicketDraws(ctx context.Context) ([]int, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT DISTINCT t.draw_number
+		FROM tickets t
+		JOIN lotto_results l ON l.draw_number = t.draw_number
+		WHERE t.checked_at IS NULL
+		ORDER BY t.draw_number`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	draws := []int{}
+	for rows.Next() {
+		var drawNo int
+		if err := rows.Scan(&drawNo); err != nil {
+			return nil, err
+		}
+		draws = append(draws, drawNo)
+	}
+	return draws, rows.Err()
+}
+
+// CheckTickets draw 회차 티켓을 모두 DB 게임의 등수 규칙으로 당첨 번호와 비교해 일치 개수, 등수,
+// prize(rank) 당첨금, 확인 시각을 기록하고 확인한 티켓을 반환한다. 이미 확인한 티켓도 다시 확인한다.
+func (s *Store) CheckTickets(ctx context.Context, draw *Draw, prize func(rank int) int64) ([]Ticket, error) {
+	tickets, err := s.ListTickets(ctx, TicketFilter{DrawNumber: draw.Number})
+	if err != nil {
+		return nil, err
+	}
+	g := s.Game()
+	err = s.withTx(ctx, func(tx *sql.Tx) error {
+		for i := range tickets {
+			t := &tickets[i]
+			m := g.Rank(t.Numbers, t.Bonus, draw.Numbers, draw.Bonus, draw.BonusNumbers)
+			t.Check = &TicketCheck{Matched: m.Main, BonusMatched: m.Extra, BonusMatches: m.Bonus, Rank: m.Rank, Prize: prize(m.Rank)}
+			_, err := tx.ExecContext(ctx, `
+				UPDATE tickets
+				SET matched = ?, bonus_matched = ?, bonus_matches = ?, rank = ?, prize = ?, checked_at = datetime('now')
+				WHERE id = ?`,
+				m.Main, m.Extra, m.Bonus, m.Rank, t.Check.Prize, t.ID)
+			if err != nil {
+				return fmt.Errorf("티켓 #%d 확인 결과 저장 실패: %w", t.ID, err)
+			}
+		}
+		return nil
+	})
+	return tickets, err
+}
+
+// TicketTotals by 컬럼(buyer 또는 strategy)별 티켓 수, 구매 금액, 당첨금 합계
+func (s *Store) TicketTotals(ctx context.Context, by string) ([]TicketTotal, error) {
+	if by != "buyer" && by != "strategy" {
+		return nil, fmt.Errorf("알 수 없는 티켓 합계 기준: %s", by)
+	}
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT %[1]s, COUNT(1),
+			COUNT(checked_at),
+			SUM(cost),
+			COALESCE(SUM(CASE WHEN checked_at IS NOT NULL THEN cost END), 0),
+			COALESCE(SUM(prize), 0)
+		FROM tickets
+		GROUP BY %[1]s
+		ORDER BY %[1]s`, by))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := []TicketTotal{}
+	for rows.Next() {
+		var t TicketTotal
+		if err := rows.Scan(&t.Key, &t.Tickets, &t.Checked, &t.Spend, &t.CheckedCost, &t.Winnings); err != nil {
+			return nil, err
+		}
+		totals = append(totals, t)
+	}
+	return totals, rows.Err()
+}
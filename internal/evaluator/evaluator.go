@@ -0,0 +1,100 @@
+// internal/evaluator/evaluator.go
+package evaluator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"lottopredictor/internal/common"
+	"lottopredictor/internal/config"
+	"lottopredictor/internal/db"
+	"lottopredictor/internal/game"
+)
+
+// RunSummary 저장된 예측 1회(prediction_meta 행)의 평가 요약
+type RunSummary struct {
+	DrawNumber int         `json:"draw_number"`
+	Idx        int         `json:"idx"`
+	Strategy   string      `json:"strategy"`
+	Sets       int         `json:"sets"`
+	Evaluated  int         `json:"evaluated"`
+	HitCounts  [7]int      `json:"hit_counts"`  // 일치 개수별 세트 수
+	RankCounts map[int]int `json:"rank_counts"` // 등수별 세트 수 (0 = 낙첨)
+	BestRank   int         `json:"best_rank"`   // 가장 높은 등수, 당첨 세트가 없으면 0
+	Prize      int64       `json:"prize"`       // 세트 전체 당첨금 합계
+}
+
+// Summarize g 게임 draw 결과로 평가된 run의 세트별 평가를 집계한다. (평가 전 세트는 Evaluated에서 빠짐)
+// 1등 당첨금은 회차의 실제 1인당 당첨금 자료가 있으면 그 값을 사용한다.
+func Summarize(g *game.Game, run *db.PredictionRun, draw *db.Draw) RunSummary {
+	summary := RunSummary{
+		DrawNumber: run.DrawNumber,
+		Idx:        run.Idx,
+		Strategy:   run.Strategy,
+		Sets:       len(run.Sets),
+		RankCounts: map[int]int{},
+	}
+	for _, set := range run.Sets {
+		e := set.Evaluation
+		if e == nil {
+			continue
+		}
+		summary.Evaluated++
+		summary.HitCounts[e.Matched]++
+		summary.RankCounts[e.Rank]++
+		if e.Rank != common.RankNone && (summary.BestRank == 0 || e.Rank < summary.BestRank) {
+			summary.BestRank = e.Rank
+		}
+
+		prize := config.PrizeAmount(g, e.Rank)
+		if e.Rank == common.RankFirst && draw != nil && draw.FirstPrize > 0 {
+			prize = draw.FirstPrize
+		}
+		summary.Prize += prize
+	}
+	return summary
+}
+
+// EvaluateDraw drawNo 회차 예측 중 평가 전 세트를 채점하고 그 회차 모든 예측의 요약을 반환
+// 당첨 번호가 아직 없으면 db.ErrNotFound
+func EvaluateDraw(ctx context.Context, store *db.Store, drawNo int) ([]RunSummary, error) {
+	draw, err := store.GetDraw(ctx, drawNo)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := store.EvaluatePredictions(ctx, draw); err != nil {
+		return nil, fmt.Errorf("회차 %d 예측 평가 실패: %w", drawNo, err)
+	}
+
+	runs, err := store.ListPredictionRuns(ctx, drawNo, 0)
+	if err != nil {
+		return nil, err
+	}
+	summaries := []RunSummary{}
+	for i := len(runs) - 1; i >= 0; i-- { // 실행 순서대로
+		summaries = append(summaries, Summarize(store.Game(), &runs[i], draw))
+	}
+	return summaries, nil
+}
+
+// EvaluatePending 당첨 번호가 저장된 회차 중 평가 전 세트가 남은 모든 회차를 평가하고 요약을 반환
+// sync로 새 회차가 추가된 뒤 호출한다.
+func EvaluatePending(ctx context.Context, store *db.Store) ([]RunSummary, error) {
+	draws, err := store.PendingEvaluationDraws(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("평가 대기 회차 조회 실패: %w", err)
+	}
+	summaries := []RunSummary{}
+	for _, drawNo := range draws {
+		s, err := EvaluateDraw(ctx, store, drawNo)
+		if errors.Is(err, db.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return summaries, err
+		}
+		summaries = append(summaries, s...)
+	}
+	return summaries, nil
+}
@@ -0,0 +1,80 @@
+// internal/fetcher/fake.go
+package fetcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// FakeHandler 기록된 회차 데이터를 동행복권 API와 같은 형식으로 응답하는 핸들러
+// 없는 회차는 실제 API처럼 {"returnValue":"fail"}을 돌려준다.
+type FakeHandler struct {
+	mu    sync.RWMutex
+	draws map[int]*DrawData
+}
+
+func NewFakeHandler(draws []*DrawData) *FakeHandler {
+	h := &FakeHandler{draws: map[int]*DrawData{}}
+	for _, d := range draws {
+		h.Add(d)
+	}
+	return h
+}
+
+// Add 회차 데이터를 추가/교체 (테스트 중 새 회차 추첨을 흉내낼 때 사용)
+func (h *FakeHandler) Add(d *DrawData) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	copied := *d
+	copied.ReturnValue = "success"
+	h.draws[d.DrwNo] = &copied
+}
+
+func (h *FakeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("method") != "getLottoNumber" {
+		http.Error(w, "unknown method", http.StatusBadRequest)
+		return
+	}
+	drawNo, err := strconv.Atoi(q.Get("drwNo"))
+	if err != nil {
+		http.Error(w, "invalid drwNo", http.StatusBadRequest)
+		return
+	}
+
+	h.mu.RLock()
+	d, ok := h.draws[drawNo]
+	h.mu.RUnlock()
+
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	if !ok {
+		w.Write([]byte(`{"returnValue":"fail"}`))
+		return
+	}
+	json.NewEncoder(w).Encode(d)
+}
+
+// NewFakeServer 기록된 회차 데이터를 응답하는 httptest 서버를 띄운다. 사용 후 Close 필요
+// 클라이언트는 NewClient(게임, srv.URL+"/common.do", srv.Client())로 연결한다.
+func NewFakeServer(draws []*DrawData) (*httptest.Server, *FakeHandler) {
+	h := NewFakeHandler(draws)
+	return httptest.NewServer(h), h
+}
+
+// LoadRecordedDraws DrawData JSON 배열 파일을 읽는다.
+func LoadRecordedDraws(path string) ([]*DrawData, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var draws []*DrawData
+	if err := json.Unmarshal(b, &draws); err != nil {
+		return nil, fmt.Errorf("%s 파싱 실패: %w", path, err)
+	}
+	return draws, nil
+}
@@ -2,10 +2,17 @@
 package fetcher
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"lottopredictor/internal/game"
 )
 
 type DrawData struct {
@@ -19,48 +26,129 @@ type DrawData struct {
 	DrwtNo6     int    `json:"drwtNo6"`
 	BnusNo      int    `json:"bnusNo"`
 	DrwNoDate   string `json:"drwNoDate"`
+	// BnusNos 따로 뽑는 보너스 풀 번호. 동행복권 API에는 없고 다른 게임의 가짜 API / 가져오기 파일용
+	BnusNos []int `json:"bnusNos,omitempty"`
+
+	TotSellamnt    int64 `json:"totSellamnt"`    // 총 판매 금액
+	FirstWinamnt   int64 `json:"firstWinamnt"`   // 1등 1인당 당첨금
+	FirstPrzwnerCo int   `json:"firstPrzwnerCo"` // 1등 당첨자 수
+	FirstAccumamnt int64 `json:"firstAccumamnt"` // 1등 총 당첨금
 }
 
-const apiURL = "https://www.dhlottery.co.kr/common.do?method=getLottoNumber&drwNo=%d"
+// Numbers 0이 아닌 drwtNo1~6 (본 번호가 6개보다 적은 게임은 뒤 필드가 0)
+func (d *DrawData) Numbers() []int {
+	nums := []int{}
+	for _, n := range []int{d.DrwtNo1, d.DrwtNo2, d.DrwtNo3, d.DrwtNo4, d.DrwtNo5, d.DrwtNo6} {
+		if n != 0 {
+			nums = append(nums, n)
+		}
+	}
+	return nums
+}
 
-func FetchDrawData(drawNo int) (*DrawData, error) {
-	url := fmt.Sprintf(apiURL, drawNo)
-	resp, err := http.Get(url)
+// SetNumbers 본 번호를 drwtNo1부터 채운다. (최대 6개, 남는 필드는 0)
+func (d *DrawData) SetNumbers(nums []int) {
+	fields := []*int{&d.DrwtNo1, &d.DrwtNo2, &d.DrwtNo3, &d.DrwtNo4, &d.DrwtNo5, &d.DrwtNo6}
+	for i, f := range fields {
+		*f = 0
+		if i < len(nums) {
+			*f = nums[i]
+		}
+	}
+}
+
+const (
+	// DefaultBaseURL 동행복권 당첨 번호 조회 API
+	DefaultBaseURL = "https://www.dhlottery.co.kr/common.do"
+	// DefaultTimeout 요청 1건당 기본 타임아웃
+	DefaultTimeout = 10 * time.Second
+)
+
+// ErrDrawNotFound API가 응답은 했지만 해당 회차 결과가 없음 (returnValue != success, 아직 추첨 전 등)
+var ErrDrawNotFound = errors.New("회차 결과 없음")
+
+// DrawSource 회차 번호로 당첨 번호를 가져오는 데이터 소스
+type DrawSource interface {
+	// Game 소스가 주는 당첨 번호의 게임. 동기화는 DB 게임과 같을 때만 한다.
+	Game() *game.Game
+	FetchDraw(ctx context.Context, drawNo int) (*DrawData, error)
+}
+
+// Client 동행복권 API 형식(getLottoNumber)의 HTTP DrawSource
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+
+	game *game.Game
+}
+
+// NewClient g 게임 당첨 번호를 주는 API 클라이언트. g가 nil이면 로또 6/45,
+// baseURL이 비어 있으면 DefaultBaseURL, httpClient가 nil이면 DefaultTimeout 클라이언트 사용
+// 동행복권 API는 로또 6/45만 제공하므로 다른 게임은 같은 형식으로 응답하는 API 주소가 필요하다.
+func NewClient(g *game.Game, baseURL string, httpClient *http.Client) (*Client, error) {
+	if g == nil {
+		g = game.Lotto645
+	}
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	if baseURL == DefaultBaseURL && g != game.Lotto645 {
+		return nil, fmt.Errorf("동행복권 API는 %s 전용: %s 당첨 번호는 같은 형식의 API 주소를 지정해야 함", game.Lotto645.Name, g.Name)
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: DefaultTimeout}
+	}
+	return &Client{BaseURL: baseURL, HTTPClient: httpClient, game: g}, nil
+}
+
+// Game 클라이언트가 조회하는 게임
+func (c *Client) Game() *game.Game { return c.game }
+
+// FetchDraw drawNo 회차 당첨 번호 조회. 결과가 없으면 ErrDrawNotFound를 감싼 오류 반환
+func (c *Client) FetchDraw(ctx context.Context, drawNo int) (*DrawData, error) {
+	u, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("잘못된 API 주소 %q: %w", c.BaseURL, err)
+	}
+	q := u.Query()
+	q.Set("method", "getLottoNumber")
+	q.Set("drwNo", strconv.Itoa(drawNo))
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
+	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
-
-	var data DrawData
-	err = json.Unmarshal(body, &data)
-	if err != nil || data.DrwNo == 0 {
-		return nil, fmt.Errorf("no data or invalid response for draw %d", drawNo)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("회차 %d 조회 실패: HTTP %d", drawNo, resp.StatusCode)
 	}
-	return &data, nil
-}
-
-func FetchDrawResult(drawNo int) (*DrawData, error) {
-	url := fmt.Sprintf("https://www.dhlottery.co.kr/common.do?method=getLottoNumber&drwNo=%d", drawNo)
-	resp, err := http.Get(url)
+	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
 
 	var data DrawData
 	if err := json.Unmarshal(body, &data); err != nil {
-		return nil, err
+		return nil, fmt.Errorf("회차 %d 응답 파싱 실패: %w", drawNo, err)
 	}
-
-	if data.ReturnValue != "success" {
-		return nil, fmt.Errorf("회차 %d 결과 없음", drawNo)
+	if data.ReturnValue != "success" || data.DrwNo == 0 {
+		return nil, fmt.Errorf("회차 %d: %w", drawNo, ErrDrawNotFound)
 	}
-
 	return &data, nil
 }
+
+// DefaultSource FetchDrawData / FetchDrawResult가 사용하는 기본 소스 (동행복권 로또 6/45)
+var DefaultSource DrawSource = &Client{BaseURL: DefaultBaseURL, HTTPClient: &http.Client{Timeout: DefaultTimeout}, game: game.Lotto645}
+
+func FetchDrawData(drawNo int) (*DrawData, error) {
+	return DefaultSource.FetchDraw(context.Background(), drawNo)
+}
+
+func FetchDrawResult(drawNo int) (*DrawData, error) {
+	return DefaultSource.FetchDraw(context.Background(), drawNo)
+}
@@ -0,0 +1,367 @@
+// internal/game/game.go
+package game
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"lottopredictor/internal/common"
+)
+
+// Pool 번호 풀. 1 ~ Max 중 Picks개를 고르고 같은 수를 추첨한다.
+type Pool struct {
+	Max   int `json:"max"`
+	Picks int `json:"picks"`
+}
+
+// Tier 당첨 등수 하나. 등수 순으로 처음 조건이 맞는 등수가 된다.
+type Tier struct {
+	Rank  int   `json:"rank"`
+	Main  int   `json:"main"`  // 일치한 본 번호 수
+	Bonus int   `json:"bonus"` // 일치한 보너스 풀 번호 수
+	Extra bool  `json:"extra"` // 본 번호 풀에서 추가로 뽑은 보너스 번호 일치 필요
+	Prize int64 `json:"prize"` // 기본 당첨금 (Currency 최소 단위)
+	Fixed bool  `json:"fixed"` // 고정 당첨금 등수 (설정 파일 prizes로 바꿀 수 없음)
+}
+
+// Currency 당첨금/구매 비용 표시 단위. 금액은 모두 최소 단위(원, 센트 등) 정수다.
+type Currency struct {
+	Code  string `json:"code"`
+	Unit  string `json:"unit"`  // 금액 뒤에 붙이는 표시 ("원", "EUR")
+	Minor int    `json:"minor"` // 1 단위당 최소 단위 수 (원 1, 유로 100)
+}
+
+// Game 추첨 방식과 당첨 등수 정의
+type Game struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Main  Pool   `json:"main"`
+	Extra int    `json:"extra"` // 본 번호 풀에서 추가로 뽑는 보너스 번호 수 (0 또는 1, 로또 6/45는 1)
+	Bonus Pool   `json:"bonus"` // 따로 뽑고 구매자도 고르는 보너스 풀 (Picks 0이면 없음)
+
+	TicketPrice int64    `json:"ticket_price"`
+	Currency    Currency `json:"currency"`
+	Tiers       []Tier   `json:"tiers"`
+}
+
+// MaxMainPicks lotto_results / prediction_results의 본 번호 컬럼 수
+const MaxMainPicks = 6
+
+// 원화 / 외화 표시 단위
+var (
+	KRW = Currency{Code: "KRW", Unit: "원", Minor: 1}
+	CAD = Currency{Code: "CAD", Unit: "CAD", Minor: 100}
+	EUR = Currency{Code: "EUR", Unit: "EUR", Minor: 100}
+	USD = Currency{Code: "USD", Unit: "USD", Minor: 100}
+)
+
+// 내장 게임. 1등 등 변동 당첨금은 평균에 가까운 기본값이고 설정 파일 prizes로 덮어쓸 수 있다. Fixed 등수는 고정 금액이다.
+var (
+	// Lotto645 한국 로또 6/45 (기본 게임)
+	Lotto645 = &Game{
+		ID: "lotto645", Name: "로또 6/45",
+		Main: Pool{Max: 45, Picks: 6}, Extra: 1,
+		TicketPrice: common.TicketPrice, Currency: KRW,
+		Tiers: []Tier{
+			{Rank: common.RankFirst, Main: 6, Prize: common.PrizeFirst},
+			{Rank: common.RankSecond, Main: 5, Extra: true, Prize: common.PrizeSecond},
+			{Rank: common.RankThird, Main: 5, Prize: common.PrizeThird},
+			{Rank: common.RankFourth, Main: 4, Prize: common.PrizeFourth, Fixed: true},
+			{Rank: common.RankFifth, Main: 3, Prize: common.PrizeFifth, Fixed: true},
+		},
+	}
+	// Lotto649 6/49 + 보너스 번호 (캐나다 Lotto 6/49 방식 등수)
+	Lotto649 = &Game{
+		ID: "lotto649", Name: "Lotto 6/49",
+		Main: Pool{Max: 49, Picks: 6}, Extra: 1,
+		TicketPrice: 300, Currency: CAD,
+		Tiers: []Tier{
+			{Rank: 1, Main: 6, Prize: 500000000},
+			{Rank: 2, Main: 5, Extra: true, Prize: 10000000},
+			{Rank: 3, Main: 5, Prize: 250000},
+			{Rank: 4, Main: 4, Prize: 8000},
+			{Rank: 5, Main: 3, Prize: 1000, Fixed: true},
+			{Rank: 6, Main: 2, Extra: true, Prize: 500, Fixed: true},
+			{Rank: 7, Main: 2, Prize: 300, Fixed: true},
+		},
+	}
+	// EuroMillions 5/50 + 별 2/12
+	EuroMillions = &Game{
+		ID: "euromillions", Name: "EuroMillions 5/50 + 2/12",
+		Main: Pool{Max: 50, Picks: 5}, Bonus: Pool{Max: 12, Picks: 2},
+		TicketPrice: 250, Currency: EUR,
+		Tiers: []Tier{
+			{Rank: 1, Main: 5, Bonus: 2, Prize: 1700000000},
+			{Rank: 2, Main: 5, Bonus: 1, Prize: 30000000},
+			{Rank: 3, Main: 5, Bonus: 0, Prize: 3000000},
+			{Rank: 4, Main: 4, Bonus: 2, Prize: 150000},
+			{Rank: 5, Main: 4, Bonus: 1, Prize: 15000},
+			{Rank: 6, Main: 3, Bonus: 2, Prize: 6000},
+			{Rank: 7, Main: 4, Bonus: 0, Prize: 4000},
+			{Rank: 8, Main: 2, Bonus: 2, Prize: 1500},
+			{Rank: 9, Main: 3, Bonus: 1, Prize: 1200},
+			{Rank: 10, Main: 3, Bonus: 0, Prize: 1000},
+			{Rank: 11, Main: 1, Bonus: 2, Prize: 800},
+			{Rank: 12, Main: 2, Bonus: 1, Prize: 600},
+			{Rank: 13, Main: 2, Bonus: 0, Prize: 400},
+		},
+	}
+	// Powerball 5/69 + 파워볼 1/26
+	Powerball = &Game{
+		ID: "powerball", Name: "Powerball 5/69 + 1/26",
+		Main: Pool{Max: 69, Picks: 5}, Bonus: Pool{Max: 26, Picks: 1},
+		TicketPrice: 200, Currency: USD,
+		Tiers: []Tier{
+			{Rank: 1, Main: 5, Bonus: 1, Prize: 4000000000},
+			{Rank: 2, Main: 5, Bonus: 0, Prize: 100000000, Fixed: true},
+			{Rank: 3, Main: 4, Bonus: 1, Prize: 5000000, Fixed: true},
+			{Rank: 4, Main: 4, Bonus: 0, Prize: 10000, Fixed: true},
+			{Rank: 5, Main: 3, Bonus: 1, Prize: 10000, Fixed: true},
+			{Rank: 6, Main: 3, Bonus: 0, Prize: 700, Fixed: true},
+			{Rank: 7, Main: 2, Bonus: 1, Prize: 700, Fixed: true},
+			{Rank: 8, Main: 1, Bonus: 1, Prize: 400, Fixed: true},
+			{Rank: 9, Main: 0, Bonus: 1, Prize: 400, Fixed: true},
+		},
+	}
+)
+
+// Default 설정에 게임이 없을 때 쓰는 게임
+var Default = Lotto645
+
+var games = map[string]*Game{}
+
+func init() {
+	for _, g := range []*Game{Lotto645, Lotto649, EuroMillions, Powerball} {
+		if err := g.Validate(); err != nil {
+			panic(err)
+		}
+		games[g.ID] = g
+	}
+}
+
+// Get id로 게임을 찾는다. 빈 id는 Default
+func Get(id string) (*Game, error) {
+	if id == "" {
+		return Default, nil
+	}
+	g, ok := games[id]
+	if !ok {
+		return nil, fmt.Errorf("알 수 없는 게임 %q (사용 가능: %s)", id, strings.Join(IDs(), ", "))
+	}
+	return g, nil
+}
+
+// IDs 내장 게임 id 목록 (정렬)
+func IDs() []string {
+	ids := make([]string, 0, len(games))
+	for id := range games {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Validate 풀 크기, 등수 정의 확인
+func (g *Game) Validate() error {
+	if g.Main.Picks < 1 || g.Main.Picks > MaxMainPicks || g.Main.Max < g.Main.Picks+g.Extra {
+		return fmt.Errorf("게임 %s: 본 번호 풀 %d/%d가 잘못됨 (최대 %d개)", g.ID, g.Main.Picks, g.Main.Max, MaxMainPicks)
+	}
+	if g.Extra < 0 || g.Extra > 1 {
+		return fmt.Errorf("게임 %s: 보너스 번호 수 %d가 잘못됨 (0 또는 1)", g.ID, g.Extra)
+	}
+	if g.Bonus.Picks < 0 || (g.Bonus.Picks > 0 && g.Bonus.Max < g.Bonus.Picks) {
+		return fmt.Errorf("게임 %s: 보너스 풀 %d/%d가 잘못됨", g.ID, g.Bonus.Picks, g.Bonus.Max)
+	}
+	for i, t := range g.Tiers {
+		if t.Rank != i+1 || t.Main > g.Main.Picks || t.Bonus > g.Bonus.Picks || (t.Extra && g.Extra == 0) {
+			return fmt.Errorf("게임 %s: %d등 조건이 잘못됨", g.ID, i+1)
+		}
+	}
+	return nil
+}
+
+// Ranks 등수 수 (낙첨 제외)
+func (g *Game) Ranks() int { return len(g.Tiers) }
+
+// Prize rank 등수 기본 당첨금 (낙첨이나 없는 등수는 0)
+func (g *Game) Prize(rank int) int64 {
+	if rank < 1 || rank > len(g.Tiers) {
+		return 0
+	}
+	return g.Tiers[rank-1].Prize
+}
+
+// FixedPrize rank 등수가 고정 당첨금인지 (없는 등수는 false)
+func (g *Game) FixedPrize(rank int) bool {
+	if rank < 1 || rank > len(g.Tiers) {
+		return false
+	}
+	return g.Tiers[rank-1].Fixed
+}
+
+// Uniform 균등 추첨일 때 본 번호 하나의 회차당 출현 확률 (%)
+func (g *Game) Uniform() float64 { return float64(g.Main.Picks) / float64(g.Main.Max) * 100 }
+
+// FormatMoney 최소 단위 금액을 표시 단위로 ("1,000원", "2.50 EUR")
+func (g *Game) FormatMoney(v int64) string {
+	if g.Currency.Minor <= 1 {
+		return groupDigits(v) + g.Currency.Unit
+	}
+	minor := int64(g.Currency.Minor)
+	sign := ""
+	if v < 0 {
+		sign, v = "-", -v
+	}
+	return fmt.Sprintf("%s%s.%02d %s", sign, groupDigits(v/minor), v%minor, g.Currency.Unit)
+}
+
+// FormatAverage 평균/기대 금액(최소 단위 실수)을 표시 단위로 ("523.4원", "1.234 EUR")
+func (g *Game) FormatAverage(v float64) string {
+	if g.Currency.Minor <= 1 {
+		return fmt.Sprintf("%.1f%s", v, g.Currency.Unit)
+	}
+	return fmt.Sprintf("%.3f %s", v/float64(g.Currency.Minor), g.Currency.Unit)
+}
+
+func groupDigits(v int64) string {
+	s := fmt.Sprint(v)
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
+
+// Match 세트 하나를 당첨 번호와 비교한 결과
+type Match struct {
+	Main  int  // 일치한 본 번호 수
+	Bonus int  // 일치한 보너스 풀 번호 수
+	Extra bool // 보너스 번호 일치
+	Rank  int  // 등수 (낙첨 0)
+}
+
+// RankOf 일치 개수로 정한 등수 (낙첨 0)
+func (g *Game) RankOf(main, bonus int, extra bool) int {
+	for _, t := range g.Tiers {
+		if t.Main == main && t.Bonus == bonus && (!t.Extra || extra) {
+			return t.Rank
+		}
+	}
+	return 0
+}
+
+// Rank 세트(본 번호 nums, 보너스 풀 번호 bonus)를 당첨 번호(winning, 보너스 번호 extra, 보너스 풀 당첨 번호 winningBonus)와 비교한다.
+func (g *Game) Rank(nums, bonus, winning []int, extra int, winningBonus []int) Match {
+	m := Match{}
+	for _, n := range nums {
+		if slices.Contains(winning, n) {
+			m.Main++
+		}
+		if extra > 0 && n == extra {
+			m.Extra = true
+		}
+	}
+	for _, n := range bonus {
+		if slices.Contains(winningBonus, n) {
+			m.Bonus++
+		}
+	}
+	m.Rank = g.RankOf(m.Main, m.Bonus, m.Extra)
+	return m
+}
+
+// Odds 세트 하나가 rank 등수가 될 확률 (균등 추첨)
+func (g *Game) Odds(rank int) float64 {
+	if rank < 1 || rank > len(g.Tiers) {
+		return 0
+	}
+	t := g.Tiers[rank-1]
+	p := hypergeometric(g.Main, t.Main) * hypergeometric(g.Bonus, t.Bonus)
+	if g.Extra == 0 {
+		return p
+	}
+	// 보너스 번호는 당첨 번호를 뺀 나머지에서 뽑히므로 일치하지 않은 내 번호 중 하나일 확률
+	extra := float64(g.Main.Picks-t.Main) / float64(g.Main.Max-g.Main.Picks)
+	if t.Extra {
+		return p * extra
+	}
+	for _, prev := range g.Tiers[:rank-1] {
+		if prev.Extra && prev.Main == t.Main && prev.Bonus == t.Bonus {
+			return p * (1 - extra)
+		}
+	}
+	return p
+}
+
+// ExpectedValue 세트 하나의 기대 당첨금 (균등 추첨). prizes가 nil이면 기본 당첨금
+func (g *Game) ExpectedValue(prizes map[int]int64) float64 {
+	ev := 0.0
+	for _, t := range g.Tiers {
+		prize := t.Prize
+		if v, ok := prizes[t.Rank]; ok {
+			prize = v
+		}
+		ev += g.Odds(t.Rank) * float64(prize)
+	}
+	return ev
+}
+
+// hypergeometric 풀에서 Picks개를 고르고 Picks개를 추첨할 때 k개 일치할 확률. 풀이 없으면 k == 0일 때 1
+func hypergeometric(p Pool, k int) float64 {
+	if p.Picks == 0 {
+		if k == 0 {
+			return 1
+		}
+		return 0
+	}
+	return float64(common.Binomial(p.Picks, k)*common.Binomial(p.Max-p.Picks, p.Picks-k)) / float64(common.Binomial(p.Max, p.Picks))
+}
+
+// CheckDraw 당첨 번호가 게임 규칙에 맞는지 (본 번호 Picks개, 보너스 번호, 보너스 풀 번호 범위와 중복)
+func (g *Game) CheckDraw(nums []int, extra int, bonus []int) error {
+	if err := checkNumbers("당첨 번호", nums, g.Main); err != nil {
+		return err
+	}
+	if g.Extra > 0 {
+		if extra < 1 || extra > g.Main.Max {
+			return fmt.Errorf("보너스 %d 범위 밖", extra)
+		}
+		if slices.Contains(nums, extra) {
+			return fmt.Errorf("보너스 %d 가 당첨 번호와 중복", extra)
+		}
+	} else if extra != 0 {
+		return fmt.Errorf("%s에는 보너스 번호가 없음: %d", g.Name, extra)
+	}
+	return checkNumbers("보너스 풀 번호", bonus, g.Bonus)
+}
+
+// CheckTicket 구매/추천 세트가 게임 규칙에 맞는지
+func (g *Game) CheckTicket(nums, bonus []int) error {
+	if err := checkNumbers("번호", nums, g.Main); err != nil {
+		return err
+	}
+	return checkNumbers("보너스 풀 번호", bonus, g.Bonus)
+}
+
+func checkNumbers(what string, nums []int, p Pool) error {
+	if len(nums) != p.Picks {
+		return fmt.Errorf("%s %d개가 필요함: %v", what, p.Picks, nums)
+	}
+	seen := map[int]bool{}
+	for _, n := range nums {
+		if n < 1 || n > p.Max {
+			return fmt.Errorf("%s %d 범위 밖 (1~%d)", what, n, p.Max)
+		}
+		if seen[n] {
+			return fmt.Errorf("%s %d 중복", what, n)
+		}
+		seen[n] = true
+	}
+	return nil
+}
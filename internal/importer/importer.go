@@ -0,0 +1,362 @@
+// internal/importer/importer.go
+// 네트워크 없이 로컬 CSV / JSON / XLSX 파일에서 당첨 번호 이력을 읽어 오는 패키지
+package importer
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"lottopredictor/internal/db"
+	"lottopredictor/internal/fetcher"
+	"lottopredictor/internal/game"
+	"lottopredictor/internal/xlsx"
+)
+
+// 지원하는 입력 형식
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+	FormatXLSX = "xlsx"
+)
+
+// DetectFormat 파일 확장자로 형식 추정
+func DetectFormat(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".json":
+		return FormatJSON, nil
+	case ".xlsx":
+		return FormatXLSX, nil
+	}
+	return "", fmt.Errorf("확장자로 형식을 알 수 없음: %s (-format 지정 필요)", path)
+}
+
+// ReadFile format 형식의 파일에서 g 게임 회차 데이터를 읽는다. 검증은 하지 않는다.
+func ReadFile(path, format string, g *game.Game) ([]*fetcher.DrawData, error) {
+	switch format {
+	case FormatJSON:
+		draws, err := fetcher.LoadRecordedDraws(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range draws {
+			d.ReturnValue = "success"
+		}
+		return draws, nil
+	case FormatCSV:
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r := csv.NewReader(f)
+		r.FieldsPerRecord = -1
+		r.TrimLeadingSpace = true
+		rows, err := r.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("CSV 파싱 실패: %w", err)
+		}
+		return parseTable(rows, g)
+	case FormatXLSX:
+		rows, err := xlsx.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("XLSX 파싱 실패: %w", err)
+		}
+		return parseTable(rows, g)
+	}
+	return nil, fmt.Errorf("지원하지 않는 형식: %s", format)
+}
+
+// 헤더 이름 → 컬럼 종류. API 필드명, 영문 컬럼명, 동행복권 엑셀 헤더를 모두 받는다.
+var headerAliases = map[string]string{
+	"draw_number": "draw", "drwno": "draw", "draw": "draw", "회차": "draw",
+	"draw_date": "date", "drwnodate": "date", "date": "date", "추첨일": "date",
+	"bonus": "bonus", "bnusno": "bonus", "보너스": "bonus",
+	"star1": "b1", "star2": "b2", "powerball": "b1", "pb": "b1",
+	"total_sales": "sales", "totsellamnt": "sales",
+	"first_winners": "winners", "firstprzwnerco": "winners",
+	"first_prize": "prize", "firstwinamnt": "prize",
+	"first_total": "total", "firstaccumamnt": "total",
+}
+
+func init() {
+	for i := 1; i <= game.MaxMainPicks; i++ {
+		headerAliases[fmt.Sprintf("n%d", i)] = fmt.Sprintf("n%d", i)
+		headerAliases[fmt.Sprintf("drwtno%d", i)] = fmt.Sprintf("n%d", i)
+		headerAliases[fmt.Sprintf("b%d", i)] = fmt.Sprintf("b%d", i)
+	}
+}
+
+// parseTable 표 형태 데이터를 g 게임 회차 데이터로 변환. 본 번호 K개, 보너스 풀 번호 M개일 때
+//   - 헤더가 있으면 컬럼 이름으로 매핑 (회차 컬럼은 필수, 보너스 풀 번호는 b1~bM)
+//   - 번호 컬럼 이름이 없으면 (동행복권 엑셀처럼 병합 헤더) 행의 마지막 숫자들을 n1~nK, 보너스, b1~bM으로 사용
+//   - 헤더가 없으면 회차, 추첨일, n1~nK, 보너스 (보너스 번호가 있는 게임만), b1~bM 순서로 간주
+func parseTable(rows [][]string, g *game.Game) ([]*fetcher.DrawData, error) {
+	cols := map[string]int{}
+	start := 0
+	for i, row := range rows {
+		found := map[string]int{}
+		for j, cell := range row {
+			if kind, ok := headerAliases[strings.ToLower(strings.TrimSpace(cell))]; ok {
+				if _, dup := found[kind]; !dup {
+					found[kind] = j
+				}
+			}
+		}
+		if _, ok := found["draw"]; ok {
+			// 동행복권 엑셀: "1등" 병합 헤더 아래 당첨자수, 당첨금액 순서
+			for j, cell := range row {
+				if strings.TrimSpace(cell) == "1등" {
+					found["winners"] = j
+					found["prize"] = j + 1
+				}
+			}
+			cols = found
+			start = i + 1
+			break
+		}
+	}
+	if len(cols) == 0 {
+		cols = map[string]int{"draw": 0, "date": 1}
+		for i := 1; i <= g.Main.Picks; i++ {
+			cols[fmt.Sprintf("n%d", i)] = 1 + i
+		}
+		if g.Extra > 0 {
+			cols["bonus"] = 2 + g.Main.Picks
+		}
+		for i := 1; i <= g.Bonus.Picks; i++ {
+			cols[fmt.Sprintf("b%d", i)] = 1 + g.Main.Picks + g.Extra + i
+		}
+	}
+	width := g.Main.Picks + g.Extra + g.Bonus.Picks
+	_, hasNumbers := cols["n1"]
+
+	draws := []*fetcher.DrawData{}
+	for i := start; i < len(rows); i++ {
+		row := rows[i]
+		drawNo, ok := cellInt(row, cols["draw"])
+		if !ok {
+			continue // 병합 헤더 두 번째 줄, 빈 줄 등
+		}
+
+		// nums: 본 번호, 보너스 번호 (있는 게임만), 보너스 풀 번호 순
+		var nums []int
+		if hasNumbers {
+			for k := 1; k <= g.Main.Picks; k++ {
+				n, _ := cellInt(row, cols[fmt.Sprintf("n%d", k)])
+				nums = append(nums, n)
+			}
+			if g.Extra > 0 {
+				bonus, _ := cellInt(row, cols["bonus"])
+				nums = append(nums, bonus)
+			}
+			for k := 1; k <= g.Bonus.Picks; k++ {
+				n, _ := cellInt(row, column(cols, fmt.Sprintf("b%d", k)))
+				nums = append(nums, n)
+			}
+		} else {
+			nums = lastNumbers(row, width)
+			if nums == nil {
+				return nil, fmt.Errorf("%d행: 당첨 번호 %d개를 찾을 수 없음", i+1, width)
+			}
+		}
+
+		date := ""
+		if j, ok := cols["date"]; ok && j < len(row) {
+			date = normalizeDate(row[j])
+		}
+
+		d := &fetcher.DrawData{
+			ReturnValue: "success",
+			DrwNo:       drawNo,
+			DrwNoDate:   date,
+		}
+		d.SetNumbers(nums[:g.Main.Picks])
+		if g.Extra > 0 {
+			d.BnusNo = nums[g.Main.Picks]
+		}
+		if g.Bonus.Picks > 0 {
+			d.BnusNos = nums[g.Main.Picks+g.Extra:]
+		}
+		// 판매/당첨금 컬럼은 선택 사항
+		if j, ok := cols["sales"]; ok {
+			d.TotSellamnt = cellInt64(row, j)
+		}
+		if j, ok := cols["winners"]; ok {
+			d.FirstPrzwnerCo = int(cellInt64(row, j))
+		}
+		if j, ok := cols["prize"]; ok {
+			d.FirstWinamnt = cellInt64(row, j)
+		}
+		if j, ok := cols["total"]; ok {
+			d.FirstAccumamnt = cellInt64(row, j)
+		} else if d.FirstPrzwnerCo > 0 {
+			d.FirstAccumamnt = d.FirstWinamnt * int64(d.FirstPrzwnerCo)
+		}
+		draws = append(draws, d)
+	}
+	return draws, nil
+}
+
+// column 컬럼 위치 (없으면 -1)
+func column(cols map[string]int, kind string) int {
+	if j, ok := cols[kind]; ok {
+		return j
+	}
+	return -1
+}
+
+func cellInt(row []string, idx int) (int, bool) {
+	if idx < 0 || idx >= len(row) {
+		return 0, false
+	}
+	s := strings.ReplaceAll(strings.TrimSpace(row[idx]), ",", "")
+	if s == "" {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f != float64(int(f)) {
+		return 0, false
+	}
+	return int(f), true
+}
+
+// cellInt64 금액 셀 ("2,000,000,000원" 형식 포함), 숫자가 아니면 0
+func cellInt64(row []string, idx int) int64 {
+	if idx < 0 || idx >= len(row) {
+		return 0
+	}
+	s := strings.NewReplacer(",", "", "원", "", " ", "").Replace(row[idx])
+	if v, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return v
+	}
+	// xlsx 숫자 셀은 "2.5E9" 같은 지수 표기로 저장되기도 한다
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return int64(f)
+}
+
+// lastNumbers 행 끝에서부터 숫자 셀 n개를 순서대로 반환 (빈 셀은 건너뜀)
+func lastNumbers(row []string, n int) []int {
+	res := make([]int, n)
+	k := n - 1
+	for j := len(row) - 1; j >= 0 && k >= 0; j-- {
+		if strings.TrimSpace(row[j]) == "" {
+			continue
+		}
+		v, ok := cellInt(row, j)
+		if !ok {
+			return nil
+		}
+		res[k] = v
+		k--
+	}
+	if k >= 0 {
+		return nil
+	}
+	return res
+}
+
+// normalizeDate "2024.05.04", "2024/05/04" → "2024-05-04"
+func normalizeDate(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.NewReplacer(".", "-", "/", "-").Replace(s)
+	return strings.TrimSuffix(s, "-")
+}
+
+// ValidationError 검증에 실패한 모든 행의 오류 목록
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	const maxShown = 10
+	shown := e.Problems
+	if len(shown) > maxShown {
+		shown = shown[:maxShown]
+	}
+	msg := fmt.Sprintf("검증 실패 %d건: %s", len(e.Problems), strings.Join(shown, "; "))
+	if len(e.Problems) > maxShown {
+		msg += fmt.Sprintf(" 외 %d건", len(e.Problems)-maxShown)
+	}
+	return msg
+}
+
+// Validate 회차별 번호 규칙과 회차 연속성을 검사하고 회차 순으로 정렬한다.
+//   - 당첨 번호는 g 게임 개수만큼 1~최대 번호 범위의 서로 다른 숫자 (로또 6/45는 1~45 중 6개)
+//   - 보너스 번호도 범위 안이고 당첨 번호와 겹치지 않음, 보너스 풀 번호도 개수와 범위를 확인
+//   - 회차 번호는 중복/누락 없이 연속이고, DB 최신 회차(latestInDB) 뒤에 빈 회차를 남기지 않음
+func Validate(g *game.Game, draws []*fetcher.DrawData, latestInDB int) error {
+	problems := []string{}
+	sort.SliceStable(draws, func(i, j int) bool { return draws[i].DrwNo < draws[j].DrwNo })
+
+	for i, d := range draws {
+		if err := g.CheckDraw(d.Numbers(), d.BnusNo, d.BnusNos); err != nil {
+			problems = append(problems, fmt.Sprintf("회차 %d: %v", d.DrwNo, err))
+		}
+
+		if d.DrwNo < 1 {
+			problems = append(problems, fmt.Sprintf("잘못된 회차 번호 %d", d.DrwNo))
+		}
+		if i == 0 {
+			continue
+		}
+		switch prev := draws[i-1].DrwNo; {
+		case d.DrwNo == prev:
+			problems = append(problems, fmt.Sprintf("회차 %d 중복", d.DrwNo))
+		case d.DrwNo != prev+1:
+			problems = append(problems, fmt.Sprintf("회차 %d ~ %d 누락", prev+1, d.DrwNo-1))
+		}
+	}
+
+	if len(draws) > 0 && draws[0].DrwNo > latestInDB+1 {
+		problems = append(problems, fmt.Sprintf("DB 최신 회차 %d 이후 회차 %d ~ %d 누락", latestInDB, latestInDB+1, draws[0].DrwNo-1))
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// Import 파일을 DB 게임 형식으로 읽고 검증한 뒤 모든 회차를 한 트랜잭션으로 upsert 하고 저장한 회차 수를 반환
+func Import(ctx context.Context, store *db.Store, path, format string) (int, error) {
+	if format == "" {
+		var err error
+		if format, err = DetectFormat(path); err != nil {
+			return 0, err
+		}
+	}
+	draws, err := ReadFile(path, format, store.Game())
+	if err != nil {
+		return 0, err
+	}
+	if len(draws) == 0 {
+		return 0, fmt.Errorf("%s: 회차 데이터 없음", path)
+	}
+	latest, err := store.LatestDrawNumber(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if err := Validate(store.Game(), draws, latest); err != nil {
+		return 0, err
+	}
+
+	rows := make([]db.Draw, 0, len(draws))
+	for _, d := range draws {
+		rows = append(rows, db.DrawFromData(d))
+	}
+	if err := store.SaveDraws(ctx, rows); err != nil {
+		return 0, err
+	}
+	return len(rows), nil
+}
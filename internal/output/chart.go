@@ -0,0 +1,122 @@
+// internal/output/chart.go
+package output
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"lottopredictor/internal/cooccur"
+)
+
+// probabilityChartSVG 번호별 등장 확률(1 ~ len(probs)번) 막대 차트와 평균선을 인라인 SVG로 만든다.
+// 외부 스크립트 없이 보이므로 네트워크가 없는 환경에서도 HTML 결과를 그대로 볼 수 있다.
+func probabilityChartSVG(probs map[int]float64) string {
+	const (
+		width, height = 900, 360
+		left, right   = 45, 20
+		top, bottom   = 20, 30
+	)
+	plotW := float64(width - left - right)
+	plotH := float64(height - top - bottom)
+
+	count := len(probs)
+	sum, maxProb := 0.0, 0.0
+	for n := 1; n <= count; n++ {
+		sum += probs[n]
+		maxProb = max(maxProb, probs[n])
+	}
+	avg := sum / float64(count)
+	maxValue := max(maxProb, avg) * 1.1
+	if maxValue == 0 {
+		maxValue = 1
+	}
+	y := func(v float64) float64 { return float64(top) + plotH - v/maxValue*plotH }
+	step := plotW / float64(count)
+
+	b := strings.Builder{}
+	b.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" width="%d" height="%d" font-size="11" font-family="sans-serif">`,
+		width, height, width, height))
+
+	// y축 눈금
+	for i := 0; i <= 4; i++ {
+		v := maxValue * float64(i) / 4
+		b.WriteString(fmt.Sprintf(`<line x1="%d" x2="%d" y1="%.1f" y2="%.1f" stroke="#eee"/>`, left, width-right, y(v), y(v)))
+		b.WriteString(fmt.Sprintf(`<text x="%d" y="%.1f" text-anchor="end" fill="#555">%.1f%%</text>`, left-6, y(v)+4, v))
+	}
+
+	for n := 1; n <= count; n++ {
+		x := float64(left) + float64(n-1)*step
+		v := probs[n]
+		b.WriteString(fmt.Sprintf(`<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="rgba(75,192,192,0.6)" stroke="rgb(75,192,192)"><title>%d번: %.3f%%</title></rect>`,
+			x+2, y(v), step-4, float64(top)+plotH-y(v), n, v))
+		b.WriteString(fmt.Sprintf(`<text x="%.1f" y="%d" text-anchor="middle" fill="#555">%d</text>`, x+step/2, height-bottom+14, n))
+	}
+
+	// 평균선
+	b.WriteString(fmt.Sprintf(`<line x1="%d" x2="%d" y1="%.1f" y2="%.1f" stroke="red" stroke-width="2" stroke-dasharray="6 4"/>`,
+		left, width-right, y(avg), y(avg)))
+	b.WriteString(fmt.Sprintf(`<text x="%d" y="%.1f" text-anchor="end" fill="red">평균선(%.2f%%)</text>`, width-right, y(avg)-5, avg))
+	b.WriteString("</svg>")
+	return b.String()
+}
+
+// pairHeatmapSVG count×count 번호 쌍 동시 출현 z값 히트맵. 기대보다 많으면 빨강, 적으면 파랑 (|z| 3에서 최대 색)
+func pairHeatmapSVG(c *cooccur.Analysis, count int) string {
+	const (
+		cell       = 14
+		left, top  = 30, 30
+		maxZ       = 3.0
+		legendGap  = 20
+		legendSize = 120
+	)
+	size := cell * count
+	width, height := left+size+legendGap+60, top+size+10
+
+	b := strings.Builder{}
+	b.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %d" width="%d" height="%d" font-size="9" font-family="sans-serif">`,
+		width, height, width, height))
+	for n := 1; n <= count; n++ {
+		if n == 1 || n%5 == 0 {
+			pos := float64((n-1)*cell) + cell/2.0
+			b.WriteString(fmt.Sprintf(`<text x="%.1f" y="%d" text-anchor="middle" fill="#555">%d</text>`, float64(left)+pos, top-6, n))
+			b.WriteString(fmt.Sprintf(`<text x="%d" y="%.1f" text-anchor="end" fill="#555">%d</text>`, left-4, float64(top)+pos+3, n))
+		}
+	}
+	for _, p := range c.Pairs {
+		color := heatColor(p.Z / maxZ)
+		title := fmt.Sprintf("%d, %d: %d회 (기대 %.1f), lift %.2f, z %+.2f", p.A, p.B, p.Count, p.Expected, p.Lift, p.Z)
+		for _, xy := range [][2]int{{p.A, p.B}, {p.B, p.A}} {
+			b.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" fill="%s"><title>%s</title></rect>`,
+				left+(xy[0]-1)*cell, top+(xy[1]-1)*cell, cell, cell, color, title))
+		}
+	}
+	for n := 1; n <= count; n++ {
+		b.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="%d" height="%d" fill="#ddd"/>`, left+(n-1)*cell, top+(n-1)*cell, cell, cell))
+	}
+
+	// 범례 (z = +3 위, -3 아래)
+	lx := left + size + legendGap
+	for i := 0; i < legendSize; i++ {
+		v := 1 - 2*float64(i)/float64(legendSize-1)
+		b.WriteString(fmt.Sprintf(`<rect x="%d" y="%d" width="12" height="1" fill="%s"/>`, lx, top+i, heatColor(v)))
+	}
+	for _, mark := range []struct {
+		y     int
+		label string
+	}{{top + 4, "+3"}, {top + legendSize/2 + 3, "0"}, {top + legendSize, "-3"}} {
+		b.WriteString(fmt.Sprintf(`<text x="%d" y="%d" fill="#555">z %s</text>`, lx+16, mark.y, mark.label))
+	}
+	b.WriteString("</svg>")
+	return b.String()
+}
+
+// heatColor -1 ~ 1 값을 파랑 - 흰색 - 빨강으로
+func heatColor(v float64) string {
+	v = max(-1, min(1, v))
+	fade := int(255 * (1 - math.Abs(v)))
+	if v >= 0 {
+		return fmt.Sprintf("rgb(255,%d,%d)", fade, fade)
+	}
+	return fmt.Sprintf("rgb(%d,%d,255)", fade, fade)
+}
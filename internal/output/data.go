@@ -0,0 +1,71 @@
+// internal/output/data.go
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// jsonRenderer Report 모델을 그대로 JSON으로 저장 (다른 프로그램에서 읽기용)
+type jsonRenderer struct{}
+
+func (jsonRenderer) Format() string    { return "json" }
+func (jsonRenderer) Extension() string { return "json" }
+
+func (jsonRenderer) Render(w io.Writer, r *Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// csvRenderer 추천 세트를 한 행씩 저장. 스프레드시트나 pandas에서 바로 읽을 수 있도록 열 이름은 영문
+// 본 번호는 n1~, 보너스 풀 번호는 b1~ 열 (보너스 풀이 있는 게임만)
+type csvRenderer struct{}
+
+func (csvRenderer) Format() string    { return "csv" }
+func (csvRenderer) Extension() string { return "csv" }
+
+func (csvRenderer) Render(w io.Writer, r *Report) error {
+	cw := csv.NewWriter(w)
+	header := []string{"draw_number", "strategy", "set_index"}
+	for i := 1; i <= r.game.Main.Picks; i++ {
+		header = append(header, fmt.Sprintf("n%d", i))
+	}
+	for i := 1; i <= r.game.Bonus.Picks; i++ {
+		header = append(header, fmt.Sprintf("b%d", i))
+	}
+	header = append(header, "percentage", "rank", "violations")
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, s := range r.Sets {
+		row := []string{strconv.Itoa(r.DrawNumber), r.Strategy, strconv.Itoa(s.Index)}
+		row = appendCells(row, s.Numbers, r.game.Main.Picks)
+		row = appendCells(row, s.Bonus, r.game.Bonus.Picks)
+		percent, rank := "", ""
+		if s.Rank != nil {
+			percent, rank = strconv.FormatFloat(*s.Percentage, 'f', 1, 64), strconv.Itoa(*s.Rank)
+		}
+		if err := cw.Write(append(row, percent, rank, strings.Join(s.Violations, "; "))); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// appendCells 번호를 n개 열로 (모자라면 빈 칸)
+func appendCells(row []string, nums []int, n int) []string {
+	for i := 0; i < n; i++ {
+		cell := ""
+		if i < len(nums) {
+			cell = strconv.Itoa(nums[i])
+		}
+		row = append(row, cell)
+	}
+	return row
+}
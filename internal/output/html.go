@@ -0,0 +1,53 @@
+// internal/output/html.go
+package output
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+)
+
+// htmlRenderer 외부 리소스 없이 열리는 단일 HTML 파일
+type htmlRenderer struct{}
+
+func (htmlRenderer) Format() string    { return "html" }
+func (htmlRenderer) Extension() string { return "html" }
+
+func (htmlRenderer) Render(w io.Writer, r *Report) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<!DOCTYPE html><html><head><meta charset="utf-8">
+<title>Lotto 분석 결과</title>
+</head><body><h1>회차 %d 분석</h1>
+`, r.DrawNumber)
+	for _, kv := range r.Summary()[1:] {
+		fmt.Fprintf(bw, "<p>%s: %s</p>\n", kv[0], html.EscapeString(kv[1]))
+	}
+
+	for _, t := range r.Tables() {
+		switch t.chart {
+		case chartProbability:
+			bw.WriteString("<h2>번호별 등장 확률</h2>\n")
+			bw.WriteString(probabilityChartSVG(r.probabilities()))
+		case chartPairHeatmap:
+			bw.WriteString("<h2>번호 쌍 동시 출현 히트맵 (z값)</h2>\n")
+			bw.WriteString(pairHeatmapSVG(r.Cooccurrence, len(r.Numbers)))
+		}
+		fmt.Fprintf(bw, "<h2>%s</h2>\n", html.EscapeString(t.Title))
+		bw.WriteString(`<table border="1" cellpadding="4" cellspacing="0"><tr>`)
+		for _, c := range t.Columns {
+			fmt.Fprintf(bw, "<th>%s</th>", html.EscapeString(c))
+		}
+		bw.WriteString("</tr>\n")
+		for _, row := range t.Rows {
+			bw.WriteString("<tr>")
+			for _, cell := range row {
+				fmt.Fprintf(bw, "<td>%s</td>", html.EscapeString(cell))
+			}
+			bw.WriteString("</tr>\n")
+		}
+		bw.WriteString("</table>\n")
+	}
+	bw.WriteString("<br></body></html>\n")
+	return bw.Flush()
+}
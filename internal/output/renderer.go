@@ -0,0 +1,129 @@
+// internal/output/renderer.go
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"lottopredictor/internal/analyzer"
+)
+
+// Renderer 하나의 출력 형식. 새 형식은 Renderer를 구현해 renderers에 추가한다.
+type Renderer interface {
+	Format() string    // 형식 이름 (-formats 값)
+	Extension() string // 파일 확장자 (점 제외)
+	Render(w io.Writer, r *Report) error
+}
+
+var renderers = map[string]Renderer{}
+
+func registerRenderer(r Renderer) {
+	renderers[r.Format()] = r
+}
+
+func init() {
+	for _, r := range []Renderer{txtRenderer{}, htmlRenderer{}, jsonRenderer{}, csvRenderer{}, markdownRenderer{}, xlsxRenderer{}} {
+		registerRenderer(r)
+	}
+}
+
+// DefaultFormats 설정/플래그로 지정하지 않았을 때의 출력 형식
+var DefaultFormats = []string{"html", "txt"}
+
+// DefaultFilename 기본 파일 이름 템플릿 (확장자 제외)
+const DefaultFilename = "lotto_analysis_{draw}"
+
+// NewRenderer 형식 이름으로 렌더러를 찾는다.
+func NewRenderer(format string) (Renderer, error) {
+	r, ok := renderers[strings.ToLower(strings.TrimSpace(format))]
+	if !ok {
+		return nil, fmt.Errorf("알 수 없는 출력 형식 %q (사용 가능: %s)", format, strings.Join(Formats(), ", "))
+	}
+	return r, nil
+}
+
+// Formats 등록된 출력 형식 이름 (정렬)
+func Formats() []string {
+	names := make([]string, 0, len(renderers))
+	for name := range renderers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Save 결과를 format 형식으로 path에 저장. 상위 디렉터리가 없으면 만든다.
+func Save(result *analyzer.PredictionResult, path, format string) error {
+	r, err := NewRenderer(format)
+	if err != nil {
+		return err
+	}
+	return save(r, NewReport(result), path)
+}
+
+func save(r Renderer, report *Report, path string) (err error) {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+	if err := r.Render(f, report); err != nil {
+		return fmt.Errorf("%s 저장 실패: %w", path, err)
+	}
+	return nil
+}
+
+// WriteAll dir 아래에 nameTemplate 이름으로 formats 형식 파일을 모두 저장하고 경로 목록을 반환.
+// 템플릿의 {draw}는 회차, {strategy}는 전략 이름으로 바뀐다.
+func WriteAll(result *analyzer.PredictionResult, dir, nameTemplate string, formats []string) ([]string, error) {
+	if len(formats) == 0 {
+		formats = DefaultFormats
+	}
+	if nameTemplate == "" {
+		nameTemplate = DefaultFilename
+	}
+	// 일부만 저장되지 않도록 형식부터 모두 확인
+	rs := make([]Renderer, 0, len(formats))
+	for _, format := range formats {
+		r, err := NewRenderer(format)
+		if err != nil {
+			return nil, err
+		}
+		rs = append(rs, r)
+	}
+
+	base := filepath.Join(dir, FileName(nameTemplate, result))
+	report := NewReport(result)
+	paths := []string{}
+	for _, r := range rs {
+		path := base + "." + r.Extension()
+		if err := save(r, report, path); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// FileName 파일 이름 템플릿의 자리표시자를 채운다.
+func FileName(nameTemplate string, result *analyzer.PredictionResult) string {
+	strategy := result.Strategy
+	if strategy == "" {
+		strategy = analyzer.DefaultStrategy
+	}
+	return strings.NewReplacer(
+		"{draw}", fmt.Sprint(result.DrawNumber),
+		"{strategy}", strategy,
+	).Replace(nameTemplate)
+}
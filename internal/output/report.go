@@ -0,0 +1,471 @@
+// internal/output/report.go
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"lottopredictor/internal/analyzer"
+	"lottopredictor/internal/audit"
+	"lottopredictor/internal/bayes"
+	"lottopredictor/internal/cooccur"
+	"lottopredictor/internal/game"
+	"lottopredictor/internal/portfolio"
+	"lottopredictor/internal/recency"
+)
+
+// Report 모든 렌더러가 공통으로 쓰는 결과 모델. analyzer.PredictionResult에서 한 번만 만든다.
+type Report struct {
+	Game          string                  `json:"game"`
+	DrawNumber    int                     `json:"draw_number"`
+	Strategy      string                  `json:"strategy,omitempty"`
+	Seed          int64                   `json:"seed,omitempty"`
+	Sets          []SetRow                `json:"sets"`
+	Numbers       []NumberStat            `json:"numbers"`      // 1 ~ 본 번호 풀 크기 번호 순
+	TopProbable   []int                   `json:"top_probable"` // 등장 확률 상위 10개
+	RecentMissing []int                   `json:"recent_missing"`
+	FreqInLast10  []int                   `json:"freq_in_last10"`
+	TopFrequent   []int                   `json:"top_frequent"`
+	LeastFrequent []int                   `json:"least_frequent"`
+	Jackpots      []analyzer.JackpotPoint `json:"jackpots,omitempty"`
+	ExpectedValue float64                 `json:"expected_value,omitempty"` // 세트 1개의 기대 당첨금 (통화 최소 단위)
+	TicketPrice   int64                   `json:"ticket_price"`             // 통화 최소 단위
+	Currency      string                  `json:"currency"`
+	Portfolio     *portfolio.Summary      `json:"portfolio,omitempty"`
+	Audit         *audit.Report           `json:"audit,omitempty"`
+	Cooccurrence  *cooccur.Analysis       `json:"cooccurrence,omitempty"`
+	Bayes         *BayesSummary           `json:"bayes,omitempty"`
+	Recency       *recency.Model          `json:"recency,omitempty"`
+
+	game *game.Game
+}
+
+// BayesSummary 베이즈 추정 설정과 상위 번호 신용구간 겹침
+type BayesSummary struct {
+	Prior    float64       `json:"prior"`
+	HalfLife float64       `json:"half_life"`
+	Uniform  float64       `json:"uniform"` // 균등 추첨일 때 번호별 출현 확률 (%)
+	Overlap  bayes.Overlap `json:"overlap"`
+}
+
+// SetRow 추천 번호 세트. 평가 전이면 Percentage, Rank가 nil
+type SetRow struct {
+	Index      int      `json:"index"`
+	Numbers    []int    `json:"numbers"`
+	Bonus      []int    `json:"bonus,omitempty"` // 보너스 풀 번호 (보너스 풀이 있는 게임만)
+	Percentage *float64 `json:"percentage,omitempty"`
+	Rank       *int     `json:"rank,omitempty"`
+	Violations []string `json:"violations,omitempty"` // 어긴 추천 조건
+}
+
+// NumberStat 번호별 등장 확률(%), 미출현 간격, 전략 점수
+type NumberStat struct {
+	Number      int     `json:"number"`
+	Probability float64 `json:"probability"`
+	Gap         int     `json:"gap"`
+	Score       float64 `json:"score,omitempty"`
+
+	Bayes *bayes.Estimate `json:"bayes,omitempty"` // 사후 평균, 95% 신용구간
+}
+
+// Table 제목과 열 이름이 있는 문자열 표. 표 형식 렌더러(TXT, Markdown, HTML, XLSX)가 같은 내용을 쓰도록 한다.
+type Table struct {
+	Title   string
+	Columns []string
+	Rows    [][]string
+
+	chart string // HTML에서 표 앞에 그리는 차트 (chartProbability, chartPairHeatmap)
+}
+
+// 표 앞에 그리는 차트 종류
+const (
+	chartProbability = "probability"
+	chartPairHeatmap = "pair_heatmap"
+)
+
+// topProbableSize 상위 확률 번호 표시 개수
+const topProbableSize = 10
+
+// NewReport 분석 결과를 렌더링용 모델로 변환
+func NewReport(result *analyzer.PredictionResult) *Report {
+	g, err := game.Get(result.Game)
+	if err != nil {
+		g = game.Default
+	}
+	r := &Report{
+		Game:          g.ID,
+		DrawNumber:    result.DrawNumber,
+		Strategy:      result.Strategy,
+		Seed:          result.Seed,
+		RecentMissing: orEmpty(result.RecentMissing),
+		FreqInLast10:  orEmpty(result.FreqInLast10),
+		TopFrequent:   orEmpty(result.TopFrequent),
+		LeastFrequent: orEmpty(result.LeastFrequent),
+		Jackpots:      result.Jackpots,
+		ExpectedValue: result.ExpectedValue,
+		TicketPrice:   g.TicketPrice,
+		Currency:      g.Currency.Code,
+		Portfolio:     result.Portfolio,
+		Audit:         result.Audit,
+		Cooccurrence:  result.Cooccurrence,
+		Recency:       result.Recency,
+		game:          g,
+	}
+	for i, set := range result.SuggestionSets {
+		row := SetRow{Index: i + 1, Numbers: set}
+		if i < len(result.BonusSets) {
+			row.Bonus = result.BonusSets[i]
+		}
+		// 평가 정보가 있는 세트만 채운다
+		if i < len(result.Percentage) && i < len(result.Ranks) {
+			row.Percentage = &result.Percentage[i]
+			row.Rank = &result.Ranks[i]
+		}
+		if i < len(result.Violations) {
+			for _, v := range result.Violations[i] {
+				row.Violations = append(row.Violations, v.String())
+			}
+		}
+		r.Sets = append(r.Sets, row)
+	}
+	for n := 1; n <= g.Main.Max; n++ {
+		stat := NumberStat{
+			Number:      n,
+			Probability: result.Probabilities[n],
+			Gap:         result.Gaps[n],
+			Score:       result.Scores[n],
+		}
+		if result.Bayes != nil {
+			stat.Bayes = &result.Bayes.Estimates[n-1]
+		}
+		r.Numbers = append(r.Numbers, stat)
+	}
+	r.TopProbable = analyzer.TopProbable(result.Probabilities, topProbableSize)
+	if b := result.Bayes; b != nil {
+		r.Bayes = &BayesSummary{
+			Prior:    b.Prior,
+			HalfLife: b.HalfLife,
+			Uniform:  b.Uniform(),
+			Overlap:  b.Compare(analyzer.TopProbable(result.Probabilities, g.Main.Max), topProbableSize),
+		}
+	}
+	return r
+}
+
+func orEmpty(nums []int) []int {
+	if nums == nil {
+		return []int{}
+	}
+	return nums
+}
+
+// Evaluated 평가 정보가 있는 세트가 하나라도 있는지
+func (r *Report) Evaluated() bool {
+	for _, s := range r.Sets {
+		if s.Rank != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// HasViolations 조건을 어긴 세트가 있는지
+func (r *Report) HasViolations() bool {
+	for _, s := range r.Sets {
+		if len(s.Violations) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Summary 표 앞에 붙이는 회차/전략 요약 (이름, 값)
+func (r *Report) Summary() [][2]string {
+	rows := [][2]string{{"회차", fmt.Sprint(r.DrawNumber)}}
+	if r.game != game.Default {
+		rows = append(rows, [2]string{"게임", r.game.Name})
+	}
+	if r.Strategy != "" {
+		rows = append(rows, [2]string{"전략", r.Strategy})
+	}
+	if r.Seed != 0 {
+		rows = append(rows, [2]string{"시드", fmt.Sprint(r.Seed)})
+	}
+	return rows
+}
+
+// Tables 표 형식 렌더러가 순서대로 출력하는 섹션
+func (r *Report) Tables() []Table {
+	stat := func(n int) NumberStat { return r.Numbers[n-1] }
+	probTable := func(title string, nums []int) Table {
+		t := Table{Title: title, Columns: []string{"번호", "확률 (%)"}}
+		for _, n := range nums {
+			t.Rows = append(t.Rows, []string{fmt.Sprint(n), fmt.Sprintf("%.3f", stat(n).Probability)})
+		}
+		return t
+	}
+
+	top := Table{Title: "상위 10 확률 번호", Columns: append([]string{"번호", "확률 (%)", "간격"}, bayesColumns(r.Bayes)...)}
+	for _, n := range r.TopProbable {
+		row := []string{fmt.Sprint(n), fmt.Sprintf("%.3f", stat(n).Probability), fmt.Sprint(stat(n).Gap)}
+		top.Rows = append(top.Rows, append(row, bayesCells(stat(n).Bayes)...))
+	}
+
+	sets := Table{Title: "추천 번호 세트", Columns: []string{"세트", "추천 번호"}}
+	if r.Evaluated() {
+		sets.Columns = append(sets.Columns, "일치율 (%)", "등수")
+	}
+	if r.HasViolations() {
+		sets.Columns = append(sets.Columns, "위반 조건")
+	}
+	for _, s := range r.Sets {
+		numbers := joinNumbers(s.Numbers)
+		if s.Bonus != nil {
+			numbers += " + " + joinNumbers(s.Bonus)
+		}
+		row := []string{fmt.Sprint(s.Index), numbers}
+		if r.Evaluated() {
+			percent, rank := "", ""
+			if s.Rank != nil {
+				percent, rank = fmt.Sprintf("%.1f", *s.Percentage), fmt.Sprint(*s.Rank)
+			}
+			row = append(row, percent, rank)
+		}
+		if r.HasViolations() {
+			row = append(row, strings.Join(s.Violations, "; "))
+		}
+		sets.Rows = append(sets.Rows, row)
+	}
+
+	var pf *Table
+	if p := r.Portfolio; p != nil {
+		pf = &Table{Title: "추천 세트 포트폴리오", Columns: []string{"항목", "값"}, Rows: [][]string{
+			{"최적화 목표", p.Objective},
+			{"후보 세트 수", fmt.Sprint(p.Candidates)},
+			{fmt.Sprintf("최저 등수(%d개 일치) 이상 1세트 이상 확률 (%%)", p.HitMatch), fmt.Sprintf("%.4f", p.HitProbability*100)},
+			{"세트가 겹치지 않을 때 상한 (%)", fmt.Sprintf("%.4f", p.IndependentBound*100)},
+		}}
+		if q, ok := p.MatchProbabilities[p.HitMatch+1]; ok {
+			pf.Rows = append(pf.Rows, []string{fmt.Sprintf("%d개 이상 일치 1세트 이상 확률 (%%)", p.HitMatch+1), fmt.Sprintf("%.5f", q*100)})
+		}
+		pf.Rows = append(pf.Rows,
+			[]string{"서로 다른 번호 수", fmt.Sprint(p.Numbers)},
+			[]string{"서로 다른 번호 쌍 수", fmt.Sprint(p.Pairs)},
+			[]string{"평균 번호 점수 (0~1)", fmt.Sprintf("%.3f", p.Score)},
+		)
+	}
+
+	missing := Table{Title: "최근 미등장 번호", Columns: []string{"번호", "간격"}}
+	for _, n := range r.RecentMissing {
+		missing.Rows = append(missing.Rows, []string{fmt.Sprint(n), fmt.Sprint(stat(n).Gap)})
+	}
+
+	tables := []Table{top}
+	if b := r.Bayes; b != nil {
+		halfLife := "없음"
+		if b.HalfLife > 0 {
+			halfLife = fmt.Sprintf("%g회", b.HalfLife)
+		}
+		o := b.Overlap
+		tables = append(tables, Table{Title: "베이즈 추정 (디리클레-다항)", Columns: []string{"항목", "값"}, Rows: [][]string{
+			{"사전 모수 (번호별)", fmt.Sprint(b.Prior)},
+			{"시간 감쇠 반감기", halfLife},
+			{"균등 확률 (%)", fmt.Sprintf("%.3f", b.Uniform)},
+			{fmt.Sprintf("상위 %d개 중 %d위와 95%% 구간이 겹치는 번호", o.Top, o.Top+1), fmt.Sprintf("%d개", o.OverlapNext)},
+			{fmt.Sprintf("상위 %d개 중 95%% 구간이 균등 확률을 포함하는 번호", o.Top), fmt.Sprintf("%d개", o.ContainUniform)},
+			{"95% 구간이 균등 확률을 벗어난 번호 (전체)", fmt.Sprintf("%d개", o.Distinct)},
+		}})
+	}
+	tables = append(tables, sets)
+	if pf != nil {
+		tables = append(tables, *pf)
+	}
+	tables = append(tables,
+		missing,
+		probTable("최근 10회 출현 빈도 높은 번호", r.FreqInLast10),
+	)
+	if r.Recency != nil {
+		tables = append(tables, recencyTable(r.Recency))
+	}
+	tables = append(tables,
+		probTable("가장 많이 등장한 번호 Top 10", r.TopFrequent),
+		probTable("가장 적게 등장한 번호 Top 10", r.LeastFrequent),
+	)
+
+	if len(r.Jackpots) > 0 {
+		unit := r.game.Currency.Unit
+		t := Table{Title: "1등 당첨금 추이", Columns: []string{"회차", "추첨일", "당첨자 수", fmt.Sprintf("1인당 당첨금 (%s)", unit), fmt.Sprintf("판매액 (%s)", unit)}}
+		for _, j := range r.Jackpots {
+			t.Rows = append(t.Rows, []string{fmt.Sprint(j.DrawNumber), j.Date, fmt.Sprint(j.Winners), r.amount(j.PrizePerWinner), r.amount(j.TotalSales)})
+		}
+		tables = append(tables, t)
+	}
+
+	if r.ExpectedValue > 0 {
+		tables = append(tables, Table{
+			Title:   "세트당 기대 당첨금",
+			Columns: []string{fmt.Sprintf("기대 당첨금 (%s)", r.game.Currency.Unit), fmt.Sprintf("게임당 가격 (%s)", r.game.Currency.Unit), fmt.Sprintf("기대 손실 (%s)", r.game.Currency.Unit)},
+			Rows: [][]string{{
+				r.expected(r.ExpectedValue),
+				r.amount(r.TicketPrice),
+				r.expected(float64(r.TicketPrice) - r.ExpectedValue),
+			}},
+		})
+	}
+
+	if r.Audit != nil {
+		tables = append(tables, auditTables(r.Audit)...)
+	}
+	if r.Cooccurrence != nil {
+		tables = append(tables, cooccurrenceTables(r.Cooccurrence)...)
+	}
+
+	all := Table{Title: "번호별 통계", Columns: append([]string{"번호", "확률 (%)", "간격", "점수"}, bayesColumns(r.Bayes)...), chart: chartProbability}
+	for _, s := range r.Numbers {
+		row := []string{fmt.Sprint(s.Number), fmt.Sprintf("%.3f", s.Probability), fmt.Sprint(s.Gap), fmt.Sprintf("%.4f", s.Score)}
+		all.Rows = append(all.Rows, append(row, bayesCells(s.Bayes)...))
+	}
+	return append(tables, all)
+}
+
+// recencyTable 시간 감쇠 확률 상위 10개 번호의 기간별 출현 확률
+func recencyTable(m *recency.Model) Table {
+	t := Table{
+		Title:   fmt.Sprintf("시간 감쇠 출현 확률 상위 10 (반감기 %g회, 유효 회차 %.1f)", m.HalfLife, m.EffectiveDraws),
+		Columns: []string{"번호", "감쇠 확률 (%)"},
+	}
+	for _, h := range m.Horizons {
+		if h.Rounds == 0 {
+			t.Columns = append(t.Columns, fmt.Sprintf("전체 %d회 (%%)", h.Draws))
+		} else {
+			t.Columns = append(t.Columns, fmt.Sprintf("최근 %d회 (%%)", h.Rounds))
+		}
+	}
+	for _, n := range m.Top(topProbableSize) {
+		row := []string{fmt.Sprint(n), fmt.Sprintf("%.3f", m.Weighted[n-1])}
+		for _, h := range m.Horizons {
+			row = append(row, fmt.Sprintf("%.3f", h.Probabilities[n-1]))
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return t
+}
+
+// bayesColumns 베이즈 추정이 있으면 번호 표에 붙이는 열
+func bayesColumns(b *BayesSummary) []string {
+	if b == nil {
+		return nil
+	}
+	return []string{"사후 평균 (%)", "95% 구간 (%)", "P(균등 초과)"}
+}
+
+func bayesCells(e *bayes.Estimate) []string {
+	if e == nil {
+		return nil
+	}
+	return []string{fmt.Sprintf("%.3f", e.Mean), fmt.Sprintf("%.2f ~ %.2f", e.Lower, e.Upper), fmt.Sprintf("%.3f", e.PAboveUniform)}
+}
+
+// auditTables 무작위성 검정 결과와 편차가 큰 번호
+func auditTables(a *audit.Report) []Table {
+	tests := Table{
+		Title:   fmt.Sprintf("무작위성 검정 (%d ~ %d회, %d회차, 유의수준 %.2f)", a.FromDraw, a.ToDraw, a.Draws, a.Alpha),
+		Columns: []string{"검정", "통계량", "자유도", "p값", "보정 p값 (Holm)", "판정"},
+	}
+	for _, t := range a.Tests {
+		df := ""
+		if t.DF > 0 {
+			df = fmt.Sprint(t.DF)
+		}
+		tests.Rows = append(tests.Rows, []string{t.Title, fmt.Sprintf("%.3f", t.Statistic), df, fmt.Sprintf("%.4f", t.PValue), fmt.Sprintf("%.4f", t.AdjustedP), t.Verdict})
+	}
+	tests.Rows = append(tests.Rows, []string{"종합", "", "", "", "", a.Verdict})
+
+	devs := Table{Title: "번호별 등장 횟수 편차 (|z| 상위 10)", Columns: []string{"번호", "등장 횟수", "기대 횟수", "z", "보정 p값 (Holm)", "유의"}}
+	for _, d := range a.Numbers[:min(10, len(a.Numbers))] {
+		significant := "아니오"
+		if d.Significant {
+			significant = "예"
+		}
+		devs.Rows = append(devs.Rows, []string{fmt.Sprint(d.Number), fmt.Sprint(d.Count), fmt.Sprintf("%.1f", d.Expected), fmt.Sprintf("%+.2f", d.Z), fmt.Sprintf("%.4f", d.AdjustedP), significant})
+	}
+	return []Table{tests, devs}
+}
+
+// cooccurrenceTables 독립 추첨 기대값보다 자주/드물게 함께 나온 번호 쌍과 세 번호 조합
+func cooccurrenceTables(c *cooccur.Analysis) []Table {
+	columns := []string{"구분", "번호", "횟수", "기대 횟수", "lift", "z"}
+	pairs := Table{Title: fmt.Sprintf("번호 쌍 동시 출현 (%d회차, z 상위/하위 %d)", c.Draws, len(c.TopPairs)), Columns: columns, chart: chartPairHeatmap}
+	for _, group := range []struct {
+		label string
+		pairs []cooccur.Pair
+	}{{"자주", c.TopPairs}, {"드물게", c.BottomPairs}} {
+		for _, p := range group.pairs {
+			pairs.Rows = append(pairs.Rows, []string{group.label, joinNumbers([]int{p.A, p.B}), fmt.Sprint(p.Count), fmt.Sprintf("%.2f", p.Expected), fmt.Sprintf("%.2f", p.Lift), fmt.Sprintf("%+.2f", p.Z)})
+		}
+	}
+	triples := Table{Title: fmt.Sprintf("세 번호 동시 출현 (z 상위/하위 %d)", len(c.TopTriples)), Columns: columns}
+	for _, group := range []struct {
+		label   string
+		triples []cooccur.Triple
+	}{{"자주", c.TopTriples}, {"드물게", c.BottomTriples}} {
+		for _, t := range group.triples {
+			triples.Rows = append(triples.Rows, []string{group.label, joinNumbers(t.Numbers[:]), fmt.Sprint(t.Count), fmt.Sprintf("%.2f", t.Expected), fmt.Sprintf("%.2f", t.Lift), fmt.Sprintf("%+.2f", t.Z)})
+		}
+	}
+	return []Table{pairs, triples}
+}
+
+// probabilities 차트용 번호 → 확률
+func (r *Report) probabilities() map[int]float64 {
+	probs := make(map[int]float64, len(r.Numbers))
+	for _, s := range r.Numbers {
+		probs[s.Number] = s.Probability
+	}
+	return probs
+}
+
+func joinNumbers(nums []int) string {
+	parts := make([]string, len(nums))
+	for i, n := range nums {
+		parts[i] = fmt.Sprint(n)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// amount 최소 단위 금액을 게임 통화 표시 단위 숫자로 (원 "1,234,567", 센트가 있는 통화 "12,345.67")
+func (r *Report) amount(v int64) string {
+	minor := int64(r.game.Currency.Minor)
+	if minor <= 1 {
+		return formatWon(v)
+	}
+	sign := ""
+	if v < 0 {
+		sign, v = "-", -v
+	}
+	return fmt.Sprintf("%s%s.%02d", sign, formatWon(v/minor), v%minor)
+}
+
+// expected 기대 금액(최소 단위 실수)을 게임 통화 표시 단위 숫자로
+func (r *Report) expected(v float64) string {
+	if r.game.Currency.Minor <= 1 {
+		return fmt.Sprintf("%.1f", v)
+	}
+	return fmt.Sprintf("%.3f", v/float64(r.game.Currency.Minor))
+}
+
+// formatWon 1234567 → "1,234,567"
+func formatWon(v int64) string {
+	s := fmt.Sprint(v)
+	if v < 0 {
+		return "-" + formatWon(-v)
+	}
+	var b strings.Builder
+	for i, ch := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(ch)
+	}
+	return b.String()
+}
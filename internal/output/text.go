@@ -0,0 +1,106 @@
+// internal/output/text.go
+package output
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// txtRenderer 터미널/메모장에서 보기 좋은 고정폭 텍스트
+type txtRenderer struct{}
+
+func (txtRenderer) Format() string    { return "txt" }
+func (txtRenderer) Extension() string { return "txt" }
+
+func (txtRenderer) Render(w io.Writer, r *Report) error {
+	bw := bufio.NewWriter(w)
+	for _, kv := range r.Summary() {
+		fmt.Fprintf(bw, "%s: %s\n", kv[0], kv[1])
+	}
+	for _, t := range r.Tables() {
+		fmt.Fprintf(bw, "\n[%s]\n", t.Title)
+		widths := make([]int, len(t.Columns))
+		for i, c := range t.Columns {
+			widths[i] = displayWidth(c)
+		}
+		for _, row := range t.Rows {
+			for i, cell := range row {
+				widths[i] = max(widths[i], displayWidth(cell))
+			}
+		}
+		writeTextRow(bw, t.Columns, widths)
+		for _, row := range t.Rows {
+			writeTextRow(bw, row, widths)
+		}
+	}
+	return bw.Flush()
+}
+
+func writeTextRow(w io.Writer, cells []string, widths []int) {
+	var b strings.Builder
+	for i, cell := range cells {
+		if i > 0 {
+			b.WriteString("  ")
+		}
+		b.WriteString(cell)
+		if i < len(cells)-1 {
+			b.WriteString(strings.Repeat(" ", widths[i]-displayWidth(cell)))
+		}
+	}
+	fmt.Fprintln(w, b.String())
+}
+
+// displayWidth 한글은 고정폭 글꼴에서 두 칸을 차지한다.
+func displayWidth(s string) int {
+	n := 0
+	for _, ch := range s {
+		if unicode.Is(unicode.Hangul, ch) {
+			n += 2
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+// markdownRenderer GitHub 형식 Markdown 표
+type markdownRenderer struct{}
+
+func (markdownRenderer) Format() string    { return "markdown" }
+func (markdownRenderer) Extension() string { return "md" }
+
+func (markdownRenderer) Render(w io.Writer, r *Report) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "# 회차 %d 분석\n\n", r.DrawNumber)
+	for _, kv := range r.Summary()[1:] {
+		fmt.Fprintf(bw, "- %s: %s\n", kv[0], markdownCell(kv[1]))
+	}
+	for _, t := range r.Tables() {
+		fmt.Fprintf(bw, "\n## %s\n\n", t.Title)
+		writeMarkdownRow(bw, t.Columns)
+		sep := make([]string, len(t.Columns))
+		for i := range sep {
+			sep[i] = "---"
+		}
+		writeMarkdownRow(bw, sep)
+		for _, row := range t.Rows {
+			writeMarkdownRow(bw, row)
+		}
+	}
+	return bw.Flush()
+}
+
+func writeMarkdownRow(w io.Writer, cells []string) {
+	escaped := make([]string, len(cells))
+	for i, c := range cells {
+		escaped[i] = markdownCell(c)
+	}
+	fmt.Fprintf(w, "| %s |\n", strings.Join(escaped, " | "))
+}
+
+func markdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
@@ -0,0 +1,26 @@
+// internal/output/xlsx.go
+package output
+
+import (
+	"io"
+
+	"lottopredictor/internal/xlsx"
+)
+
+// xlsxRenderer 요약 시트와 표마다 시트 하나씩 만든 엑셀 통합 문서
+type xlsxRenderer struct{}
+
+func (xlsxRenderer) Format() string    { return "xlsx" }
+func (xlsxRenderer) Extension() string { return "xlsx" }
+
+func (xlsxRenderer) Render(w io.Writer, r *Report) error {
+	summary := xlsx.Sheet{Name: "요약"}
+	for _, kv := range r.Summary() {
+		summary.Rows = append(summary.Rows, []string{kv[0], kv[1]})
+	}
+	sheets := []xlsx.Sheet{summary}
+	for _, t := range r.Tables() {
+		sheets = append(sheets, xlsx.Sheet{Name: t.Title, Rows: append([][]string{t.Columns}, t.Rows...)})
+	}
+	return xlsx.Write(w, sheets)
+}
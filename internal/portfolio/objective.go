@@ -0,0 +1,86 @@
+// internal/portfolio/objective.go
+package portfolio
+
+import (
+	"math/bits"
+
+	"lottopredictor/internal/game"
+)
+
+// evaluator 고른 후보 인덱스 묶음의 목표 값 (0~1 근처로 정규화)
+type evaluator interface {
+	value(chosen []int) float64
+}
+
+// hitEval 균등 추첨 표본 중 한 세트 이상 최저 등수 일치 개수 이상 일치한 표본 비율을, 세트가 겹치지 않을 때의 기대값으로 나눈 값
+type hitEval struct {
+	hits  [][]uint64 // 후보별 적중 표본 비트셋
+	words int
+	bound float64
+}
+
+func newHitEval(g *game.Game, cands [][]int, size, samples int, random func() float64) *hitEval {
+	e := &hitEval{words: (samples + 63) / 64}
+	hit := hitMatch(g)
+	draws := make([]uint64, samples)
+	nums := make([]int, g.Main.Max)
+	for i := range draws {
+		for j := range nums {
+			nums[j] = j + 1
+		}
+		// 앞 본 번호 수 개만 섞는 부분 Fisher-Yates
+		for j := 0; j < g.Main.Picks; j++ {
+			k := j + min(int(random()*float64(len(nums)-j)), len(nums)-j-1)
+			nums[j], nums[k] = nums[k], nums[j]
+		}
+		draws[i] = mask(nums[:g.Main.Picks])
+	}
+	for _, c := range cands {
+		m := mask(c)
+		set := make([]uint64, e.words)
+		for i, d := range draws {
+			if bits.OnesCount64(m&d) >= hit {
+				set[i/64] |= 1 << (i % 64)
+			}
+		}
+		e.hits = append(e.hits, set)
+	}
+	e.bound = singleHit(g, hit) * float64(size) * float64(samples)
+	return e
+}
+
+func (e *hitEval) value(chosen []int) float64 {
+	covered := 0
+	for w := 0; w < e.words; w++ {
+		var u uint64
+		for _, c := range chosen {
+			u |= e.hits[c][w]
+		}
+		covered += bits.OnesCount64(u)
+	}
+	return float64(covered) / e.bound
+}
+
+// coverageEval 서로 다른 번호 비율과 번호 쌍 비율의 평균 (세트 수로 가능한 최대 대비)
+type coverageEval struct {
+	cands             [][]int
+	maxNums, maxPairs float64
+}
+
+func newCoverageEval(g *game.Game, cands [][]int, size int) *coverageEval {
+	k, n := g.Main.Picks, g.Main.Max
+	return &coverageEval{
+		cands:    cands,
+		maxNums:  float64(min(k*size, n)),
+		maxPairs: float64(min(k*(k-1)/2*size, n*(n-1)/2)),
+	}
+}
+
+func (e *coverageEval) value(chosen []int) float64 {
+	sets := make([][]int, len(chosen))
+	for i, c := range chosen {
+		sets[i] = e.cands[c]
+	}
+	numbers, pairs := coverage(sets)
+	return (float64(numbers)/e.maxNums + float64(pairs)/e.maxPairs) / 2
+}
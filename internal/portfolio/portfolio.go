@@ -0,0 +1,317 @@
+// internal/portfolio/portfolio.go
+package portfolio
+
+import (
+	"fmt"
+	"math/bits"
+	"slices"
+
+	"lottopredictor/internal/common"
+	"lottopredictor/internal/constraint"
+	"lottopredictor/internal/game"
+)
+
+// 최적화 목표
+const (
+	ObjectiveHit      = "hit"      // 한 세트 이상 최저 등수(로또 6/45는 5등, 3개 일치) 이상일 확률 최대화
+	ObjectiveCoverage = "coverage" // 서로 다른 번호/번호 쌍 최대화
+)
+
+// 기본값
+const (
+	DefaultCandidates  = 10    // 추천 세트 수 × 10개 후보에서 고른다
+	DefaultSamples     = 20000 // hit 목표 몬테카를로 추첨 표본 수
+	DefaultScoreWeight = 0.2
+)
+
+// maxPool 추첨 결과를 uint64 비트마스크로 다루므로 본 번호 풀은 64개까지
+const maxPool = 64
+
+// Supports g 게임으로 포트폴리오를 최적화할 수 있는지. 적중 확률은 본 번호만 계산하므로 구매자가 고르는 보너스 풀이 있는 게임은 지원하지 않는다.
+func Supports(g *game.Game) error {
+	if g.Bonus.Picks > 0 {
+		return fmt.Errorf("포트폴리오 최적화는 보너스 풀이 없는 게임만 지원 (현재 %s): 적중 확률은 본 번호만 계산함", g.Name)
+	}
+	if g.Main.Max > maxPool {
+		return fmt.Errorf("포트폴리오 최적화는 본 번호 풀 %d개까지 지원 (현재 %s, %d개)", maxPool, g.Name, g.Main.Max)
+	}
+	return nil
+}
+
+// Options 설정 파일 portfolio 항목. Objective가 비어 있으면 최적화하지 않는다.
+type Options struct {
+	Objective   string   `json:"objective"`
+	Candidates  int      `json:"candidates,omitempty"`   // 후보 세트 배수 (0이면 10)
+	Samples     int      `json:"samples,omitempty"`      // hit 목표 표본 수 (0이면 20000)
+	ScoreWeight *float64 `json:"score_weight,omitempty"` // 번호 점수 반영 비중 (없으면 0.2, 0이면 점수 무시)
+}
+
+// Validate 목표 이름과 값 범위 확인
+func (o *Options) Validate() error {
+	switch o.Objective {
+	case "", ObjectiveHit, ObjectiveCoverage:
+	default:
+		return fmt.Errorf("알 수 없는 포트폴리오 목표 %q (사용 가능: %s, %s)", o.Objective, ObjectiveHit, ObjectiveCoverage)
+	}
+	if o.Candidates < 0 || o.Samples < 0 || (o.ScoreWeight != nil && *o.ScoreWeight < 0) {
+		return fmt.Errorf("포트폴리오 설정 값은 0 이상이어야 함")
+	}
+	return nil
+}
+
+// Enabled 최적화를 수행하는지
+func (o *Options) Enabled() bool { return o != nil && o.Objective != "" }
+
+// CandidateCount size개를 고를 때 만들 후보 세트 수
+func (o *Options) CandidateCount(size int) int {
+	if o.Candidates > 0 {
+		return size * o.Candidates
+	}
+	return size * DefaultCandidates
+}
+
+func (o *Options) samples() int {
+	if o.Samples > 0 {
+		return o.Samples
+	}
+	return DefaultSamples
+}
+
+func (o *Options) scoreWeight() float64 {
+	if o.ScoreWeight != nil {
+		return *o.ScoreWeight
+	}
+	return DefaultScoreWeight
+}
+
+// Summary 고른 포트폴리오의 정보. 확률은 모든 추첨 조합을 계산한 정확한 값 (균등 추첨 가정)
+type Summary struct {
+	Objective      string  `json:"objective"`
+	Candidates     int     `json:"candidates"`
+	HitMatch       int     `json:"hit_match"`       // 최저 등수의 본 번호 일치 개수 (로또 6/45는 3개, 5등)
+	HitProbability float64 `json:"hit_probability"` // 한 세트 이상 HitMatch개 이상 일치 (최저 등수 이상)
+	// MatchProbabilities 일치 개수 m(HitMatch ~ 본 번호 수)별로 한 세트 이상 m개 이상 일치할 확률
+	MatchProbabilities map[int]float64 `json:"match_probabilities"`
+	// IndependentBound 세트가 전혀 겹치지 않는다고 볼 때의 상한 (세트 수 × 세트 1개 확률)
+	IndependentBound float64 `json:"independent_bound"`
+	Numbers          int     `json:"numbers"` // 서로 다른 번호 수
+	Pairs            int     `json:"pairs"`   // 서로 다른 번호 쌍 수
+	Score            float64 `json:"score"`   // 세트 평균 점수 (0~1, 점수 상위 본 번호 수 개 합 대비)
+}
+
+// Optimize g 게임 후보 세트 중 size개를 함께 골라 목표를 최대화한다.
+// 탐욕법으로 하나씩 고른 뒤, 고른 세트를 고르지 않은 후보로 바꿔 목표가 나아지면 교체하는 지역 탐색을 반복한다.
+// random은 hit 목표의 표본 추첨에 쓴다. 서로 다른 후보가 size개보다 적으면 오류
+func Optimize(g *game.Game, cands [][]int, size int, scores map[int]float64, opts *Options, random func() float64) ([][]int, *Summary, error) {
+	if err := Supports(g); err != nil {
+		return nil, nil, err
+	}
+	cands = dedupe(cands)
+	if len(cands) < size {
+		return nil, nil, fmt.Errorf("서로 다른 후보 세트가 %d개뿐이라 %d세트를 고를 수 없음 (추천 조건이 너무 좁음)", len(cands), size)
+	}
+
+	setScores := normalizedScores(g.Main.Picks, cands, scores)
+	w := opts.scoreWeight()
+	var eval evaluator
+	if opts.Objective == ObjectiveCoverage {
+		eval = newCoverageEval(g, cands, size)
+	} else {
+		eval = newHitEval(g, cands, size, opts.samples(), random)
+	}
+	objective := func(chosen []int) float64 {
+		s := 0.0
+		for _, c := range chosen {
+			s += setScores[c]
+		}
+		return eval.value(chosen) + w*s/float64(size)
+	}
+
+	chosen := []int{}
+	used := make([]bool, len(cands))
+	for len(chosen) < size {
+		best, bestValue := -1, 0.0
+		for c := range cands {
+			if used[c] {
+				continue
+			}
+			if v := objective(append(chosen, c)); best < 0 || v > bestValue {
+				best, bestValue = c, v
+			}
+		}
+		chosen = append(chosen, best)
+		used[best] = true
+	}
+
+	// 교체 지역 탐색 (한 바퀴 돌 때 개선이 없으면 종료)
+	current := objective(chosen)
+	for pass := 0; pass < 5; pass++ {
+		improved := false
+		for i := range chosen {
+			for c := range cands {
+				if used[c] {
+					continue
+				}
+				old := chosen[i]
+				chosen[i] = c
+				if v := objective(chosen); v > current+1e-12 {
+					used[old], used[c] = false, true
+					current = v
+					improved = true
+				} else {
+					chosen[i] = old
+				}
+			}
+		}
+		if !improved {
+			break
+		}
+	}
+
+	sets := make([][]int, len(chosen))
+	score := 0.0
+	for i, c := range chosen {
+		sets[i] = cands[c]
+		score += setScores[c]
+	}
+	summary := Evaluate(g, sets)
+	summary.Objective = opts.Objective
+	summary.Candidates = len(cands)
+	summary.Score = score / float64(max(1, len(chosen)))
+	return sets, summary, nil
+}
+
+// Evaluate g 게임 세트 묶음의 정확한 일치 확률과 번호/번호 쌍 수를 계산한다.
+// 본 번호 추첨 결과를 모두 확인한다 (로또 6/45는 C(45,6) = 8,145,060가지). g는 Supports를 통과해야 한다.
+func Evaluate(g *game.Game, sets [][]int) *Summary {
+	masks := make([]uint64, len(sets))
+	for i, s := range sets {
+		masks[i] = mask(s)
+	}
+	k := g.Main.Picks
+	atLeast := make([]int64, k+1)
+	eachDraw(g.Main.Max, k, func(draw uint64) {
+		best := 0
+		for _, m := range masks {
+			best = max(best, bits.OnesCount64(m&draw))
+		}
+		atLeast[best]++
+	})
+	for m := k - 1; m >= 0; m-- {
+		atLeast[m] += atLeast[m+1]
+	}
+
+	total := float64(common.Binomial(g.Main.Max, k))
+	s := &Summary{HitMatch: hitMatch(g), MatchProbabilities: map[int]float64{}}
+	for m := s.HitMatch; m <= k; m++ {
+		s.MatchProbabilities[m] = float64(atLeast[m]) / total
+	}
+	s.HitProbability = s.MatchProbabilities[s.HitMatch]
+	s.IndependentBound = min(1, singleHit(g, s.HitMatch)*float64(len(sets)))
+	s.Numbers, s.Pairs = coverage(sets)
+	return s
+}
+
+// hitMatch 보너스 풀·보너스 번호 없이 당첨되는 가장 낮은 등수의 본 번호 일치 개수
+func hitMatch(g *game.Game) int {
+	hit := g.Main.Picks
+	for _, t := range g.Tiers {
+		if t.Bonus == 0 && !t.Extra {
+			hit = min(hit, t.Main)
+		}
+	}
+	return hit
+}
+
+// singleHit 세트 하나가 본 번호 hit개 이상 일치할 확률 (해당 등수 확률의 합)
+func singleHit(g *game.Game, hit int) float64 {
+	p := 0.0
+	for _, t := range g.Tiers {
+		if t.Bonus == 0 && t.Main >= hit {
+			p += g.Odds(t.Rank)
+		}
+	}
+	return p
+}
+
+// eachDraw n개 중 k개인 모든 비트마스크 (Gosper's hack). n은 64 이하
+func eachDraw(n, k int, fn func(uint64)) {
+	m := uint64(1)<<k - 1
+	// n이 64면 1<<64가 0이 되므로 마지막 조합(상위 k비트)까지 돌고 멈춘다
+	last := m << (n - k)
+	for {
+		fn(m)
+		if m == last {
+			return
+		}
+		c := m & -m
+		r := m + c
+		m = (((r ^ m) >> 2) / c) | r
+	}
+}
+
+// mask 번호 n → 비트 n-1
+func mask(nums []int) uint64 {
+	var m uint64
+	for _, n := range nums {
+		m |= 1 << (n - 1)
+	}
+	return m
+}
+
+func coverage(sets [][]int) (numbers, pairs int) {
+	seenNum := map[int]bool{}
+	seenPair := map[[2]int]bool{}
+	for _, s := range sets {
+		for i, a := range s {
+			seenNum[a] = true
+			for _, b := range s[i+1:] {
+				seenPair[[2]int{min(a, b), max(a, b)}] = true
+			}
+		}
+	}
+	return len(seenNum), len(seenPair)
+}
+
+// Distinct 번호 순서를 무시한 서로 다른 세트 수
+func Distinct(cands [][]int) int {
+	return len(dedupe(cands))
+}
+
+func dedupe(cands [][]int) [][]int {
+	seen := map[string]bool{}
+	out := [][]int{}
+	for _, c := range cands {
+		c = slices.Sorted(slices.Values(c))
+		if k := constraint.Key(c); !seen[k] {
+			seen[k] = true
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// normalizedScores 세트별 번호 점수 합 / 점수 상위 k개 합 (0~1)
+func normalizedScores(k int, cands [][]int, scores map[int]float64) []float64 {
+	vals := []float64{}
+	for _, v := range scores {
+		vals = append(vals, v)
+	}
+	slices.Sort(vals)
+	slices.Reverse(vals)
+	top := 0.0
+	for _, v := range vals[:min(k, len(vals))] {
+		top += v
+	}
+	out := make([]float64, len(cands))
+	if top <= 0 {
+		return out
+	}
+	for i, c := range cands {
+		for _, n := range c {
+			out[i] += scores[n]
+		}
+		out[i] /= top
+	}
+	return out
+}
@@ -0,0 +1,112 @@
+// internal/recency/recency.go
+package recency
+
+import (
+	"fmt"
+	"sort"
+
+	"lottopredictor/internal/common"
+	"lottopredictor/internal/game"
+)
+
+// 기본값
+const (
+	DefaultHalfLife = 50 // 결과 파일의 시간 감쇠 확률 반감기 (회차 수)
+)
+
+// DefaultHorizons 기간별 출현 확률을 보는 최근 회차 수 (0은 전체)
+var DefaultHorizons = []int{10, 50, 100, 0}
+
+// Options 설정 파일 recency 항목
+type Options struct {
+	HalfLife float64 `json:"half_life,omitempty"` // 시간 감쇠 반감기 (0이면 50)
+	Horizons []int   `json:"horizons,omitempty"`  // 최근 회차 수 목록 (0은 전체, 비어 있으면 10, 50, 100, 전체)
+}
+
+// Validate 값 범위 확인
+func (o *Options) Validate() error {
+	if o.HalfLife < 0 {
+		return fmt.Errorf("recency.half_life는 0 이상이어야 함: %v", o.HalfLife)
+	}
+	for _, h := range o.Horizons {
+		if h < 0 {
+			return fmt.Errorf("recency.horizons 값은 0 이상이어야 함: %d", h)
+		}
+	}
+	return nil
+}
+
+func (o *Options) halfLife() float64 {
+	if o.HalfLife > 0 {
+		return o.HalfLife
+	}
+	return DefaultHalfLife
+}
+
+func (o *Options) horizons() []int {
+	if len(o.Horizons) > 0 {
+		return o.Horizons
+	}
+	return DefaultHorizons
+}
+
+// Horizon 최근 Rounds개 회차(0이면 전체)의 번호별 출현 확률 (%)
+type Horizon struct {
+	Rounds        int       `json:"rounds"`
+	Draws         int       `json:"draws"` // 실제로 센 회차 수
+	Probabilities []float64 `json:"probabilities"`
+}
+
+// Model 시간 감쇠 출현 확률과 기간별 출현 확률
+type Model struct {
+	HalfLife float64   `json:"half_life"`
+	Weighted []float64 `json:"weighted"` // index = 번호-1, 회차당 출현 확률 (%)
+	// EffectiveDraws 가중치 합. 감쇠 확률이 몇 회차 분량의 정보인지
+	EffectiveDraws float64   `json:"effective_draws"`
+	Horizons       []Horizon `json:"horizons"`
+}
+
+// Compute p 번호 풀의 회차 순 당첨 번호(draws)로 모델을 만든다.
+func Compute(draws [][]int, p game.Pool, opts Options) *Model {
+	m := &Model{HalfLife: opts.halfLife()}
+	m.Weighted, m.EffectiveDraws = Weighted(draws, p, m.HalfLife)
+	for _, rounds := range opts.horizons() {
+		recent := draws
+		if rounds > 0 && rounds < len(draws) {
+			recent = draws[len(draws)-rounds:]
+		}
+		probs, _ := Weighted(recent, p, 0)
+		m.Horizons = append(m.Horizons, Horizon{Rounds: rounds, Draws: len(recent), Probabilities: probs})
+	}
+	return m
+}
+
+// Weighted 최근 회차일수록 0.5^(경과 회차/halfLife) 가중치를 준 번호별 회차당 출현 확률 (%)과 가중치 합.
+// halfLife가 0이면 모든 회차를 같은 가중치로 센다 (기존 등장 확률과 같음).
+func Weighted(draws [][]int, p game.Pool, halfLife float64) ([]float64, float64) {
+	probs := make([]float64, p.Max)
+	total := 0.0
+	for t, d := range draws {
+		w := common.DecayWeight(len(draws)-1-t, halfLife)
+		total += w
+		for _, n := range d {
+			probs[n-1] += w
+		}
+	}
+	if total > 0 {
+		for i := range probs {
+			probs[i] = probs[i] / total * 100
+		}
+	}
+	return probs, total
+}
+
+// Top 감쇠 확률 상위 n개 번호 (같으면 작은 번호 먼저)
+func (m *Model) Top(n int) []int {
+	nums := make([]int, len(m.Weighted))
+	for i := range nums {
+		nums[i] = i + 1
+	}
+	sort.SliceStable(nums, func(a, b int) bool { return m.Weighted[nums[a]-1] > m.Weighted[nums[b]-1] })
+	return nums[:min(n, len(nums))]
+}
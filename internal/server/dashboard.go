@@ -0,0 +1,21 @@
+// internal/server/dashboard.go
+package server
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+// web 대시보드 정적 파일. 외부 CDN 없이 바이너리에 포함되어 오프라인에서도 동작한다.
+//
+//go:embed web
+var webFiles embed.FS
+
+func dashboardHandler() http.Handler {
+	sub, err := fs.Sub(webFiles, "web")
+	if err != nil {
+		panic(err) // embed 경로가 잘못된 경우뿐
+	}
+	return http.FileServerFS(sub)
+}
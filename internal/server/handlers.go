@@ -0,0 +1,326 @@
+// internal/server/handlers.go
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"lottopredictor/internal/analyzer"
+	"lottopredictor/internal/config"
+	"lottopredictor/internal/db"
+	"lottopredictor/internal/evaluator"
+)
+
+const (
+	defaultDrawLimit       = 50
+	maxDrawLimit           = 5000
+	defaultEvaluationLimit = 20
+)
+
+// intQuery 쿼리 파라미터 정수값 (없으면 def)
+func intQuery(r *http.Request, name string, def int) (int, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, errors.New(name + " 값이 잘못됨: " + v)
+	}
+	return n, nil
+}
+
+func (s *Server) latestDraw(w http.ResponseWriter, r *http.Request) (int, bool) {
+	latest, err := s.store.LatestDrawNumber(r.Context())
+	if err != nil {
+		writeInternal(w, r, err)
+		return 0, false
+	}
+	if latest == 0 {
+		writeError(w, http.StatusNotFound, "lotto_results가 비어 있음: sync 먼저 실행")
+		return 0, false
+	}
+	return latest, true
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	latest, err := s.store.LatestDrawNumber(ctx)
+	if err != nil {
+		writeInternal(w, r, err)
+		return
+	}
+	prediction, err := s.store.LatestPredictionDraw(ctx)
+	if err != nil {
+		writeInternal(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"status":            "ok",
+		"latest_draw":       latest,
+		"latest_prediction": prediction,
+	})
+}
+
+// GET /api/draws?from=&to=&limit= (from이 없으면 to까지 최근 limit개)
+func (s *Server) handleDraws(w http.ResponseWriter, r *http.Request) {
+	latest, err := s.store.LatestDrawNumber(r.Context())
+	if err != nil {
+		writeInternal(w, r, err)
+		return
+	}
+	from, err := intQuery(r, "from", 0)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+	to, err := intQuery(r, "to", latest)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+	limit, err := intQuery(r, "limit", defaultDrawLimit)
+	if err != nil || limit == 0 || limit > maxDrawLimit {
+		writeError(w, http.StatusBadRequest, "limit은 1 ~ %d", maxDrawLimit)
+		return
+	}
+	if from == 0 {
+		from = max(to-limit+1, 1)
+	}
+	if from > to {
+		writeError(w, http.StatusBadRequest, "구간이 잘못됨: %d ~ %d", from, to)
+		return
+	}
+
+	draws, err := s.store.ListDraws(r.Context(), from, to)
+	if err != nil {
+		writeInternal(w, r, err)
+		return
+	}
+	if len(draws) > limit {
+		draws = draws[:limit]
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"latest_draw": latest, "draws": draws})
+}
+
+// GET /api/draws/{draw} (회차 번호 또는 latest)
+func (s *Server) handleDraw(w http.ResponseWriter, r *http.Request) {
+	drawNo, ok := s.drawPath(w, r)
+	if !ok {
+		return
+	}
+	draw, err := s.store.GetDraw(r.Context(), drawNo)
+	if errors.Is(err, db.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "회차 %d 당첨 번호 없음", drawNo)
+		return
+	}
+	if err != nil {
+		writeInternal(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, draw)
+}
+
+// drawPath {draw} 경로 값. latest는 DB 최신 회차
+func (s *Server) drawPath(w http.ResponseWriter, r *http.Request) (int, bool) {
+	v := r.PathValue("draw")
+	if v == "latest" {
+		return s.latestDraw(w, r)
+	}
+	drawNo, err := strconv.Atoi(v)
+	if err != nil || drawNo < 1 {
+		writeError(w, http.StatusBadRequest, "회차 번호가 잘못됨: %s", v)
+		return 0, false
+	}
+	return drawNo, true
+}
+
+// numberStat 번호별 통계
+type numberStat struct {
+	Number      int     `json:"number"`
+	Probability float64 `json:"probability"` // 전체 회차 대비 등장 비율(%)
+	Gap         int     `json:"gap"`         // 대상 회차 기준 마지막 등장 이후 회차 수
+}
+
+// GET /api/numbers?draw= (draw 회차 예측 기준, 기본은 DB 최신 회차 + 1)
+func (s *Server) handleNumbers(w http.ResponseWriter, r *http.Request) {
+	latest, ok := s.latestDraw(w, r)
+	if !ok {
+		return
+	}
+	target, err := intQuery(r, "draw", latest+1)
+	if err != nil || target < 2 || target > latest+1 {
+		writeError(w, http.StatusBadRequest, "draw는 2 ~ %d", latest+1)
+		return
+	}
+
+	stats, err := analyzer.ComputeStats(r.Context(), s.store, target-1)
+	if err != nil {
+		writeInternal(w, r, err)
+		return
+	}
+	numbers := []numberStat{}
+	for n := 1; n <= s.store.Game().Main.Max; n++ {
+		numbers = append(numbers, numberStat{Number: n, Probability: stats.Probabilities[n], Gap: stats.Gaps[n]})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"game":           s.store.Game().ID,
+		"draw_number":    target,
+		"numbers":        numbers,
+		"top_frequent":   stats.TopFrequent,
+		"least_frequent": stats.LeastFrequent,
+		"recent_missing": stats.RecentMissing,
+		"freq_in_last10": stats.FreqInLast10,
+	})
+}
+
+// GET /api/strategies 사용 가능한 전략 이름과 설정의 기본 전략
+func (s *Server) handleStrategies(w http.ResponseWriter, r *http.Request) {
+	def := config.AppConfig.Strategy
+	if def == "" {
+		def = analyzer.DefaultStrategy
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"strategies": analyzer.StrategyNames(), "default": def})
+}
+
+// GET /api/predictions/latest 가장 최근 예측 대상 회차의 마지막 예측
+func (s *Server) handleLatestPrediction(w http.ResponseWriter, r *http.Request) {
+	drawNo, err := s.store.LatestPredictionDraw(r.Context())
+	if err != nil {
+		writeInternal(w, r, err)
+		return
+	}
+	if drawNo == 0 {
+		writeError(w, http.StatusNotFound, "저장된 예측 없음")
+		return
+	}
+	s.writePrediction(w, r, drawNo)
+}
+
+// GET /api/predictions/{draw}
+func (s *Server) handlePrediction(w http.ResponseWriter, r *http.Request) {
+	drawNo, err := strconv.Atoi(r.PathValue("draw"))
+	if err != nil || drawNo < 2 {
+		writeError(w, http.StatusBadRequest, "회차 번호가 잘못됨: %s", r.PathValue("draw"))
+		return
+	}
+	s.writePrediction(w, r, drawNo)
+}
+
+func (s *Server) writePrediction(w http.ResponseWriter, r *http.Request, drawNo int) {
+	result, err := analyzer.LoadPrediction(r.Context(), s.store, drawNo)
+	if err != nil {
+		writeInternal(w, r, err)
+		return
+	}
+	if len(result.SuggestionSets) == 0 {
+		writeError(w, http.StatusNotFound, "회차 %d 저장된 예측 없음", drawNo)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// predictionRequest POST /api/predictions 본문 (모든 필드 생략 가능)
+type predictionRequest struct {
+	Draw     int                `json:"draw"`     // 예측 대상 회차, 0이면 DB 최신 회차 + 1
+	Strategy string             `json:"strategy"` // 비어 있으면 설정의 전략
+	Params   map[string]float64 `json:"params"`   // 전략 파라미터. strategy가 비어 있으면 설정의 strategy_params에 덮어쓴다.
+}
+
+// POST /api/predictions 새 예측을 만들어 저장하고 결과를 반환
+func (s *Server) handleCreatePrediction(w http.ResponseWriter, r *http.Request) {
+	var req predictionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "요청 본문 파싱 실패: %v", err)
+		return
+	}
+
+	latest, ok := s.latestDraw(w, r)
+	if !ok {
+		return
+	}
+	if req.Draw == 0 {
+		req.Draw = latest + 1
+	}
+	if req.Draw < 2 || req.Draw > latest+1 {
+		writeError(w, http.StatusBadRequest, "draw는 2 ~ %d", latest+1)
+		return
+	}
+
+	name, params := req.Strategy, req.Params
+	if name == "" {
+		// 설정의 전략과 파라미터에 요청 파라미터를 덮어쓴다 (CLI -param과 같은 방식)
+		name = config.AppConfig.Strategy
+		if name == "" {
+			name = analyzer.DefaultStrategy
+		}
+		params = map[string]float64{}
+		for k, v := range config.AppConfig.StrategyParams {
+			params[k] = v
+		}
+		for k, v := range req.Params {
+			params[k] = v
+		}
+	}
+	strategy, err := analyzer.NewStrategy(name, params)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+
+	s.predictMu.Lock()
+	result, err := analyzer.AnalyzeWithStrategy(r.Context(), s.store, req.Draw-1, strategy)
+	s.predictMu.Unlock()
+	if err != nil {
+		writeInternal(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, result)
+}
+
+// evaluatedRun 예측 1회와 평가 요약
+type evaluatedRun struct {
+	db.PredictionRun
+	Result  *db.Draw             `json:"result,omitempty"` // 대상 회차 당첨 번호 (추첨 전이면 없음)
+	Summary evaluator.RunSummary `json:"summary"`
+}
+
+// GET /api/evaluations?draw=&limit= (draw가 없으면 전체 회차 최신 순 limit개)
+func (s *Server) handleEvaluations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	drawNo, err := intQuery(r, "draw", 0)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+	limit, err := intQuery(r, "limit", defaultEvaluationLimit)
+	if err != nil || limit == 0 {
+		writeError(w, http.StatusBadRequest, "limit은 1 이상")
+		return
+	}
+
+	runs, err := s.store.ListPredictionRuns(ctx, drawNo, limit)
+	if err != nil {
+		writeInternal(w, r, err)
+		return
+	}
+
+	results := map[int]*db.Draw{}
+	out := []evaluatedRun{}
+	for _, run := range runs {
+		draw, ok := results[run.DrawNumber]
+		if !ok {
+			draw, err = s.store.GetDraw(ctx, run.DrawNumber)
+			if err != nil && !errors.Is(err, db.ErrNotFound) {
+				writeInternal(w, r, err)
+				return
+			}
+			results[run.DrawNumber] = draw
+		}
+		out = append(out, evaluatedRun{PredictionRun: run, Result: draw, Summary: evaluator.Summarize(s.store.Game(), &run, draw)})
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"runs": out})
+}
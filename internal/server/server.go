@@ -0,0 +1,101 @@
+// internal/server/server.go
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"lottopredictor/internal/db"
+)
+
+// Server 분석/예측 기능을 JSON API(/api/...)와 내장 대시보드(/)로 제공하는 http.Handler
+// API 오류는 모두 {"error": {"status": 404, "message": "..."}} 형식으로 응답한다.
+type Server struct {
+	store *db.Store
+	mux   *http.ServeMux
+
+	// 예측 생성은 설정/난수 상태를 공유하므로 한 번에 하나만 실행
+	predictMu sync.Mutex
+}
+
+func New(store *db.Store) *Server {
+	s := &Server{store: store, mux: http.NewServeMux()}
+	s.routes()
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) routes() {
+	s.handle("/api/health", methods{"GET": s.handleHealth})
+	s.handle("/api/draws", methods{"GET": s.handleDraws})
+	s.handle("/api/draws/{draw}", methods{"GET": s.handleDraw})
+	s.handle("/api/numbers", methods{"GET": s.handleNumbers})
+	s.handle("/api/strategies", methods{"GET": s.handleStrategies})
+	s.handle("/api/predictions", methods{"POST": s.handleCreatePrediction})
+	s.handle("/api/predictions/latest", methods{"GET": s.handleLatestPrediction})
+	s.handle("/api/predictions/{draw}", methods{"GET": s.handlePrediction})
+	s.handle("/api/evaluations", methods{"GET": s.handleEvaluations})
+
+	s.mux.HandleFunc("/api/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "알 수 없는 API: %s", r.URL.Path)
+	})
+	s.mux.Handle("/", dashboardHandler())
+}
+
+// methods HTTP 메서드별 핸들러
+type methods map[string]http.HandlerFunc
+
+// handle path에 메서드별 핸들러를 등록한다. 없는 메서드로 요청하면 JSON 405 응답
+func (s *Server) handle(path string, handlers methods) {
+	allowed := []string{}
+	for m := range handlers {
+		allowed = append(allowed, m)
+	}
+	sort.Strings(allowed)
+
+	s.mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		h, ok := handlers[r.Method]
+		if !ok {
+			w.Header().Set("Allow", strings.Join(allowed, ", "))
+			writeError(w, http.StatusMethodNotAllowed, "%s %s 지원하지 않음", r.Method, r.URL.Path)
+			return
+		}
+		h(w, r)
+	})
+}
+
+// apiError 오류 응답 본문
+type apiError struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		log.Printf("[Serve] 응답 쓰기 실패: %v\n", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, format string, args ...any) {
+	writeJSON(w, status, map[string]apiError{
+		"error": {Status: status, Message: fmt.Sprintf(format, args...)},
+	})
+}
+
+// writeInternal 내부 오류는 로그에 남기고 같은 형식으로 500 응답
+func writeInternal(w http.ResponseWriter, r *http.Request, err error) {
+	log.Printf("[Serve] %s %s 실패: %v\n", r.Method, r.URL.Path, err)
+	writeError(w, http.StatusInternalServerError, "%v", err)
+}
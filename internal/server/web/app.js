@@ -0,0 +1,320 @@
+// lottoPredictor 대시보드. 외부 라이브러리 없이 /api 만 사용한다.
+"use strict";
+
+const SVG_NS = "http://www.w3.org/2000/svg";
+
+let latestDraw = 0;
+let currentDraw = 0;
+
+async function api(path, options) {
+	const resp = await fetch(path, options);
+	let body = null;
+	try {
+		body = await resp.json();
+	} catch (e) {
+		throw new Error(path + ": 응답 파싱 실패");
+	}
+	if (!resp.ok) {
+		throw new Error(body && body.error ? body.error.message : resp.status + " " + resp.statusText);
+	}
+	return body;
+}
+
+function el(tag, attrs, ...children) {
+	const node = document.createElement(tag);
+	for (const [k, v] of Object.entries(attrs || {})) {
+		if (k === "class") {
+			node.className = v;
+		} else if (k.startsWith("on")) {
+			node.addEventListener(k.slice(2), v);
+		} else {
+			node.setAttribute(k, v);
+		}
+	}
+	for (const c of children) {
+		if (c === null || c === undefined) continue;
+		node.append(c instanceof Node ? c : document.createTextNode(String(c)));
+	}
+	return node;
+}
+
+function svg(tag, attrs, text) {
+	const node = document.createElementNS(SVG_NS, tag);
+	for (const [k, v] of Object.entries(attrs || {})) {
+		node.setAttribute(k, v);
+	}
+	if (text !== undefined) node.textContent = text;
+	return node;
+}
+
+function showError(target, err) {
+	target.replaceChildren(el("p", { class: "error" }, err.message));
+}
+
+function won(v) {
+	return Number(v).toLocaleString("ko-KR") + "원";
+}
+
+// ball 번호 공. 동행복권과 같은 10단위 색상
+function ball(n, extra) {
+	const range = Math.min(Math.floor((n - 1) / 10) + 1, 5);
+	return el("span", { class: "ball r" + range + (extra ? " " + extra : "") }, n);
+}
+
+function balls(numbers, winning) {
+	const frag = document.createDocumentFragment();
+	for (const n of numbers) {
+		frag.append(ball(n, winning && !winning.includes(n) ? "miss" : ""));
+	}
+	return frag;
+}
+
+// drawBalls 당첨 번호 + 보너스 번호 (보너스 풀이 있는 게임은 보너스 풀 당첨 번호)
+function drawBalls(d) {
+	const frag = document.createDocumentFragment();
+	frag.append(balls(d.numbers));
+	if (d.bonus > 0) frag.append(el("span", { class: "plus" }, "+"), ball(d.bonus));
+	if (d.bonus_numbers) frag.append(el("span", { class: "plus" }, "+"), balls(d.bonus_numbers));
+	return frag;
+}
+
+function rankText(rank) {
+	return rank > 0 ? rank + "등" : "낙첨";
+}
+
+// ---- 상태 ----
+
+async function loadStatus() {
+	const status = document.getElementById("status");
+	try {
+		const h = await api("/api/health");
+		latestDraw = h.latest_draw;
+		status.textContent = "최신 회차 " + h.latest_draw + (h.latest_prediction ? " · 최근 예측 " + h.latest_prediction + "회" : "");
+	} catch (err) {
+		status.textContent = err.message;
+	}
+}
+
+// ---- 번호별 차트 ----
+
+function renderChart(data, metric) {
+	const width = 900, height = 320;
+	const m = { top: 20, right: 20, bottom: 30, left: 45 };
+	const plotW = width - m.left - m.right;
+	const plotH = height - m.top - m.bottom;
+
+	const values = data.numbers.map(x => metric === "gap" ? x.gap : x.probability);
+	const avg = values.reduce((a, b) => a + b, 0) / values.length;
+	const maxValue = Math.max(...values, avg) * 1.1 || 1;
+	const y = v => m.top + plotH - (v / maxValue) * plotH;
+	const step = plotW / values.length;
+	const highlight = new Set(metric === "gap" ? data.recent_missing : data.top_frequent);
+	const unit = metric === "gap" ? "회" : "%";
+
+	const root = svg("svg", { viewBox: `0 0 ${width} ${height}`, role: "img" });
+
+	// y축 눈금
+	for (let i = 0; i <= 4; i++) {
+		const v = maxValue * i / 4;
+		root.append(svg("line", { x1: m.left, x2: width - m.right, y1: y(v), y2: y(v), stroke: "#eee" }));
+		root.append(svg("text", { x: m.left - 6, y: y(v) + 4, "text-anchor": "end" }, v.toFixed(metric === "gap" ? 0 : 1) + unit));
+	}
+
+	data.numbers.forEach((x, i) => {
+		const v = values[i];
+		const bar = svg("rect", {
+			class: "bar" + (highlight.has(x.number) ? " hot" : ""),
+			x: m.left + i * step + 2,
+			y: y(v),
+			width: step - 4,
+			height: m.top + plotH - y(v),
+		});
+		bar.append(svg("title", {}, `${x.number}번: 등장 확률 ${x.probability.toFixed(3)}%, 미출현 ${x.gap}회`));
+		root.append(bar);
+		root.append(svg("text", { x: m.left + i * step + step / 2, y: height - m.bottom + 14, "text-anchor": "middle" }, x.number));
+	});
+
+	// 평균선
+	root.append(svg("line", { class: "avg", x1: m.left, x2: width - m.right, y1: y(avg), y2: y(avg) }));
+	root.append(svg("text", { class: "avg-label", x: width - m.right, y: y(avg) - 5, "text-anchor": "end" },
+		"평균 " + avg.toFixed(metric === "gap" ? 1 : 2) + unit));
+
+	document.getElementById("chart").replaceChildren(root);
+	document.getElementById("chart-hint").textContent = data.draw_number + "회 예측 기준 · 주황색: " +
+		(metric === "gap" ? "오래 나오지 않은 번호" : "가장 많이 등장한 번호 Top 10");
+}
+
+async function loadNumbers() {
+	const form = document.getElementById("numbers-form");
+	const draw = form.draw.value;
+	const metric = form.metric.value;
+	try {
+		const data = await api("/api/numbers" + (draw ? "?draw=" + draw : ""));
+		form.draw.value = data.draw_number;
+		renderChart(data, metric);
+	} catch (err) {
+		showError(document.getElementById("chart"), err);
+	}
+}
+
+// ---- 회차별 당첨 번호 ----
+
+async function loadDraw(drawNo) {
+	const detail = document.getElementById("draw-detail");
+	const rows = document.getElementById("draw-rows");
+	if (!drawNo || drawNo < 1) return;
+	currentDraw = drawNo;
+	document.getElementById("draws-form").draw.value = drawNo;
+
+	try {
+		const d = await api("/api/draws/" + drawNo);
+		detail.replaceChildren(el("p", {},
+			el("strong", {}, d.number + "회 "), d.date + " ",
+			drawBalls(d)));
+	} catch (err) {
+		showError(detail, err);
+	}
+
+	try {
+		const list = await api(`/api/draws?to=${Math.max(drawNo, 1)}&limit=15`);
+		rows.replaceChildren(...list.draws.reverse().map(d => el("tr", { class: "clickable", onclick: () => loadDraw(d.number) },
+			el("td", {}, d.number),
+			el("td", {}, d.date),
+			el("td", {}, drawBalls(d)),
+			el("td", {}, d.total_sales ? d.first_winners + "명" : "-"),
+			el("td", {}, d.total_sales ? won(d.first_prize) : "-"))));
+	} catch (err) {
+		rows.replaceChildren(el("tr", {}, el("td", { colspan: 5, class: "error" }, err.message)));
+	}
+}
+
+// ---- 예측 실행 / 평가 ----
+
+function setRows(run) {
+	const winning = run.result ? run.result.numbers : null;
+	const box = el("div");
+	for (const s of run.sets) {
+		const meta = s.evaluation
+			? `${s.evaluation.matched}개 일치${s.evaluation.bonus_matched ? " + 보너스" : ""}${s.evaluation.bonus_matches ? ` + 보너스 풀 ${s.evaluation.bonus_matches}개` : ""} · ${rankText(s.evaluation.rank)}`
+			: "평가 전";
+		const bonus = s.bonus ? [el("span", { class: "plus" }, "+"), balls(s.bonus, run.result ? run.result.bonus_numbers : null)] : [];
+		box.append(el("div", { class: "set-row" }, s.set_index + ". ", balls(s.numbers, winning), ...bonus, el("span", { class: "meta" }, meta)));
+	}
+	if (run.result) {
+		box.append(el("div", { class: "set-row" }, "당첨 번호: ", drawBalls(run.result)));
+	}
+	return box;
+}
+
+async function loadRuns() {
+	const rows = document.getElementById("run-rows");
+	const draw = document.getElementById("runs-form").draw.value;
+	try {
+		const data = await api("/api/evaluations?limit=30" + (draw ? "&draw=" + draw : ""));
+		if (data.runs.length === 0) {
+			rows.replaceChildren(el("tr", {}, el("td", { colspan: 7 }, "저장된 예측 없음")));
+			return;
+		}
+		const out = [];
+		for (const run of data.runs) {
+			const s = run.summary;
+			const detail = el("tr", { class: "detail", hidden: "" }, el("td", { colspan: 7 }, setRows(run)));
+			out.push(el("tr", { class: "clickable", onclick: () => detail.toggleAttribute("hidden") },
+				el("td", {}, run.draw_number),
+				el("td", {}, "#" + run.idx),
+				el("td", {}, run.strategy || "-"),
+				el("td", {}, run.created_at),
+				el("td", {}, s.evaluated ? `${s.evaluated}/${s.sets}` : "추첨 전"),
+				el("td", {}, s.evaluated ? rankText(s.best_rank) : "-"),
+				el("td", {}, s.evaluated ? won(s.prize) : "-")), detail);
+		}
+		rows.replaceChildren(...out);
+	} catch (err) {
+		rows.replaceChildren(el("tr", {}, el("td", { colspan: 7, class: "error" }, err.message)));
+	}
+}
+
+// ---- 추천 번호 생성 ----
+
+async function loadStrategies() {
+	const select = document.getElementById("generate-form").strategy;
+	try {
+		const data = await api("/api/strategies");
+		select.replaceChildren(...data.strategies.map(name => {
+			const opt = el("option", { value: name }, name);
+			if (name === data.default) opt.selected = true;
+			return opt;
+		}));
+	} catch (err) {
+		select.replaceChildren(el("option", { value: "" }, "설정 기본값"));
+	}
+}
+
+// parseParams "a=1, b=2" → {a: 1, b: 2}
+function parseParams(text) {
+	const params = {};
+	for (const part of text.split(/[,\s]+/).filter(Boolean)) {
+		const [k, v] = part.split("=");
+		if (!k || v === undefined || isNaN(Number(v))) {
+			throw new Error("파라미터 형식 오류: " + part + " (key=숫자)");
+		}
+		params[k] = Number(v);
+	}
+	return params;
+}
+
+async function generate(ev) {
+	ev.preventDefault();
+	const form = ev.target;
+	const out = document.getElementById("generate-result");
+	const button = form.querySelector("button");
+	button.disabled = true;
+	try {
+		const body = { strategy: form.strategy.value, params: parseParams(form.params.value) };
+		if (form.draw.value) body.draw = Number(form.draw.value);
+		const r = await api("/api/predictions", {
+			method: "POST",
+			headers: { "Content-Type": "application/json" },
+			body: JSON.stringify(body),
+		});
+		out.replaceChildren(
+			el("p", {}, `${r.draw_number}회 추천 (${r.strategy}) · 세트당 기대 당첨금 ${r.expected_value.toFixed(1)}원`),
+			...r.suggestion_sets.map((set, i) => el("div", { class: "set-row" }, i + 1 + ". ", balls(set))));
+		await Promise.all([loadRuns(), loadStatus()]);
+	} catch (err) {
+		showError(out, err);
+	} finally {
+		button.disabled = false;
+	}
+}
+
+// ---- 초기화 ----
+
+document.getElementById("numbers-form").addEventListener("submit", ev => {
+	ev.preventDefault();
+	loadNumbers();
+});
+document.getElementById("numbers-form").addEventListener("change", ev => {
+	if (ev.target.name === "metric") loadNumbers();
+});
+document.getElementById("draws-form").addEventListener("submit", ev => {
+	ev.preventDefault();
+	loadDraw(Number(ev.target.draw.value));
+});
+document.querySelectorAll("#draws-form button[data-step]").forEach(b => b.addEventListener("click", () => {
+	const next = currentDraw + Number(b.dataset.step);
+	if (next >= 1 && next <= latestDraw) loadDraw(next);
+}));
+document.getElementById("runs-form").addEventListener("submit", ev => {
+	ev.preventDefault();
+	loadRuns();
+});
+document.getElementById("generate-form").addEventListener("submit", generate);
+
+(async function init() {
+	await loadStatus();
+	loadNumbers();
+	loadDraw(latestDraw);
+	loadStrategies();
+	loadRuns();
+})();
@@ -0,0 +1,456 @@
+// internal/simulate/simulate.go
+package simulate
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+
+	"lottopredictor/internal/game"
+	"lottopredictor/internal/util"
+)
+
+// 가상 추첨 모델. 보너스 풀 번호는 두 모델 모두 균등 추첨이다.
+const (
+	ModelUniform = "uniform" // 본 번호 풀 균등 추첨
+	ModelFitted  = "fitted"  // 번호별 가중치(이력으로 추정한 출현 확률)에 비례한 비복원 추첨
+)
+
+// 기본값
+const (
+	DefaultTrials = 1000000
+	DefaultRounds = 1
+)
+
+// Options 시뮬레이션 설정
+type Options struct {
+	Game    *game.Game // nil이면 로또 6/45
+	Bonus   [][]int    // 세트별 보너스 풀 번호 (보너스 풀이 있는 게임만, 세트 순)
+	Model   string
+	Weights []float64 // ModelFitted의 본 번호별 가중치 (index = 번호-1)
+	Trials  int       // 가상 시행 수 (0이면 1,000,000)
+	Rounds  int       // 시행 하나에서 같은 세트로 참여하는 회차 수 (0이면 1)
+	Workers int       // 병렬 작업 수 (0이면 CPU 수)
+	Seed    int64     // 난수 시드. 같은 시드, 같은 Workers면 같은 결과
+	Prizes  map[int]int64
+}
+
+// Validate 값 범위 확인
+func (o *Options) Validate() error {
+	g := o.game()
+	if g.Main.Max > 2*64 || g.Bonus.Max > 64 {
+		return fmt.Errorf("%s: 시뮬레이션은 본 번호 128개, 보너스 풀 64개까지 지원", g.Name)
+	}
+	switch o.Model {
+	case ModelUniform:
+	case ModelFitted:
+		if len(o.Weights) != g.Main.Max {
+			return fmt.Errorf("fitted 모델 가중치는 %d개여야 함: %d", g.Main.Max, len(o.Weights))
+		}
+		positive := 0
+		for _, w := range o.Weights {
+			if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+				return fmt.Errorf("fitted 모델 가중치가 잘못됨: %v", w)
+			}
+			if w > 0 {
+				positive++
+			}
+		}
+		if drawn := g.Main.Picks + g.Extra; positive < drawn {
+			return fmt.Errorf("fitted 모델 가중치가 0보다 큰 번호가 %d개 미만", drawn)
+		}
+	default:
+		return fmt.Errorf("알 수 없는 추첨 모델 %q (사용 가능: %s, %s)", o.Model, ModelUniform, ModelFitted)
+	}
+	if o.Trials < 0 || o.Rounds < 0 || o.Workers < 0 {
+		return fmt.Errorf("시행 수, 회차 수, 작업 수는 0 이상이어야 함")
+	}
+	return nil
+}
+
+func (o *Options) game() *game.Game {
+	if o.Game == nil {
+		return game.Default
+	}
+	return o.Game
+}
+
+func (o *Options) trials() int {
+	if o.Trials > 0 {
+		return o.Trials
+	}
+	return DefaultTrials
+}
+
+func (o *Options) rounds() int {
+	if o.Rounds > 0 {
+		return o.Rounds
+	}
+	return DefaultRounds
+}
+
+func (o *Options) workers() int {
+	if o.Workers > 0 {
+		return o.Workers
+	}
+	return runtime.NumCPU()
+}
+
+// Outcome 시행 하나의 당첨금 합계와 그 값이 나온 시행 수
+type Outcome struct {
+	Return      int64   `json:"return"`
+	Count       int64   `json:"count"`
+	Probability float64 `json:"probability"`
+}
+
+// Percentile 당첨금 합계 분포의 분위수
+type Percentile struct {
+	P      float64 `json:"p"`
+	Return int64   `json:"return"`
+}
+
+// percentiles 결과에 표시하는 분위수
+var percentiles = []float64{0.5, 0.9, 0.99, 0.999, 0.9999}
+
+// Result 시뮬레이션 결과. 금액은 게임 통화 최소 단위(원, 센트), 시행 하나는 세트 Tickets개로 Rounds 회차에 참여한 것
+type Result struct {
+	Game    string        `json:"game"`
+	Model   string        `json:"model"`
+	Trials  int           `json:"trials"`
+	Rounds  int           `json:"rounds"`
+	Tickets int           `json:"tickets"`
+	Workers int           `json:"workers"`
+	Seed    int64         `json:"seed"`
+	Prizes  map[int]int64 `json:"prizes"`
+	Cost    int64         `json:"cost"` // 시행 하나의 구매 비용
+
+	RankCounts []int64 `json:"rank_counts"` // 세트×회차 단위 등수별 횟수 (index 0은 낙첨)
+
+	MeanReturn float64 `json:"mean_return"` // 시행 하나의 평균 당첨금 합계
+	StdDev     float64 `json:"std_dev"`
+	// LossPerTicket 1게임(로또 6/45는 1,000원) 구매당 기대 손실 (게임 통화 최소 단위)
+	LossPerTicket float64 `json:"loss_per_ticket"`
+	// BreakEven 당첨금 합계가 구매 비용 이상인 시행 비율
+	BreakEven float64 `json:"break_even"`
+
+	Distribution []Outcome    `json:"distribution"` // 당첨금 합계별 시행 수 (금액 순)
+	Percentiles  []Percentile `json:"percentiles"`
+
+	ticketPrice int64 // 1게임 가격
+}
+
+// ROI 평균 (당첨금 - 구매 비용) / 구매 비용
+func (r *Result) ROI() float64 {
+	if r.Cost == 0 {
+		return 0
+	}
+	return (r.MeanReturn - float64(r.Cost)) / float64(r.Cost)
+}
+
+// partial 작업 하나의 집계
+type partial struct {
+	ranks   []int64
+	returns map[int64]int64
+}
+
+// bitset 번호(0부터) 128개까지의 비트마스크
+type bitset [2]uint64
+
+func (b *bitset) add(n int)     { b[n/64] |= 1 << (n % 64) }
+func (b bitset) has(n int) bool { return b[n/64]&(1<<(n%64)) != 0 }
+func (b bitset) common(c bitset) int {
+	return bits.OnesCount64(b[0]&c[0]) + bits.OnesCount64(b[1]&c[1])
+}
+
+// ticket 세트 하나의 본 번호/보너스 풀 번호 비트마스크
+type ticket struct {
+	main  bitset
+	bonus uint64
+}
+
+// outcome 가상 추첨 한 번의 당첨 번호, 보너스 번호, 보너스 풀 당첨 번호 비트마스크
+type outcome struct {
+	main, extra bitset
+	bonus       uint64
+}
+
+// rankTable 본 번호/보너스 풀/보너스 번호 일치로 등수를 바로 찾는 표 (game.RankOf 결과를 미리 계산)
+type rankTable struct {
+	bonus int
+	ranks []int
+}
+
+func newRankTable(g *game.Game) rankTable {
+	t := rankTable{bonus: g.Bonus.Picks + 1, ranks: make([]int, (g.Main.Picks+1)*(g.Bonus.Picks+1)*2)}
+	for main := 0; main <= g.Main.Picks; main++ {
+		for bonus := 0; bonus <= g.Bonus.Picks; bonus++ {
+			t.ranks[t.index(main, bonus, false)] = g.RankOf(main, bonus, false)
+			t.ranks[t.index(main, bonus, true)] = g.RankOf(main, bonus, true)
+		}
+	}
+	return t
+}
+
+func (t rankTable) index(main, bonus int, extra bool) int {
+	i := (main*t.bonus + bonus) * 2
+	if extra {
+		i++
+	}
+	return i
+}
+
+func (t rankTable) rank(tk ticket, o outcome) int {
+	return t.ranks[t.index(tk.main.common(o.main), bits.OnesCount64(tk.bonus&o.bonus), tk.main.common(o.extra) > 0)]
+}
+
+// Run tickets 세트 묶음을 가상 추첨 Trials×Rounds 회에 참여시켜 당첨금 분포를 구한다.
+// 등수는 예측 평가와 같은 게임 등수 규칙(game.RankOf)으로 정하고, 시행은 Workers개 작업에 나눠 병렬로 돌린다.
+// 작업마다 Seed에서 정해지는 난수열과 시행 수를 쓰므로 실행 순서와 관계없이 결과가 같다.
+func Run(tickets [][]int, opts Options) (*Result, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if len(tickets) == 0 {
+		return nil, fmt.Errorf("시뮬레이션할 세트가 없음")
+	}
+	g := opts.game()
+	if g.Bonus.Picks > 0 && len(opts.Bonus) != len(tickets) {
+		return nil, fmt.Errorf("%s: 세트마다 보너스 풀 번호가 필요함 (세트 %d개, 보너스 %d개)", g.Name, len(tickets), len(opts.Bonus))
+	}
+	masks := make([]ticket, len(tickets))
+	for i, t := range tickets {
+		var bonus []int
+		if g.Bonus.Picks > 0 {
+			bonus = opts.Bonus[i]
+		}
+		if err := g.CheckTicket(t, bonus); err != nil {
+			return nil, fmt.Errorf("세트 %d: %w", i+1, err)
+		}
+		for _, n := range t {
+			masks[i].main.add(n - 1)
+		}
+		for _, n := range bonus {
+			masks[i].bonus |= 1 << (n - 1)
+		}
+	}
+	table := newRankTable(g)
+	prizes := make([]int64, g.Ranks()+1)
+	for rank := 1; rank <= g.Ranks(); rank++ {
+		prizes[rank] = opts.Prizes[rank]
+	}
+
+	trials, rounds, workers := opts.trials(), opts.rounds(), min(opts.workers(), opts.trials())
+	parts := make([]partial, workers)
+	var wg sync.WaitGroup
+	for w := range workers {
+		// 시행을 작업 수로 고르게 나눈다 (앞쪽 작업이 나머지를 하나씩 더 맡음)
+		n := trials / workers
+		if w < trials%workers {
+			n++
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			random := util.NewRand(opts.Seed + int64(w))
+			draw := newDrawer(g, opts.Model, opts.Weights, random)
+			p := partial{ranks: make([]int64, len(prizes)), returns: map[int64]int64{}}
+			for range n {
+				total := int64(0)
+				for range rounds {
+					o := draw()
+					for _, m := range masks {
+						rank := table.rank(m, o)
+						p.ranks[rank]++
+						total += prizes[rank]
+					}
+				}
+				p.returns[total]++
+			}
+			parts[w] = p
+		}()
+	}
+	wg.Wait()
+
+	result := &Result{
+		Game:    g.ID,
+		Model:   opts.Model,
+		Trials:  trials,
+		Rounds:  rounds,
+		Tickets: len(tickets),
+		Workers: workers,
+		Seed:    opts.Seed,
+		Prizes:  map[int]int64{},
+		Cost:    int64(len(tickets)*rounds) * g.TicketPrice,
+
+		RankCounts:  make([]int64, len(prizes)),
+		ticketPrice: g.TicketPrice,
+	}
+	for rank := 1; rank < len(prizes); rank++ {
+		result.Prizes[rank] = prizes[rank]
+	}
+	returns := map[int64]int64{}
+	for _, p := range parts {
+		for rank, c := range p.ranks {
+			result.RankCounts[rank] += c
+		}
+		for v, c := range p.returns {
+			returns[v] += c
+		}
+	}
+	summarize(result, returns)
+	return result, nil
+}
+
+// summarize 당첨금 합계별 시행 수로 평균, 표준편차, 손익분기 확률, 분포, 분위수를 채운다.
+func summarize(r *Result, returns map[int64]int64) {
+	values := make([]int64, 0, len(returns))
+	for v := range returns {
+		values = append(values, v)
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	n := float64(r.Trials)
+	sum, sumSq, even := 0.0, 0.0, int64(0)
+	for _, v := range values {
+		c := returns[v]
+		sum += float64(v) * float64(c)
+		sumSq += float64(v) * float64(v) * float64(c)
+		if v >= r.Cost {
+			even += c
+		}
+		r.Distribution = append(r.Distribution, Outcome{Return: v, Count: c, Probability: float64(c) / n})
+	}
+	r.MeanReturn = sum / n
+	r.StdDev = math.Sqrt(max(0, sumSq/n-r.MeanReturn*r.MeanReturn))
+	r.BreakEven = float64(even) / n
+	if r.Cost > 0 {
+		r.LossPerTicket = (float64(r.Cost) - r.MeanReturn) / float64(r.Cost) * float64(r.ticketPrice)
+	}
+
+	cum := int64(0)
+	next := 0
+	for _, v := range values {
+		cum += returns[v]
+		for next < len(percentiles) && float64(cum) >= percentiles[next]*n {
+			r.Percentiles = append(r.Percentiles, Percentile{P: percentiles[next], Return: v})
+			next++
+		}
+	}
+}
+
+// newDrawer 가상 추첨 한 번의 당첨 번호, 보너스 번호, 보너스 풀 당첨 번호를 만드는 함수
+func newDrawer(g *game.Game, model string, weights []float64, random *rand.Rand) func() outcome {
+	main := uniformDrawer(g.Main.Max, random)
+	if model == ModelFitted {
+		main = fittedDrawer(weights, random)
+	}
+	bonus := uniformDrawer(g.Bonus.Max, random)
+	return func() outcome {
+		var o outcome
+		// 보너스 번호는 당첨 번호 다음에 같은 풀에서 이어서 뽑는다
+		main(g.Main.Picks+g.Extra, func(i, n int) {
+			if i < g.Main.Picks {
+				o.main.add(n)
+			} else {
+				o.extra.add(n)
+			}
+		})
+		if g.Bonus.Picks > 0 {
+			bonus(g.Bonus.Picks, func(_, n int) { o.bonus |= 1 << n })
+		}
+		return o
+	}
+}
+
+// uniformDrawer 0 ~ size-1 중 count개를 균등 비복원 추첨해 뽑은 순서대로 pick에 넘긴다.
+func uniformDrawer(size int, random *rand.Rand) func(count int, pick func(i, n int)) {
+	nums := make([]int, size)
+	return func(count int, pick func(i, n int)) {
+		for i := range nums {
+			nums[i] = i
+		}
+		// 앞 count개만 섞는 부분 Fisher-Yates
+		for i := 0; i < count; i++ {
+			k := i + random.Intn(len(nums)-i)
+			nums[i], nums[k] = nums[k], nums[i]
+			pick(i, nums[i])
+		}
+	}
+}
+
+// fittedDrawer 가중치에 비례해 번호를 하나씩 비복원으로 뽑는다. 보너스 번호도 남은 번호에서 같은 방식으로 뽑는다.
+func fittedDrawer(weights []float64, random *rand.Rand) func(count int, pick func(i, n int)) {
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+	return func(count int, pick func(i, n int)) {
+		var drawn bitset
+		remaining := total
+		for i := 0; i < count; i++ {
+			target := random.Float64() * remaining
+			next := -1
+			for n, w := range weights {
+				if w == 0 || drawn.has(n) {
+					continue
+				}
+				next = n
+				if target -= w; target < 0 {
+					break
+				}
+			}
+			remaining -= weights[next]
+			drawn.add(next)
+			pick(i, next)
+		}
+	}
+}
+
+// DefaultEdges 당첨금 분포 구간 경계 (구매 비용 대비 배수)
+var DefaultEdges = []float64{0, 0.5, 1, 2, 10, 100}
+
+// Bucket 구매 비용 대비 당첨금 배수 구간의 시행 비율.
+// ZeroOnly 구간은 당첨금 0원만, 나머지는 [From, To)이고 0원은 빠진다 (From이 0이면 0 초과). To가 0이면 상한 없음
+type Bucket struct {
+	From        float64 `json:"from"`
+	To          float64 `json:"to"`
+	ZeroOnly    bool    `json:"zero_only,omitempty"`
+	Probability float64 `json:"probability"`
+}
+
+// Contains 구매 비용 대비 배수 ratio가 구간에 드는지
+func (b Bucket) Contains(ratio float64) bool {
+	if b.ZeroOnly {
+		return ratio == 0
+	}
+	if ratio <= 0 || ratio < b.From {
+		return false
+	}
+	return b.To == 0 || ratio < b.To
+}
+
+// Histogram 구매 비용 대비 배수 구간별 시행 비율. 첫 구간은 당첨금 0원만 세는 ZeroOnly 구간이고 구간끼리 겹치지 않는다.
+func (r *Result) Histogram(edges []float64) []Bucket {
+	buckets := []Bucket{{ZeroOnly: true}}
+	for i := 0; i < len(edges); i++ {
+		b := Bucket{From: edges[i]}
+		if i+1 < len(edges) {
+			b.To = edges[i+1]
+		}
+		buckets = append(buckets, b)
+	}
+	for _, o := range r.Distribution {
+		ratio := float64(o.Return) / float64(r.Cost)
+		for i := range buckets {
+			if buckets[i].Contains(ratio) {
+				buckets[i].Probability += o.Probability
+				break
+			}
+		}
+	}
+	return buckets
+}
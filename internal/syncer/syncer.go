@@ -0,0 +1,221 @@
+// internal/syncer/syncer.go
+// 당첨 번호 동기화: 재시도/백오프, 초당 요청 제한, 빠진 회차 복구, 실행 기록(sync_runs)
+package syncer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"lottopredictor/internal/db"
+	"lottopredictor/internal/fetcher"
+)
+
+// Options 동기화 동작 설정. 0 값은 기본값으로 채워진다.
+type Options struct {
+	MaxRetries        int           // 네트워크 오류 시 재시도 횟수 (기본 3)
+	InitialBackoff    time.Duration // 첫 재시도 대기 시간, 이후 2배씩 증가 (기본 1초)
+	MaxBackoff        time.Duration // 재시도 대기 상한 (기본 30초)
+	RequestsPerSecond float64       // 초당 최대 요청 수 (기본 2)
+	RepairGaps        bool          // 1회 ~ 최신 회차 사이 빠진 회차 복구
+}
+
+func (o *Options) setDefaults() {
+	if o.MaxRetries <= 0 {
+		o.MaxRetries = 3
+	}
+	if o.InitialBackoff <= 0 {
+		o.InitialBackoff = time.Second
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = 30 * time.Second
+	}
+	if o.RequestsPerSecond <= 0 {
+		o.RequestsPerSecond = 2
+	}
+}
+
+// Result 동기화 1회 실행 결과
+type Result struct {
+	RunID      int64
+	Added      []int // 새로 추가된 회차
+	Repaired   []int // 복구된 빠진 회차
+	Errors     []string
+	LatestDraw int
+}
+
+// Syncer DrawSource에서 DB로 당첨 번호를 동기화한다.
+type Syncer struct {
+	store  *db.Store
+	source fetcher.DrawSource
+	opts   Options
+
+	lastRequest time.Time
+}
+
+func New(store *db.Store, source fetcher.DrawSource, opts Options) *Syncer {
+	opts.setDefaults()
+	return &Syncer{store: store, source: source, opts: opts}
+}
+
+// Run 빠진 회차 복구(RepairGaps) 후 최신 회차 다음부터 "결과 없음" 응답을 받을 때까지 저장한다.
+// 소스 게임이 DB 게임과 다르거나, 재시도 후에도 네트워크 오류가 나거나 응답 번호가 게임 규칙에 맞지 않으면 그 지점에서 멈추고 오류를 기록한다. (새 회차 없음으로 취급하지 않음)
+// 실행 기록은 성공/실패와 관계없이 sync_runs에 남는다.
+func (s *Syncer) Run(ctx context.Context) (*Result, error) {
+	runID, err := s.store.StartSyncRun(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("sync_runs 기록 실패: %w", err)
+	}
+	result := &Result{RunID: runID}
+
+	runErr := s.run(ctx, result)
+	if runErr != nil {
+		result.Errors = append(result.Errors, runErr.Error())
+	}
+	if latest, err := s.store.LatestDrawNumber(ctx); err == nil {
+		result.LatestDraw = latest
+	} else if runErr == nil {
+		runErr = fmt.Errorf("최신 회차 조회 실패: %w", err)
+		result.Errors = append(result.Errors, runErr.Error())
+	}
+
+	status := db.SyncStatusSuccess
+	if runErr != nil {
+		status = db.SyncStatusFailed
+	}
+	// 취소된 ctx로도 종료 기록은 남긴다
+	err = s.store.FinishSyncRun(context.WithoutCancel(ctx), &db.SyncRun{
+		ID:            runID,
+		DrawsAdded:    len(result.Added),
+		DrawsRepaired: len(result.Repaired),
+		Errors:        result.Errors,
+		Status:        status,
+	})
+	if err != nil {
+		log.Printf("[Sync] sync_runs(%d) 종료 기록 실패: %v\n", runID, err)
+	}
+	return result, runErr
+}
+
+func (s *Syncer) run(ctx context.Context, result *Result) error {
+	if src, dst := s.source.Game(), s.store.Game(); src != dst {
+		return fmt.Errorf("당첨 번호 소스 게임(%s)이 DB 게임(%s)과 다름", src.Name, dst.Name)
+	}
+	if s.opts.RepairGaps {
+		missing, err := s.store.FindMissingDraws(ctx)
+		if err != nil {
+			return fmt.Errorf("빠진 회차 조회 실패: %w", err)
+		}
+		if len(missing) > 0 {
+			log.Printf("[Sync] 빠진 회차 %d개 복구 시작: %v\n", len(missing), missing)
+		}
+		for _, drawNo := range missing {
+			data, err := s.fetch(ctx, drawNo)
+			if errors.Is(err, fetcher.ErrDrawNotFound) {
+				// 과거 회차인데 결과가 없다면 API 쪽 문제. 다른 회차는 계속 복구
+				result.Errors = append(result.Errors, err.Error())
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if err := s.save(ctx, data); err != nil {
+				return err
+			}
+			result.Repaired = append(result.Repaired, drawNo)
+		}
+	}
+
+	latest, err := s.store.LatestDrawNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("최신 회차 조회 실패: %w", err)
+	}
+	for drawNo := latest + 1; ; drawNo++ {
+		data, err := s.fetch(ctx, drawNo)
+		if errors.Is(err, fetcher.ErrDrawNotFound) {
+			log.Printf("[Sync] 회차 %d 아직 결과 없음, 동기화 종료\n", drawNo)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.save(ctx, data); err != nil {
+			return err
+		}
+		result.Added = append(result.Added, drawNo)
+	}
+}
+
+// fetch 초당 요청 제한을 지키며 조회하고, 결과 없음이 아닌 오류는 지수 백오프로 재시도
+func (s *Syncer) fetch(ctx context.Context, drawNo int) (*fetcher.DrawData, error) {
+	backoff := s.opts.InitialBackoff
+	var lastErr error
+	for attempt := 0; attempt <= s.opts.MaxRetries; attempt++ {
+		if attempt > 0 {
+			log.Printf("[Sync] 회차 %d 재시도 %d/%d (%v 후): %v\n", drawNo, attempt, s.opts.MaxRetries, backoff, lastErr)
+			if err := sleep(ctx, backoff); err != nil {
+				return nil, err
+			}
+			backoff *= 2
+			if backoff > s.opts.MaxBackoff {
+				backoff = s.opts.MaxBackoff
+			}
+		}
+
+		if err := s.wait(ctx); err != nil {
+			return nil, err
+		}
+		data, err := s.source.FetchDraw(ctx, drawNo)
+		if err == nil || errors.Is(err, fetcher.ErrDrawNotFound) {
+			return data, err
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		lastErr = err
+	}
+	return nil, fmt.Errorf("회차 %d 조회 %d회 실패: %w", drawNo, s.opts.MaxRetries+1, lastErr)
+}
+
+// wait 직전 요청 이후 1/RequestsPerSecond 초가 지날 때까지 대기
+func (s *Syncer) wait(ctx context.Context) error {
+	interval := time.Duration(float64(time.Second) / s.opts.RequestsPerSecond)
+	if !s.lastRequest.IsZero() {
+		if err := sleep(ctx, time.Until(s.lastRequest.Add(interval))); err != nil {
+			return err
+		}
+	}
+	s.lastRequest = time.Now()
+	return nil
+}
+
+// save API 응답을 DB 게임 규칙(가져오기와 같은 CheckDraw)으로 검증한 뒤 저장한다.
+// 범위 밖/중복 번호 같은 잘못된 응답은 "결과 없음"이 아니라 동기화 오류로 반환한다.
+func (s *Syncer) save(ctx context.Context, data *fetcher.DrawData) error {
+	if err := s.store.Game().CheckDraw(data.Numbers(), data.BnusNo, data.BnusNos); err != nil {
+		return fmt.Errorf("회차 %d 응답 검증 실패: %w", data.DrwNo, err)
+	}
+	if err := s.store.SaveDraws(ctx, []db.Draw{db.DrawFromData(data)}); err != nil {
+		return err
+	}
+	if data.DrwNo%100 == 0 {
+		log.Printf("[DB] insering(drawnumber: ~%d)\n", data.DrwNo)
+	}
+	return nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
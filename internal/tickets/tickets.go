@@ -0,0 +1,116 @@
+// internal/tickets/tickets.go
+package tickets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"lottopredictor/internal/common"
+	"lottopredictor/internal/config"
+	"lottopredictor/internal/db"
+	"lottopredictor/internal/game"
+)
+
+// StrategyManual 직접 고른 번호로 산 티켓의 전략 이름
+const StrategyManual = "manual"
+
+// DrawSummary 한 회차 티켓 당첨 확인 결과
+type DrawSummary struct {
+	DrawNumber int         `json:"draw_number"`
+	Tickets    int         `json:"tickets"`
+	RankCounts map[int]int `json:"rank_counts"` // 등수별 티켓 수 (0 = 낙첨)
+	Cost       int64       `json:"cost"`
+	Prize      int64       `json:"prize"`
+	Winners    []db.Ticket `json:"winners"` // 당첨 티켓
+}
+
+// Add 티켓 번호를 DB 게임 규칙으로 확인해 저장한다.
+// 구매자는 필수, 전략이 비어 있으면 manual, 구매 금액이 0이면 게임 1장 가격을 쓰고 번호는 오름차순으로 저장한다.
+func Add(ctx context.Context, store *db.Store, tickets []db.Ticket) error {
+	g := store.Game()
+	for i := range tickets {
+		t := &tickets[i]
+		t.Buyer = strings.TrimSpace(t.Buyer)
+		if t.Buyer == "" {
+			return errors.New("티켓 구매자가 없음")
+		}
+		if t.DrawNumber < 1 {
+			return fmt.Errorf("티켓 회차가 잘못됨: %d", t.DrawNumber)
+		}
+		if err := g.CheckTicket(t.Numbers, t.Bonus); err != nil {
+			return fmt.Errorf("티켓 %d: %w", i+1, err)
+		}
+		if t.Strategy == "" {
+			t.Strategy = StrategyManual
+		}
+		if t.Cost < 0 {
+			return fmt.Errorf("티켓 구매 금액이 음수: %d", t.Cost)
+		}
+		if t.Cost == 0 {
+			t.Cost = g.TicketPrice
+		}
+		t.Numbers = sorted(t.Numbers)
+		t.Bonus = sorted(t.Bonus)
+	}
+	return store.AddTickets(ctx, tickets)
+}
+
+func sorted(nums []int) []int {
+	out := append([]int(nil), nums...)
+	sort.Ints(out)
+	return out
+}
+
+// Prize g 게임 draw 회차의 등수별 당첨금. 1등은 회차의 실제 1인당 당첨금 자료가 있으면 그 값을 사용한다.
+func Prize(g *game.Game, draw *db.Draw) func(rank int) int64 {
+	return func(rank int) int64 {
+		if rank == common.RankFirst && draw.FirstPrize > 0 {
+			return draw.FirstPrize
+		}
+		return config.PrizeAmount(g, rank)
+	}
+}
+
+// Check drawNo 회차 티켓을 모두 당첨 확인하고 요약을 반환. 당첨 번호가 아직 없으면 db.ErrNotFound
+func Check(ctx context.Context, store *db.Store, drawNo int) (*DrawSummary, error) {
+	draw, err := store.GetDraw(ctx, drawNo)
+	if err != nil {
+		return nil, err
+	}
+	tickets, err := store.CheckTickets(ctx, draw, Prize(store.Game(), draw))
+	if err != nil {
+		return nil, fmt.Errorf("회차 %d 티켓 확인 실패: %w", drawNo, err)
+	}
+
+	s := &DrawSummary{DrawNumber: drawNo, Tickets: len(tickets), RankCounts: map[int]int{}, Winners: []db.Ticket{}}
+	for _, t := range tickets {
+		s.RankCounts[t.Check.Rank]++
+		s.Cost += t.Cost
+		s.Prize += t.Check.Prize
+		if t.Check.Rank != common.RankNone {
+			s.Winners = append(s.Winners, t)
+		}
+	}
+	return s, nil
+}
+
+// CheckPending 당첨 번호가 저장된 회차 중 확인 전 티켓이 남은 모든 회차를 확인하고 요약을 반환
+// sync / import로 새 회차가 추가된 뒤 호출한다.
+func CheckPending(ctx context.Context, store *db.Store) ([]DrawSummary, error) {
+	draws, err := store.PendingTicketDraws(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("확인 대기 티켓 회차 조회 실패: %w", err)
+	}
+	summaries := []DrawSummary{}
+	for _, drawNo := range draws {
+		s, err := Check(ctx, store, drawNo)
+		if err != nil {
+			return summaries, err
+		}
+		summaries = append(summaries, *s)
+	}
+	return summaries, nil
+}
@@ -3,6 +3,7 @@ package util
 import (
 	"crypto/rand"
 	"encoding/binary"
+	"hash/fnv"
 	mathrand "math/rand"
 )
 
@@ -11,13 +12,27 @@ var GlobalRand *mathrand.Rand
 
 // SeedCryptoRand 안전한 crypto 기반 시드로 전역 rand 생성
 func SeedCryptoRand() {
+	GlobalRand = NewRand(NewSeed())
+}
+
+// NewSeed crypto/rand로 0이 아닌 시드를 만든다. (0은 설정에서 "시드 없음"을 뜻한다)
+func NewSeed() int64 {
 	var b [8]byte
-	_, err := rand.Read(b[:])
-	if err != nil {
-		panic("crypto/rand 실패: " + err.Error())
+	for {
+		_, err := rand.Read(b[:])
+		if err != nil {
+			panic("crypto/rand 실패: " + err.Error())
+		}
+		// 저장/출력하기 쉽도록 음수가 아닌 값만 쓴다
+		if seed := int64(binary.LittleEndian.Uint64(b[:]) >> 1); seed != 0 {
+			return seed
+		}
 	}
-	seed := int64(binary.LittleEndian.Uint64(b[:]))
-	GlobalRand = mathrand.New(mathrand.NewSource(seed))
+}
+
+// NewRand seed로 초기화한 rand 인스턴스. 같은 시드면 같은 난수열을 만든다.
+func NewRand(seed int64) *mathrand.Rand {
+	return mathrand.New(mathrand.NewSource(seed))
 }
 
 // RandIntn returns a random integer in [0, n)
@@ -53,3 +68,18 @@ func RandChoice(slice []int) int {
 	}
 	return slice[GlobalRand.Intn(len(slice))]
 }
+
+// DeriveSeed base 시드와 이름을 해시해 이름마다 다른 시드를 만든다. (같은 base, 같은 이름이면 같은 시드)
+// 한 실행에서 여러 전략이 같은 난수열을 나눠 쓰지 않도록 할 때 쓴다.
+func DeriveSeed(base int64, name string) int64 {
+	h := fnv.New64a()
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], uint64(base))
+	h.Write(b[:])
+	h.Write([]byte(name))
+	// NewSeed와 같이 0이 아닌 음수 아닌 값
+	if seed := int64(h.Sum64() >> 1); seed != 0 {
+		return seed
+	}
+	return 1
+}
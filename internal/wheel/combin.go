@@ -0,0 +1,101 @@
+// internal/wheel/combin.go
+package wheel
+
+import (
+	"math/bits"
+
+	"lottopredictor/internal/common"
+	"lottopredictor/internal/game"
+)
+
+// 풀 안의 조합은 풀 위치 비트마스크(uint32)로 다룬다.
+// 같은 크기의 마스크를 숫자 순으로 나열하면 colex 순서가 되어 rank로 바로 배열 위치를 구할 수 있다.
+
+// eachMask n개 위치 중 k개를 고른 마스크를 colex 순서로 fn에 전달
+func eachMask(n, k int, fn func(uint32)) {
+	if k == 0 {
+		fn(0)
+		return
+	}
+	if k > n {
+		return
+	}
+	mask := uint32(1)<<k - 1
+	limit := uint32(1) << n
+	for mask < limit {
+		fn(mask)
+		// Gosper's hack: 같은 개수의 1비트를 가진 다음 수
+		c := mask & -mask
+		r := mask + c
+		mask = (((r ^ mask) >> 2) / c) | r
+	}
+}
+
+// masks n개 중 k개 마스크 목록 (colex 순서, 인덱스 = rank)
+func masks(n, k int) []uint32 {
+	list := make([]uint32, 0, common.Binomial(n, k))
+	eachMask(n, k, func(m uint32) { list = append(list, m) })
+	return list
+}
+
+// binomial 풀 크기까지의 조합 수 표 (rank 계산용)
+var binomial = func() [MaxPool + 1][game.MaxMainPicks + 1]int {
+	var t [MaxPool + 1][game.MaxMainPicks + 1]int
+	for n := range t {
+		for k := range t[n] {
+			t[n][k] = int(common.Binomial(n, k))
+		}
+	}
+	return t
+}()
+
+// rank colex 순서에서 마스크의 위치
+func rank(mask uint32) int {
+	r, i := 0, 1
+	for mask != 0 {
+		r += binomial[bits.TrailingZeros32(mask)][i]
+		mask &= mask - 1
+		i++
+	}
+	return r
+}
+
+// positions 마스크의 1비트 위치
+func positions(set uint32) []int {
+	ps := make([]int, 0, bits.OnesCount32(set))
+	for m := set; m != 0; m &= m - 1 {
+		ps = append(ps, bits.TrailingZeros32(m))
+	}
+	return ps
+}
+
+// eachSubset 위치 목록 중 k개를 고른 부분 마스크를 fn에 전달
+func eachSubset(ps []int, k int, fn func(uint32)) {
+	eachMask(len(ps), k, func(sel uint32) {
+		var sub uint32
+		for ; sel != 0; sel &= sel - 1 {
+			sub |= 1 << ps[bits.TrailingZeros32(sel)]
+		}
+		fn(sub)
+	})
+}
+
+// coveredBy k개 세트 t와 g.Hit개 이상 겹치는 g.Match개 목표 조합
+func coveredBy(t uint32, n, k int, g Guarantee, fn func(uint32)) {
+	in, out := positions(t), positions((uint32(1)<<n-1)&^t)
+	for j := g.Hit; j <= g.Match && j <= k; j++ {
+		eachSubset(in, j, func(a uint32) {
+			eachSubset(out, g.Match-j, func(b uint32) { fn(a | b) })
+		})
+	}
+}
+
+// coveringTickets 목표 조합 target과 g.Hit개 이상 겹치는 k개 세트
+func coveringTickets(target uint32, n, k int, g Guarantee, fn func(uint32)) {
+	in, out := positions(target), positions((uint32(1)<<n-1)&^target)
+	for j := g.Hit; j <= g.Match; j++ {
+		eachSubset(in, j, func(a uint32) {
+			eachSubset(out, k-j, func(b uint32) { fn(a | b) })
+		})
+	}
+}
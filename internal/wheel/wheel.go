@@ -0,0 +1,229 @@
+// internal/wheel/wheel.go
+package wheel
+
+import (
+	"fmt"
+	"math/bits"
+	"slices"
+
+	"lottopredictor/internal/common"
+	"lottopredictor/internal/game"
+)
+
+// MaxPool 풀 크기 상한. 18개면 6/45 세트 후보가 C(18,6) = 18,564개
+const MaxPool = 18
+
+// MinPool g 게임 풀 크기 하한 (본 번호 수 + 1)
+func MinPool(g *game.Game) int { return g.Main.Picks + 1 }
+
+// Supports g 게임으로 휠을 만들 수 있는지. 보장은 본 번호만 계산하므로 구매자가 고르는 보너스 풀이 있는 게임은 지원하지 않는다.
+func Supports(g *game.Game) error {
+	if g.Bonus.Picks > 0 {
+		return fmt.Errorf("휠은 보너스 풀이 없는 게임만 지원 (현재 %s): 보장은 본 번호만 계산하고 세트마다 보너스 풀 번호가 필요함", g.Name)
+	}
+	return nil
+}
+
+// Guarantee "풀에 당첨 번호가 Match개 있으면 최소 한 세트가 Hit개 이상 일치"
+type Guarantee struct {
+	Match int `json:"match"`
+	Hit   int `json:"hit"`
+}
+
+func (g Guarantee) String() string {
+	return fmt.Sprintf("풀에 당첨 번호 %d개가 있으면 최소 1세트 %d개 이상 일치", g.Match, g.Hit)
+}
+
+// Level 풀에 들어온 당첨 번호 개수별로 계산으로 확인한 최소 일치 개수
+type Level struct {
+	InPool     int `json:"in_pool"`
+	MinMatched int `json:"min_matched"`
+}
+
+// Wheel 번호 풀로 만든 휠(조합표)
+type Wheel struct {
+	Game      string    `json:"game"`
+	Pool      []int     `json:"pool"`
+	Full      bool      `json:"full"` // 풀의 모든 본 번호 수 개 조합
+	Guarantee Guarantee `json:"guarantee"`
+	Tickets   [][]int   `json:"tickets"`
+
+	game *game.Game
+}
+
+// Cost 전체 세트 구매 비용 (게임 통화 최소 단위)
+func (w *Wheel) Cost() int64 {
+	return int64(len(w.Tickets)) * w.game.TicketPrice
+}
+
+// FormatCost 전체 세트 구매 비용 표시 ("12,000원")
+func (w *Wheel) FormatCost() string {
+	return w.game.FormatMoney(w.Cost())
+}
+
+// Levels 풀에 당첨 번호가 1 ~ 본 번호 수 개 들어왔을 때 보장되는 일치 개수를 모든 경우를 계산해 구한다.
+func (w *Wheel) Levels() []Level {
+	levels := []Level{}
+	for m := 1; m <= w.game.Main.Picks && m <= len(w.Pool); m++ {
+		levels = append(levels, Level{InPool: m, MinMatched: Verify(w.Pool, w.Tickets, m)})
+	}
+	return levels
+}
+
+// PoolHitProbability 실제 추첨에서 본 번호 당첨 번호 중 match개 이상이 풀에 들어올 확률 (초구분포)
+func (w *Wheel) PoolHitProbability(match int) float64 {
+	v, main := len(w.Pool), w.game.Main
+	p := 0.0
+	for j := match; j <= main.Picks; j++ {
+		p += float64(common.Binomial(v, j)*common.Binomial(main.Max-v, main.Picks-j)) / float64(common.Binomial(main.Max, main.Picks))
+	}
+	return p
+}
+
+// FullWheel g 게임 풀의 모든 본 번호 수 개 조합. 당첨 번호가 모두 풀에 있으면 본 번호 전부 일치가 보장된다.
+func FullWheel(g *game.Game, pool []int) (*Wheel, error) {
+	sorted, err := checkPool(g, pool)
+	if err != nil {
+		return nil, err
+	}
+	k := g.Main.Picks
+	w := &Wheel{Game: g.ID, Pool: sorted, Full: true, Guarantee: Guarantee{Match: k, Hit: k}, game: g}
+	eachMask(len(sorted), k, func(mask uint32) {
+		w.Tickets = append(w.Tickets, ticket(sorted, mask))
+	})
+	return w, nil
+}
+
+// Abbreviated g 게임에서 guarantee를 만족하는 축약 휠 (커버링 디자인).
+// 아직 보장되지 않은 Match개 조합을 가장 많이 덮는 세트를 차례로 고르고(동률이면 weights 합이 큰 세트),
+// 마지막에 빼도 보장이 유지되는 세트를 제거한 뒤 전체 경우를 다시 계산해 보장을 확인한다.
+func Abbreviated(g *game.Game, pool []int, guarantee Guarantee, weights map[int]float64) (*Wheel, error) {
+	sorted, err := checkPool(g, pool)
+	if err != nil {
+		return nil, err
+	}
+	k := g.Main.Picks
+	if guarantee.Hit < 1 || guarantee.Hit > guarantee.Match || guarantee.Match > k || guarantee.Match > len(sorted) {
+		return nil, fmt.Errorf("보장 조건이 잘못됨: %d개 중 %d개 일치 (1 <= 일치 <= 풀 당첨 번호 <= %d)", guarantee.Match, guarantee.Hit, min(k, len(sorted)))
+	}
+
+	v := len(sorted)
+	tickets := masks(v, k)
+	score := make([]float64, len(tickets))
+	for i, t := range tickets {
+		for _, n := range ticket(sorted, t) {
+			score[i] += weights[n]
+		}
+	}
+
+	// 세트 하나가 처음 덮는 목표 조합 수는 대칭이라 모두 같다.
+	// 목표 조합이 새로 덮이면 그 조합을 덮던 다른 세트의 이득을 하나씩 줄여, 매번 모든 후보를 다시 세지 않는다.
+	initial := 0
+	coveredBy(tickets[0], v, k, guarantee, func(uint32) { initial++ })
+	gain := make([]int, len(tickets))
+	for i := range gain {
+		gain[i] = initial
+	}
+
+	coverCount := make([]int, common.Binomial(v, guarantee.Match))
+	uncovered := len(coverCount)
+	chosen := []uint32{}
+	for uncovered > 0 {
+		best := 0
+		for i := range tickets {
+			if gain[i] > gain[best] || (gain[i] == gain[best] && score[i] > score[best]) {
+				best = i
+			}
+		}
+		chosen = append(chosen, tickets[best])
+		coveredBy(tickets[best], v, k, guarantee, func(target uint32) {
+			idx := rank(target)
+			coverCount[idx]++
+			if coverCount[idx] > 1 {
+				return
+			}
+			uncovered--
+			coveringTickets(target, v, k, guarantee, func(t uint32) { gain[rank(t)]-- })
+		})
+	}
+
+	// 빼도 모든 목표 조합이 다른 세트로 덮이는 세트 제거 (나중에 고른 것부터)
+	for i := len(chosen) - 1; i >= 0; i-- {
+		redundant := true
+		coveredBy(chosen[i], v, k, guarantee, func(target uint32) {
+			if coverCount[rank(target)] < 2 {
+				redundant = false
+			}
+		})
+		if !redundant {
+			continue
+		}
+		coveredBy(chosen[i], v, k, guarantee, func(target uint32) { coverCount[rank(target)]-- })
+		chosen = slices.Delete(chosen, i, i+1)
+	}
+
+	w := &Wheel{Game: g.ID, Pool: sorted, Guarantee: guarantee, game: g}
+	for _, t := range chosen {
+		w.Tickets = append(w.Tickets, ticket(sorted, t))
+	}
+	if got := Verify(sorted, w.Tickets, guarantee.Match); got < guarantee.Hit {
+		return nil, fmt.Errorf("휠 검증 실패: 풀 당첨 번호 %d개일 때 최소 %d개 일치 (기대 %d개)", guarantee.Match, got, guarantee.Hit)
+	}
+	return w, nil
+}
+
+// Verify 풀에서 당첨 번호 match개가 나오는 모든 경우를 확인해, 어떤 경우에도 tickets 중 한 세트가
+// 최소 몇 개 일치하는지 반환한다.
+func Verify(pool []int, tickets [][]int, match int) int {
+	index := map[int]int{}
+	for i, n := range pool {
+		index[n] = i
+	}
+	ts := make([]uint32, len(tickets))
+	for i, t := range tickets {
+		for _, n := range t {
+			if idx, ok := index[n]; ok {
+				ts[i] |= 1 << idx
+			}
+		}
+	}
+	worst := match
+	eachMask(len(pool), match, func(winning uint32) {
+		best := 0
+		for _, t := range ts {
+			best = max(best, bits.OnesCount32(t&winning))
+		}
+		worst = min(worst, best)
+	})
+	return worst
+}
+
+func checkPool(g *game.Game, pool []int) ([]int, error) {
+	if err := Supports(g); err != nil {
+		return nil, err
+	}
+	sorted := slices.Sorted(slices.Values(pool))
+	if len(sorted) < MinPool(g) || len(sorted) > MaxPool {
+		return nil, fmt.Errorf("번호 풀은 %d ~ %d개여야 함: %d개", MinPool(g), MaxPool, len(sorted))
+	}
+	for i, n := range sorted {
+		if n < 1 || n > g.Main.Max {
+			return nil, fmt.Errorf("번호 %d가 1~%d 범위를 벗어남", n, g.Main.Max)
+		}
+		if i > 0 && n == sorted[i-1] {
+			return nil, fmt.Errorf("번호 %d 중복", n)
+		}
+	}
+	return sorted, nil
+}
+
+// ticket 풀 위치 비트마스크 → 번호 세트
+func ticket(pool []int, mask uint32) []int {
+	nums := []int{}
+	for i, n := range pool {
+		if mask&(1<<i) != 0 {
+			nums = append(nums, n)
+		}
+	}
+	return nums
+}
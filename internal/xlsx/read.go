@@ -0,0 +1,213 @@
+// internal/xlsx/read.go
+// 외부 의존성 없이 xlsx(Office Open XML) 시트를 문자열 표로 읽고 쓰는 최소 구현
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type sharedStrings struct {
+	Items []struct {
+		Text string `xml:"t"`
+		Runs []struct {
+			Text string `xml:"t"`
+		} `xml:"r"`
+	} `xml:"si"`
+}
+
+type worksheet struct {
+	Rows []struct {
+		Cells []struct {
+			Ref    string `xml:"r,attr"`
+			Type   string `xml:"t,attr"`
+			Value  string `xml:"v"`
+			Inline struct {
+				Text string `xml:"t"`
+			} `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// ReadFile xlsx 파일 첫 번째 시트의 모든 행을 문자열 슬라이스로 반환
+func ReadFile(filePath string) ([][]string, error) {
+	zr, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return read(&zr.Reader)
+}
+
+func read(zr *zip.Reader) ([][]string, error) {
+	files := zipFiles(zr)
+	sheets := []string{}
+	for name := range files {
+		if path.Dir(name) == "xl/worksheets" && strings.HasSuffix(name, ".xml") {
+			sheets = append(sheets, name)
+		}
+	}
+	if len(sheets) == 0 {
+		return nil, fmt.Errorf("xlsx 시트 없음")
+	}
+	sheetName := "xl/worksheets/sheet1.xml"
+	if files[sheetName] == nil {
+		sort.Strings(sheets)
+		sheetName = sheets[0]
+	}
+
+	strs, err := readSharedStrings(files)
+	if err != nil {
+		return nil, err
+	}
+	return readSheet(files[sheetName], strs)
+}
+
+// ReadSheets 통합 문서의 모든 시트를 workbook.xml 순서대로 이름과 함께 반환
+func ReadSheets(filePath string) ([]Sheet, error) {
+	zr, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	files := zipFiles(&zr.Reader)
+
+	var wb workbook
+	var rels relationships
+	if files["xl/workbook.xml"] == nil || files["xl/_rels/workbook.xml.rels"] == nil {
+		return nil, fmt.Errorf("xlsx workbook 정보 없음")
+	}
+	if err := decodeXML(files["xl/workbook.xml"], &wb); err != nil {
+		return nil, fmt.Errorf("workbook 파싱 실패: %w", err)
+	}
+	if err := decodeXML(files["xl/_rels/workbook.xml.rels"], &rels); err != nil {
+		return nil, fmt.Errorf("workbook 관계 파싱 실패: %w", err)
+	}
+	targets := map[string]string{}
+	for _, r := range rels.Items {
+		targets[r.ID] = path.Join("xl", r.Target)
+	}
+
+	strs, err := readSharedStrings(files)
+	if err != nil {
+		return nil, err
+	}
+	sheets := []Sheet{}
+	for _, s := range wb.Sheets {
+		f := files[targets[s.RelID]]
+		if f == nil {
+			return nil, fmt.Errorf("시트 %q 파일 없음", s.Name)
+		}
+		rows, err := readSheet(f, strs)
+		if err != nil {
+			return nil, err
+		}
+		sheets = append(sheets, Sheet{Name: s.Name, Rows: rows})
+	}
+	return sheets, nil
+}
+
+type workbook struct {
+	Sheets []struct {
+		Name  string `xml:"name,attr"`
+		RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type relationships struct {
+	Items []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+func zipFiles(zr *zip.Reader) map[string]*zip.File {
+	files := map[string]*zip.File{}
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	return files
+}
+
+func readSharedStrings(files map[string]*zip.File) ([]string, error) {
+	var shared sharedStrings
+	if f := files["xl/sharedStrings.xml"]; f != nil {
+		if err := decodeXML(f, &shared); err != nil {
+			return nil, fmt.Errorf("sharedStrings 파싱 실패: %w", err)
+		}
+	}
+	strs := make([]string, len(shared.Items))
+	for i, item := range shared.Items {
+		if len(item.Runs) == 0 {
+			strs[i] = item.Text
+			continue
+		}
+		var b strings.Builder
+		for _, r := range item.Runs {
+			b.WriteString(r.Text)
+		}
+		strs[i] = b.String()
+	}
+	return strs, nil
+}
+
+func readSheet(f *zip.File, strs []string) ([][]string, error) {
+	var sheet worksheet
+	if err := decodeXML(f, &sheet); err != nil {
+		return nil, fmt.Errorf("%s 파싱 실패: %w", f.Name, err)
+	}
+
+	rows := make([][]string, 0, len(sheet.Rows))
+	for _, r := range sheet.Rows {
+		row := []string{}
+		for i, c := range r.Cells {
+			col := i
+			if c.Ref != "" {
+				col = columnIndex(c.Ref)
+			}
+			for len(row) <= col {
+				row = append(row, "")
+			}
+			switch c.Type {
+			case "s":
+				idx, err := strconv.Atoi(c.Value)
+				if err != nil || idx < 0 || idx >= len(strs) {
+					return nil, fmt.Errorf("%s: 잘못된 공유 문자열 인덱스 %q", c.Ref, c.Value)
+				}
+				row[col] = strs[idx]
+			case "inlineStr":
+				row[col] = c.Inline.Text
+			default:
+				row[col] = c.Value
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func decodeXML(f *zip.File, v any) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	return xml.NewDecoder(rc).Decode(v)
+}
+
+// columnIndex "C12" → 2 (0부터 시작하는 열 번호)
+func columnIndex(ref string) int {
+	col := 0
+	for _, ch := range ref {
+		if ch < 'A' || ch > 'Z' {
+			break
+		}
+		col = col*26 + int(ch-'A'+1)
+	}
+	return col - 1
+}
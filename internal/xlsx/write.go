@@ -0,0 +1,146 @@
+// internal/xlsx/write.go
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Sheet 시트 이름과 행 목록. 숫자로 읽히는 값은 숫자 셀로, 나머지는 문자열 셀로 저장된다.
+type Sheet struct {
+	Name string
+	Rows [][]string
+}
+
+// maxSheetName 엑셀 시트 이름 최대 길이
+const maxSheetName = 31
+
+// Write 시트들을 xlsx 통합 문서로 w에 쓴다. (스타일 없는 최소 구성)
+func Write(w io.Writer, sheets []Sheet) error {
+	if len(sheets) == 0 {
+		return fmt.Errorf("xlsx 시트 없음")
+	}
+	zw := zip.NewWriter(w)
+
+	names := sheetNames(sheets)
+	var workbook, rels, types strings.Builder
+	workbook.WriteString(xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	rels.WriteString(xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	types.WriteString(xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+
+	for i, sheet := range sheets {
+		n := i + 1
+		workbook.WriteString(fmt.Sprintf(`<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escape(names[i]), n, n))
+		rels.WriteString(fmt.Sprintf(`<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, n, n))
+		types.WriteString(fmt.Sprintf(`<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, n))
+		if err := writeFile(zw, fmt.Sprintf("xl/worksheets/sheet%d.xml", n), sheetXML(sheet.Rows)); err != nil {
+			return err
+		}
+	}
+	workbook.WriteString(`</sheets></workbook>`)
+	rels.WriteString(`</Relationships>`)
+	types.WriteString(`</Types>`)
+
+	files := []struct{ name, body string }{
+		{"[Content_Types].xml", types.String()},
+		{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", workbook.String()},
+		{"xl/_rels/workbook.xml.rels", rels.String()},
+	}
+	for _, f := range files {
+		if err := writeFile(zw, f.name, f.body); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func writeFile(zw *zip.Writer, name, body string) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(f, body)
+	return err
+}
+
+func sheetXML(rows [][]string) string {
+	var b strings.Builder
+	b.WriteString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	for r, row := range rows {
+		b.WriteString(fmt.Sprintf(`<row r="%d">`, r+1))
+		for c, v := range row {
+			if v == "" {
+				continue
+			}
+			ref := columnName(c) + strconv.Itoa(r+1)
+			if _, err := strconv.ParseFloat(v, 64); err == nil {
+				b.WriteString(fmt.Sprintf(`<c r="%s"><v>%s</v></c>`, ref, v))
+				continue
+			}
+			b.WriteString(fmt.Sprintf(`<c r="%s" t="inlineStr"><is><t>%s</t></is></c>`, ref, escape(v)))
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+	return b.String()
+}
+
+// sheetNames 엑셀에서 허용하지 않는 문자를 빼고 31자로 자른 뒤 중복 이름에 번호를 붙인다.
+func sheetNames(sheets []Sheet) []string {
+	seen := map[string]bool{}
+	names := make([]string, len(sheets))
+	for i, s := range sheets {
+		name := strings.Map(func(r rune) rune {
+			if strings.ContainsRune(`[]:*?/\`, r) {
+				return -1
+			}
+			return r
+		}, s.Name)
+		if name == "" {
+			name = fmt.Sprintf("Sheet%d", i+1)
+		}
+		base := name
+		name = truncate(base, maxSheetName)
+		for n := 2; seen[name]; n++ {
+			suffix := fmt.Sprintf(" (%d)", n)
+			name = truncate(base, maxSheetName-len([]rune(suffix))) + suffix
+		}
+		seen[name] = true
+		names[i] = name
+	}
+	return names
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) > n {
+		return string(r[:n])
+	}
+	return s
+}
+
+// columnName 0 → "A", 26 → "AA"
+func columnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -1,44 +1,25 @@
 // 로또 예측 프로그램 - 모듈화된 구조로 구성된 메인 파일
+// 서브커맨드 목록은 internal/cli 참고 (lottopredictor help)
 package main
 
 import (
-	"fmt"
+	"errors"
+	"flag"
 	"log"
 	"os"
 
-	"lottopredictor/internal/analyzer"
-	"lottopredictor/internal/config"
-	"lottopredictor/internal/db"
-	"lottopredictor/internal/fetcher"
-	"lottopredictor/internal/output"
-	"lottopredictor/internal/util"
+	"lottopredictor/internal/cli"
 )
 
 func main() {
-	config.LoadConfig("config.json")
-
-	util.SeedCryptoRand() // 안전한 시드 초기화
-
-	os.MkdirAll("database", os.ModePerm)
-	database, err := db.InitDB("database/lotto.db")
-	if err != nil {
-		log.Fatalf("DB 초기화 실패: %v", err)
-	}
-	defer database.Close()
-
-	latest := db.GetLatestDrawNumber(database)
-	for i := latest + 1; ; i++ {
-		result, err := fetcher.FetchDrawData(i)
-		if err != nil {
-			break
-		}
-		db.SaveDrawResult(database, result)
+	err := cli.Run(os.Args[1:])
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return
+	case errors.Is(err, cli.ErrUsage):
+		log.Println(err)
+		os.Exit(2)
+	default:
+		log.Fatalf("%v", err)
 	}
-
-	predictions := analyzer.Analyze(database)
-
-	os.MkdirAll("result", os.ModePerm)
-	output.SaveAsHTML(predictions, fmt.Sprintf("result/lotto_analysis_%d.html", predictions.DrawNumber))
-	output.SaveAsTXT(predictions, fmt.Sprintf("result/lotto_analysis_%d.txt", predictions.DrawNumber))
-
 }
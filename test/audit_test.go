@@ -0,0 +1,76 @@
+package test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"lottopredictor/internal/audit"
+	"lottopredictor/internal/game"
+)
+
+func randomDraws(rnd *rand.Rand, n int) [][]int {
+	draws := make([][]int, n)
+	for i := range draws {
+		for _, p := range rnd.Perm(45)[:6] {
+			draws[i] = append(draws[i], p+1)
+		}
+	}
+	return draws
+}
+
+func TestAuditRandomDraws(t *testing.T) {
+	r := audit.Run(1, randomDraws(rand.New(rand.NewSource(11)), 600), game.Lotto645.Main, audit.DefaultAlpha)
+	if r.Draws != 600 || r.ToDraw != 600 || len(r.Tests) != 5 || len(r.Numbers) != 45 {
+		t.Fatalf("결과 크기 불일치: %+v", r)
+	}
+	for _, tt := range r.Tests {
+		if tt.Skipped || tt.Significant {
+			t.Errorf("균등 난수 이력에서 %s 결과가 이상함: %+v", tt.Name, tt)
+		}
+		if tt.AdjustedP < tt.PValue || tt.PValue < 0 || tt.PValue > 1 {
+			t.Errorf("%s p값 범위 오류: %+v", tt.Name, tt)
+		}
+	}
+	for _, d := range r.Numbers {
+		if d.Significant {
+			t.Errorf("균등 난수 이력에서 %d번이 유의하게 나옴: %+v", d.Number, d)
+		}
+	}
+
+	// 합계 분포는 확률의 합이 1, 최소/최대 합계는 21, 255
+	dist := audit.SumDistribution(game.Lotto645.Main)
+	total := 0.0
+	for _, p := range dist {
+		total += p
+	}
+	if math.Abs(total-1) > 1e-9 || len(dist) != 256 || dist[20] != 0 || dist[21] == 0 {
+		t.Errorf("합계 분포 오류: 합 %v, 길이 %d", total, len(dist))
+	}
+}
+
+func TestAuditBiasedDraws(t *testing.T) {
+	rnd := rand.New(rand.NewSource(5))
+	draws := randomDraws(rnd, 600)
+	// 짝수 번째 회차에는 7번이 반드시 나오도록 바꾼다
+	for i, d := range draws {
+		if i%2 == 0 && d[0] != 7 && d[1] != 7 && d[2] != 7 && d[3] != 7 && d[4] != 7 && d[5] != 7 {
+			d[0] = 7
+		}
+	}
+	r := audit.Run(1, draws, game.Lotto645.Main, audit.DefaultAlpha)
+	if r.Numbers[0].Number != 7 || !r.Numbers[0].Significant || r.Numbers[0].Z <= 0 {
+		t.Errorf("7번 편차를 찾지 못함: %+v", r.Numbers[0])
+	}
+	freq := r.Tests[0]
+	if freq.Name != audit.TestFrequency || !freq.Significant {
+		t.Errorf("빈도 검정이 유의하지 않음: %+v", freq)
+	}
+
+	few := audit.Run(1, draws[:5], game.Lotto645.Main, audit.DefaultAlpha)
+	for _, tt := range few.Tests {
+		if !tt.Skipped || tt.PValue != 1 {
+			t.Errorf("자료 부족 검정이 수행됨: %+v", tt)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"lottopredictor/internal/analyzer"
+	"lottopredictor/internal/backtest"
+	"lottopredictor/internal/common"
+	"lottopredictor/internal/config"
+	"lottopredictor/internal/db"
+)
+
+// spyStrategy 전달받은 이력을 검사하고 항상 1~6을 추천
+type spyStrategy struct {
+	t     *testing.T
+	calls int
+}
+
+func (s *spyStrategy) Name() string               { return "spy" }
+func (s *spyStrategy) Params() map[string]float64 { return map[string]float64{} }
+
+func (s *spyStrategy) Predict(h *analyzer.History, count int) *analyzer.Prediction {
+	s.calls++
+	for drawNo := range h.Draws {
+		if drawNo > h.BaseDraw {
+			s.t.Errorf("기준 회차 %d 예측에 미래 회차 %d 포함", h.BaseDraw, drawNo)
+		}
+	}
+	if len(h.Draws) != h.BaseDraw {
+		s.t.Errorf("기준 회차 %d 이력 개수 %d", h.BaseDraw, len(h.Draws))
+	}
+	sets := [][]int{}
+	for i := 0; i < count; i++ {
+		sets = append(sets, []int{1, 2, 3, 4, 5, 6})
+	}
+	return &analyzer.Prediction{Sets: sets}
+}
+
+func TestBacktestWalkForward(t *testing.T) {
+	dbConn := newSeededDB(t, 60)
+
+	spy := &spyStrategy{t: t}
+	report, err := backtest.Run(context.Background(), dbConn, spy, backtest.Options{From: 41, To: 60, SetsPerDraw: 2})
+	if err != nil {
+		t.Fatalf("백테스트 실패: %v", err)
+	}
+
+	if spy.calls != 20 || report.Draws != 20 || report.Sets != 40 {
+		t.Fatalf("호출 %d, 회차 %d, 세트 %d", spy.calls, report.Draws, report.Sets)
+	}
+
+	hits := 0
+	for _, n := range report.HitCounts {
+		hits += n
+	}
+	ranks := 0
+	for _, n := range report.RankCounts {
+		ranks += n
+	}
+	if hits != report.Sets || ranks != report.Sets {
+		t.Errorf("분포 합계 불일치: 일치 %d, 등수 %d, 세트 %d", hits, ranks, report.Sets)
+	}
+	if report.Cost != int64(report.Sets)*common.TicketPrice {
+		t.Errorf("구매 비용 %d", report.Cost)
+	}
+
+	saved, err := dbConn.BacktestResults(context.Background(), report.RunID)
+	if err != nil || len(saved) != report.Sets {
+		t.Errorf("저장된 결과 %d개, 기대 %d개 (%v)", len(saved), report.Sets, err)
+	}
+	live, err := dbConn.CountRows(context.Background(), "prediction_results")
+	if err != nil || live != 0 {
+		t.Errorf("실시간 예측 테이블에 %d개 저장됨", live)
+	}
+}
+
+func TestRank(t *testing.T) {
+	actual := []int{1, 2, 3, 4, 5, 6}
+	cases := []struct {
+		nums []int
+		rank int
+	}{
+		{[]int{1, 2, 3, 4, 5, 6}, common.RankFirst},
+		{[]int{1, 2, 3, 4, 5, 7}, common.RankSecond},
+		{[]int{1, 2, 3, 4, 5, 8}, common.RankThird},
+		{[]int{1, 2, 3, 4, 9, 10}, common.RankFourth},
+		{[]int{1, 2, 3, 9, 10, 11}, common.RankFifth},
+		{[]int{1, 2, 7, 9, 10, 11}, common.RankNone},
+	}
+	for _, c := range cases {
+		if _, _, rank := common.Rank(c.nums, actual, 7); rank != c.rank {
+			t.Errorf("%v: 등수 %d, 기대 %d", c.nums, rank, c.rank)
+		}
+	}
+}
+
+func TestBacktestStrategySeeds(t *testing.T) {
+	config.LoadConfig("../config.json")
+	ctx := context.Background()
+	dbConn := newSeededDB(t, 40)
+
+	run := func(name string) (*backtest.Report, []db.BacktestResult) {
+		t.Helper()
+		strategy, err := analyzer.NewStrategy(name, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		report, err := backtest.Run(ctx, dbConn, strategy, backtest.Options{From: 31, To: 40, SetsPerDraw: 3, Seed: 7})
+		if err != nil {
+			t.Fatal(err)
+		}
+		results, err := dbConn.BacktestResults(ctx, report.RunID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return report, results
+	}
+
+	// 같은 기준 시드라도 전략마다 다른 시드, 같은 전략이면 같은 시드와 세트
+	uniform, uniformSets := run("uniform")
+	freq, _ := run(analyzer.DefaultStrategy)
+	if uniform.BaseSeed != 7 || freq.BaseSeed != 7 || uniform.Seed == freq.Seed || uniform.Seed == 7 {
+		t.Errorf("전략별 시드 uniform %d, %s %d (기준 %d)", uniform.Seed, analyzer.DefaultStrategy, freq.Seed, uniform.BaseSeed)
+	}
+	again, againSets := run("uniform")
+	if again.Seed != uniform.Seed || !reflect.DeepEqual(againSets, uniformSets) {
+		t.Errorf("같은 기준 시드, 같은 전략인데 결과가 다름: 시드 %d / %d", again.Seed, uniform.Seed)
+	}
+	var baseSeed int64
+	if err := dbConn.DB().QueryRow("SELECT base_seed FROM backtest_runs WHERE id = ?", uniform.RunID).Scan(&baseSeed); err != nil || baseSeed != 7 {
+		t.Errorf("저장된 기준 시드 %d, %v", baseSeed, err)
+	}
+
+	// 기준 시드가 0이면 설정 파일 시드를 쓴다
+	defer func() {
+		config.AppConfig.Seed = 0
+		config.LoadConfig("../config.json")
+	}()
+	config.AppConfig.Seed = 7
+	strategy, _ := analyzer.NewStrategy("uniform", nil)
+	fromConfig, err := backtest.Run(ctx, dbConn, strategy, backtest.Options{From: 31, To: 40, SetsPerDraw: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fromConfig.BaseSeed != 7 || fromConfig.Seed != uniform.Seed {
+		t.Errorf("설정 시드 백테스트 시드 %d (기준 %d), 기대 %d", fromConfig.Seed, fromConfig.BaseSeed, uniform.Seed)
+	}
+}
@@ -0,0 +1,74 @@
+package test
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+
+	"lottopredictor/internal/analyzer"
+	"lottopredictor/internal/bayes"
+	"lottopredictor/internal/config"
+	"lottopredictor/internal/game"
+)
+
+func TestBayesPosterior(t *testing.T) {
+	// 이력이 없으면 각 번호 몫은 Beta(1, 44): 분위수 1 - (1-p)^(1/44)
+	empty := bayes.Posterior(nil, game.Lotto645.Main, bayes.Options{})
+	e := empty.Estimates[0]
+	q := func(p float64) float64 { return (1 - math.Pow(1-p, 1.0/44)) * 600 }
+	if math.Abs(e.Mean-empty.Uniform()) > 1e-9 || math.Abs(e.Lower-q(0.025)) > 1e-6 || math.Abs(e.Upper-q(0.975)) > 1e-6 {
+		t.Errorf("사전 분포 구간 불일치: %+v (기대 %.4f ~ %.4f)", e, q(0.025), q(0.975))
+	}
+
+	rnd := rand.New(rand.NewSource(2))
+	draws := randomDraws(rnd, 500)
+	// 최근 50회차에만 7번을 심는다
+	for _, d := range draws[450:] {
+		if !containsAny(d, 7) {
+			d[0] = 7
+		}
+	}
+	flat := bayes.Posterior(draws, game.Lotto645.Main, bayes.Options{Prior: 2})
+	decayed := bayes.Posterior(draws, game.Lotto645.Main, bayes.Options{Prior: 2, HalfLife: 30})
+	sum := 0.0
+	for _, e := range flat.Estimates {
+		sum += e.Mean
+		if e.Lower > e.Mean || e.Mean > e.Upper || e.PAboveUniform < 0 || e.PAboveUniform > 1 {
+			t.Errorf("%d번 추정 범위 오류: %+v", e.Number, e)
+		}
+	}
+	if math.Abs(sum-600) > 1e-6 {
+		t.Errorf("사후 평균 합은 600%%: %v", sum)
+	}
+	f, d := flat.Estimates[6], decayed.Estimates[6]
+	if d.Mean <= f.Mean || d.PAboveUniform < 0.99 || d.Upper-d.Lower <= f.Upper-f.Lower {
+		t.Errorf("시간 감쇠가 최근 편향을 더 크게, 구간은 더 넓게 봐야 함: 감쇠 없음 %+v, 감쇠 %+v", f, d)
+	}
+	if err := (&bayes.Options{HalfLife: -1}).Validate(); err == nil {
+		t.Error("음수 반감기 허용")
+	}
+}
+
+func TestBayesSnapshot(t *testing.T) {
+	config.LoadConfig("../config.json")
+	defer config.LoadConfig("../config.json")
+	ctx := context.Background()
+	store := newSeededDB(t, 30)
+
+	result, err := analyzer.AnalyzeWithDrawNumber(ctx, store, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved, err := store.GetDrawEstimates(ctx, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved) != 45 || math.Abs(saved[4].Mean-result.Bayes.Estimates[4].Mean) > 1e-3 {
+		t.Errorf("베이즈 추정 저장 불일치: %d개", len(saved))
+	}
+	probs, err := store.GetDrawProbabilities(ctx, 30)
+	if err != nil || len(probs) != 45 {
+		t.Errorf("등장 확률 행이 유지되어야 함: %d개, %v", len(probs), err)
+	}
+}
@@ -0,0 +1,121 @@
+package test
+
+import (
+	"context"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"lottopredictor/internal/analyzer"
+	"lottopredictor/internal/config"
+	"lottopredictor/internal/constraint"
+)
+
+func TestConstraintCheck(t *testing.T) {
+	rules := &constraint.Rules{
+		Sum:                &constraint.Range{Min: 100, Max: 170},
+		Odd:                &constraint.Range{Min: 4, Max: 5},
+		High:               &constraint.Range{Min: 2, Max: 4},
+		MaxConsecutive:     2,
+		MinAC:              7,
+		Include:            []int{7},
+		Exclude:            []int{3},
+		ExcludePastWinners: true,
+	}
+	past := func(nums []int) bool { return slices.Equal(nums, []int{1, 2, 3, 4, 5, 6}) }
+
+	got := []string{}
+	for _, v := range rules.Check([]int{6, 5, 4, 3, 2, 1}, past) {
+		got = append(got, v.Rule)
+	}
+	want := []string{"sum", "odd", "high", "max_consecutive", "min_ac", "include", "exclude", "exclude_past_winners"}
+	if !slices.Equal(got, want) {
+		t.Errorf("위반 규칙 %v, 기대 %v", got, want)
+	}
+	if vs := rules.Check([]int{7, 12, 20, 29, 33, 41}, past); len(vs) != 0 {
+		t.Errorf("조건을 지키는 세트인데 위반: %v", vs)
+	}
+}
+
+func TestConstraintSample(t *testing.T) {
+	rules := &constraint.Rules{}
+	for _, kv := range [][2]string{{"sum", "150-190"}, {"odd", "3"}, {"high", "4-6"}, {"max_consecutive", "1"}, {"min_ac", "7"}, {"include", "2,44"}, {"exclude", "45"}} {
+		if err := rules.Set(kv[0], kv[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := rules.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 낮은 번호에 가중치가 몰려 있어도 합계/고번호 조건을 만족하는 세트를 만들어야 한다
+	weights := map[int]float64{}
+	for n := 1; n <= 45; n++ {
+		weights[n] = 1 / float64(n*n)
+	}
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		set, vs := rules.Sample(weights, rnd.Float64, nil)
+		if len(set) != 6 || len(vs) != 0 || len(rules.Check(set, nil)) != 0 {
+			t.Fatalf("조건 불만족 세트 %v: %v", set, vs)
+		}
+	}
+
+	for _, bad := range []*constraint.Rules{
+		{Include: []int{1, 2, 3, 4, 5, 6}, Sum: &constraint.Range{Min: 200, Max: 270}},
+		{Include: []int{5}, Exclude: []int{5}},
+		{Odd: &constraint.Range{Min: 5, Max: 7}},
+	} {
+		if err := bad.Validate(); err == nil {
+			t.Errorf("모순된 조건인데 통과: %+v", bad)
+		}
+	}
+}
+
+func TestAnalyzeWithConstraints(t *testing.T) {
+	config.LoadConfig("../config.json")
+	defer config.LoadConfig("../config.json")
+	ctx := context.Background()
+	store := newSeededDB(t, 50)
+
+	config.AppConfig.Constraints = constraint.Rules{
+		Sum:                &constraint.Range{Min: 120, Max: 160},
+		Include:            []int{11},
+		ExcludePastWinners: true,
+	}
+	result, err := analyzer.AnalyzeWithDrawNumber(ctx, store, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Violations) != 0 {
+		t.Errorf("조건 위반 보고: %v", result.Violations)
+	}
+	for _, set := range result.SuggestionSets {
+		if !slices.Contains(set, 11) {
+			t.Errorf("포함 번호 11 없음: %v", set)
+		}
+	}
+
+	// 설정 파일 조건이 바뀌어도 저장된 세트는 생성할 때 저장한 조건으로 확인한다
+	config.AppConfig.Constraints.Exclude = []int{11}
+	config.AppConfig.Constraints.Include = nil
+	report, err := analyzer.LoadPredictionReport(ctx, store, 51)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Violations) != 0 {
+		t.Errorf("생성 조건에 없던 위반 보고: %v", report.Violations)
+	}
+
+	// 생성 설정이 기록되지 않은 예전 예측은 현재 설정의 조건으로 확인해 세트별 위반 규칙이 보고된다
+	if _, err := store.DB().Exec("UPDATE prediction_meta SET settings = NULL WHERE draw_number = 51"); err != nil {
+		t.Fatal(err)
+	}
+	report, err = analyzer.LoadPredictionReport(ctx, store, 51)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Violations) != len(result.SuggestionSets) || report.Violations[0][0].Rule != constraint.RuleExclude {
+		t.Errorf("위반 보고 불일치: %v", report.Violations)
+	}
+}
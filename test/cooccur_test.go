@@ -0,0 +1,104 @@
+package test
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+
+	"lottopredictor/internal/analyzer"
+	"lottopredictor/internal/config"
+	"lottopredictor/internal/constraint"
+	"lottopredictor/internal/cooccur"
+	"lottopredictor/internal/game"
+)
+
+func TestCooccurrenceAnalyze(t *testing.T) {
+	rnd := rand.New(rand.NewSource(9))
+	draws := randomDraws(rnd, 660)
+	// 3번과 40번이 1/3 회차에 함께 나오도록 심는다
+	for i, d := range draws {
+		if i%3 == 0 && !containsAny(d, 3, 40) {
+			d[0], d[1] = 3, 40
+		}
+	}
+	a := cooccur.Analyze(draws, game.Lotto645.Main, cooccur.DefaultTop)
+	if len(a.Pairs) != 990 || len(a.TopPairs) != 10 || len(a.BottomTriples) != 10 {
+		t.Fatalf("결과 크기 불일치: %d %d %d", len(a.Pairs), len(a.TopPairs), len(a.BottomTriples))
+	}
+	top := a.TopPairs[0]
+	if top.A != 3 || top.B != 40 || top.Lift < 2 || top.Z < 5 {
+		t.Errorf("심은 번호 쌍을 찾지 못함: %+v", top)
+	}
+	if math.Abs(top.Expected-10) > 1e-9 {
+		t.Errorf("660회차 쌍 기대 횟수는 10: %v", top.Expected)
+	}
+	m := cooccur.NewMatrix(draws, game.Lotto645.Main)
+	if m.Count(3, 40) != top.Count || m.Count(40, 3) != top.Count {
+		t.Errorf("행렬 횟수 불일치: %d", m.Count(3, 40))
+	}
+
+	// 친화도를 켜면 심은 번호 쌍이 함께 뽑히는 비율이 늘어난다
+	weights := map[int]float64{}
+	for n := 1; n <= 45; n++ {
+		weights[n] = 1
+	}
+	rules := &constraint.Rules{Include: []int{3}}
+	together := func(affinity constraint.Affinity) int {
+		r := rand.New(rand.NewSource(1))
+		hits := 0
+		for i := 0; i < 1000; i++ {
+			set, _ := rules.SampleAffinity(weights, affinity, r.Float64, nil)
+			if containsAny(set, 40) {
+				hits++
+			}
+		}
+		return hits
+	}
+	plain := together(nil)
+	boosted := together(func(chosen []int, n int) float64 { return m.Affinity(chosen, n, 2) })
+	if boosted < plain*2 {
+		t.Errorf("친화도 반영 안 됨: 기본 %d, 친화도 %d", plain, boosted)
+	}
+}
+
+func TestCooccurrenceSnapshot(t *testing.T) {
+	config.LoadConfig("../config.json")
+	defer config.LoadConfig("../config.json")
+	ctx := context.Background()
+	store := newSeededDB(t, 40)
+
+	config.AppConfig.PairAffinity = 1
+	result, err := analyzer.AnalyzeWithDrawNumber(ctx, store, 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Cooccurrence == nil || result.Cooccurrence.Draws != 40 {
+		t.Fatalf("동시 출현 분석 없음: %+v", result.Cooccurrence)
+	}
+	pairs, err := store.GetPairCooccurrence(ctx, 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pairs) != 990 || pairs[0].A != 1 || pairs[0].B != 2 || pairs[0].Count != result.Cooccurrence.Pairs[0].Count {
+		t.Errorf("번호 쌍 스냅샷 불일치: %d개, 첫 행 %+v", len(pairs), pairs[0])
+	}
+	triples, err := store.GetTripleCooccurrence(ctx, 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(triples) != 20 || triples[0].Numbers != result.Cooccurrence.TopTriples[0].Numbers {
+		t.Errorf("세 번호 스냅샷 불일치: %d개", len(triples))
+	}
+}
+
+func containsAny(set []int, nums ...int) bool {
+	for _, s := range set {
+		for _, n := range nums {
+			if s == n {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,224 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"lottopredictor/internal/analyzer"
+	"lottopredictor/internal/common"
+	"lottopredictor/internal/config"
+	"lottopredictor/internal/db"
+	"lottopredictor/internal/game"
+	"lottopredictor/internal/importer"
+	"lottopredictor/internal/simulate"
+)
+
+func TestGameOdds(t *testing.T) {
+	// 로또 6/45 등수별 확률은 기존 조합 수 표와 같다
+	g := game.Lotto645
+	for rank := common.RankFirst; rank <= common.RankFifth; rank++ {
+		want := float64(common.RankCombinations[rank]) / common.TotalCombinations
+		if got := g.Odds(rank); math.Abs(got-want) > 1e-15 {
+			t.Errorf("6/45 %d등 확률 %v, 기대 %v", rank, got, want)
+		}
+	}
+
+	for _, c := range []struct {
+		g    *game.Game
+		want float64 // 1등 1/조합 수
+	}{
+		{game.Lotto649, 13983816},
+		{game.EuroMillions, 139838160},
+		{game.Powerball, 292201338},
+	} {
+		if got := 1 / c.g.Odds(1); math.Abs(got-c.want) > 1e-3 {
+			t.Errorf("%s 1등 1/%.0f, 기대 1/%.0f", c.g.ID, got, c.want)
+		}
+		total := 0.0
+		for rank := 1; rank <= c.g.Ranks(); rank++ {
+			total += c.g.Odds(rank)
+		}
+		if total <= 0 || total >= 1 {
+			t.Errorf("%s 당첨 확률 합 %v", c.g.ID, total)
+		}
+	}
+}
+
+func TestGameRank(t *testing.T) {
+	// 로또 6/45는 기존 common.Rank와 같은 등수
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		p := r.Perm(common.MaxLottoNum)
+		winning := []int{p[0] + 1, p[1] + 1, p[2] + 1, p[3] + 1, p[4] + 1, p[5] + 1}
+		bonus := p[6] + 1
+		q := r.Perm(common.MaxLottoNum)
+		set := []int{q[0] + 1, q[1] + 1, q[2] + 1, q[3] + 1, q[4] + 1, q[5] + 1}
+		if i%3 == 0 {
+			set = append(winning[:5:5], bonus) // 2등 경우도 확인
+		}
+		matched, bonusMatched, want := common.Rank(set, winning, bonus)
+		m := game.Lotto645.Rank(set, nil, winning, bonus, nil)
+		if m.Rank != want || m.Main != matched || m.Extra != bonusMatched {
+			t.Fatalf("%v / %v + %d: %+v, 기대 %d등 (%d개, 보너스 %v)", set, winning, bonus, m, want, matched, bonusMatched)
+		}
+	}
+
+	winning := []int{1, 2, 3, 4, 5}
+	for _, c := range []struct {
+		g           *game.Game
+		nums, bonus []int
+		want        int
+	}{
+		{game.Powerball, []int{1, 2, 3, 4, 5}, []int{7}, 1},
+		{game.Powerball, []int{1, 2, 3, 4, 5}, []int{8}, 2},
+		{game.Powerball, []int{10, 20, 30, 40, 50}, []int{7}, 9},
+		{game.Powerball, []int{1, 2, 30, 40, 50}, []int{8}, 0},
+		{game.EuroMillions, []int{1, 2, 30, 40, 50}, []int{7, 9}, 8},
+		{game.EuroMillions, []int{1, 20, 30, 40, 50}, []int{7, 11}, 0},
+		{game.EuroMillions, []int{1, 2, 3, 40, 50}, []int{10, 11}, 10},
+	} {
+		if m := c.g.Rank(c.nums, c.bonus, winning, 0, []int{7, 9}); m.Rank != c.want {
+			t.Errorf("%s %v + %v: %d등, 기대 %d등", c.g.ID, c.nums, c.bonus, m.Rank, c.want)
+		}
+	}
+
+	if err := game.Powerball.CheckTicket([]int{1, 2, 3, 4, 70}, []int{1}); err == nil {
+		t.Error("파워볼 본 번호 범위 오류가 없음")
+	}
+	if err := game.Powerball.CheckTicket([]int{1, 2, 3, 4, 5}, nil); err == nil {
+		t.Error("파워볼 보너스 풀 번호 누락 오류가 없음")
+	}
+}
+
+func TestBindGame(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "powerball.db")
+	store, err := db.OpenStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.BindGame(ctx, "powerball"); err != nil {
+		t.Fatal(err)
+	}
+	store.Close()
+
+	// 기록된 게임은 다시 열 때 그대로 쓰고 다른 게임으로는 열 수 없다
+	store, err = db.OpenStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	if store.Game() != game.Powerball {
+		t.Errorf("다시 연 DB 게임 %s, 기대 powerball", store.Game().ID)
+	}
+	if err := store.BindGame(ctx, ""); err != nil || store.Game() != game.Powerball {
+		t.Errorf("게임 없이 열기: %v, %s", err, store.Game().ID)
+	}
+	if err := store.BindGame(ctx, "lotto645"); err == nil {
+		t.Error("다른 게임으로 열기 오류가 없음")
+	}
+	if err := store.BindGame(ctx, "keno"); err == nil {
+		t.Error("알 수 없는 게임 오류가 없음")
+	}
+
+	// 게임 기록 전에 회차가 저장된 DB는 로또 6/45 DB
+	legacy := newSeededDB(t, 3)
+	if err := legacy.BindGame(ctx, "powerball"); err == nil {
+		t.Error("6/45 회차가 있는 DB를 파워볼로 열기 오류가 없음")
+	}
+	if err := legacy.BindGame(ctx, "lotto645"); err != nil {
+		t.Errorf("6/45 DB를 6/45로 열기 실패: %v", err)
+	}
+}
+
+func TestPowerballPipeline(t *testing.T) {
+	config.LoadConfig("../config.json")
+	ctx := context.Background()
+	store, err := db.OpenStore(filepath.Join(t.TempDir(), "powerball.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	if err := store.BindGame(ctx, "powerball"); err != nil {
+		t.Fatal(err)
+	}
+	g := store.Game()
+
+	// 헤더 없는 CSV: 회차, 날짜, 본 번호 5개, 파워볼
+	r := rand.New(rand.NewSource(3))
+	var csv strings.Builder
+	const draws = 120
+	for d := 1; d <= draws; d++ {
+		p := r.Perm(g.Main.Max)
+		fmt.Fprintf(&csv, "%d,2024-01-%02d,%d,%d,%d,%d,%d,%d\n", d, d%28+1, p[0]+1, p[1]+1, p[2]+1, p[3]+1, p[4]+1, r.Intn(g.Bonus.Max)+1)
+	}
+	path := writeFile(t, "powerball.csv", csv.String())
+	if n, err := importer.Import(ctx, store, path, ""); err != nil || n != draws {
+		t.Fatalf("가져오기 %d개, %v", n, err)
+	}
+	last, err := store.GetDraw(ctx, draws)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(last.Numbers) != 5 || len(last.BonusNumbers) != 1 || last.Bonus != 0 {
+		t.Fatalf("저장된 회차 %+v", last)
+	}
+
+	result, err := analyzer.AnalyzeWithDrawNumber(ctx, store, draws-1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Game != "powerball" || len(result.BonusSets) != len(result.SuggestionSets) {
+		t.Fatalf("예측 결과 게임 %q, 세트 %d개, 보너스 %d개", result.Game, len(result.SuggestionSets), len(result.BonusSets))
+	}
+	for i, set := range result.SuggestionSets {
+		if err := g.CheckTicket(set, result.BonusSets[i]); err != nil {
+			t.Errorf("세트 %d: %v", i+1, err)
+		}
+	}
+	if len(result.Probabilities) != g.Main.Max || result.Bayes.Pool != g.Main {
+		t.Errorf("번호별 확률 %d개, 베이즈 풀 %+v", len(result.Probabilities), result.Bayes.Pool)
+	}
+
+	// 당첨 번호로 평가하면 보너스 풀 일치까지 게임 규칙으로 채점된다
+	if _, err := store.EvaluatePredictions(ctx, last); err != nil {
+		t.Fatal(err)
+	}
+	run, err := store.LatestPredictionRun(ctx, draws)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, set := range run.Sets {
+		m := g.Rank(set.Numbers, set.Bonus, last.Numbers, last.Bonus, last.BonusNumbers)
+		e := set.Evaluation
+		if e == nil || e.Rank != m.Rank || e.Matched != m.Main || e.BonusMatches != m.Bonus {
+			t.Fatalf("세트 %v + %v 평가 %+v, 기대 %+v", set.Numbers, set.Bonus, e, m)
+		}
+	}
+
+	// 6/45가 아닌 게임도 시뮬레이션 등수 빈도가 이론 확률과 맞는다
+	sim, err := simulate.Run([][]int{{3, 14, 25, 36, 69}}, simulate.Options{
+		Game: g, Bonus: [][]int{{26}}, Model: simulate.ModelUniform, Trials: 200000, Workers: 2, Seed: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := float64(sim.Trials)
+	for rank := 6; rank <= g.Ranks(); rank++ {
+		p := g.Odds(rank)
+		if got := float64(sim.RankCounts[rank]); math.Abs(got-n*p) > 5*math.Sqrt(n*p*(1-p)) {
+			t.Errorf("%d등 %v회, 기대 %.0f회", rank, got, n*p)
+		}
+	}
+	if sim.Cost != g.TicketPrice {
+		t.Errorf("구매 비용 %d, 기대 %d", sim.Cost, g.TicketPrice)
+	}
+	if _, err := simulate.Run([][]int{{3, 14, 25, 36, 69}}, simulate.Options{Game: g, Model: simulate.ModelUniform, Trials: 10}); err == nil {
+		t.Error("보너스 풀 번호 누락 오류가 없음")
+	}
+}
@@ -0,0 +1,57 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"lottopredictor/internal/common"
+	"lottopredictor/internal/db"
+	"lottopredictor/internal/fetcher"
+)
+
+// fakeClient 가짜 API 서버에 연결한 로또 6/45 클라이언트
+func fakeClient(t *testing.T, srv *httptest.Server) *fetcher.Client {
+	t.Helper()
+	c, err := fetcher.NewClient(nil, srv.URL+"/common.do", srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// fakeDraw seed로 만든 drawNo 회차 가짜 당첨 번호 (같은 seed면 항상 같은 번호)
+func fakeDraw(r *rand.Rand, drawNo int) *fetcher.DrawData {
+	p := r.Perm(common.MaxLottoNum)
+	return &fetcher.DrawData{
+		ReturnValue: "success",
+		DrwNo:       drawNo,
+		DrwNoDate:   fmt.Sprintf("2002-12-%02d", drawNo%28+1),
+		DrwtNo1:     p[0] + 1, DrwtNo2: p[1] + 1, DrwtNo3: p[2] + 1,
+		DrwtNo4: p[3] + 1, DrwtNo5: p[4] + 1, DrwtNo6: p[5] + 1,
+		BnusNo: p[6] + 1,
+	}
+}
+
+// newSeededDB 임시 디렉터리에 DB를 만들고 1~n 회차 가짜 당첨 번호를 저장
+func newSeededDB(t *testing.T, n int) *db.Store {
+	t.Helper()
+	store, err := db.OpenStore(filepath.Join(t.TempDir(), "lotto.db"))
+	if err != nil {
+		t.Fatalf("DB 초기화 실패: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	r := rand.New(rand.NewSource(1))
+	draws := []db.Draw{}
+	for i := 1; i <= n; i++ {
+		draws = append(draws, db.DrawFromData(fakeDraw(r, i)))
+	}
+	if err := store.SaveDraws(context.Background(), draws); err != nil {
+		t.Fatalf("가짜 회차 저장 실패: %v", err)
+	}
+	return store
+}
@@ -0,0 +1,146 @@
+package test
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"lottopredictor/internal/fetcher"
+	"lottopredictor/internal/game"
+	"lottopredictor/internal/importer"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// writeOfficialXLSX 동행복권 엑셀처럼 병합 헤더 두 줄 + 마지막 7칸이 번호인 시트를 만든다.
+func writeOfficialXLSX(t *testing.T, rows [][]string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "excel.xlsx")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	zw := zip.NewWriter(f)
+
+	shared := []string{}
+	index := map[string]int{}
+	var sheet strings.Builder
+	sheet.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	for r, row := range rows {
+		sheet.WriteString(fmt.Sprintf(`<row r="%d">`, r+1))
+		for c, v := range row {
+			if v == "" {
+				continue
+			}
+			ref := fmt.Sprintf("%c%d", 'A'+c, r+1)
+			if _, err := fmt.Sscan(v, new(int)); err == nil && !strings.Contains(v, ".") {
+				sheet.WriteString(fmt.Sprintf(`<c r="%s"><v>%s</v></c>`, ref, v))
+				continue
+			}
+			if _, ok := index[v]; !ok {
+				index[v] = len(shared)
+				shared = append(shared, v)
+			}
+			sheet.WriteString(fmt.Sprintf(`<c r="%s" t="s"><v>%d</v></c>`, ref, index[v]))
+		}
+		sheet.WriteString(`</row>`)
+	}
+	sheet.WriteString(`</sheetData></worksheet>`)
+
+	var sst strings.Builder
+	sst.WriteString(`<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	for _, s := range shared {
+		sst.WriteString("<si><t>" + s + "</t></si>")
+	}
+	sst.WriteString(`</sst>`)
+
+	for name, body := range map[string]string{
+		"xl/worksheets/sheet1.xml": sheet.String(),
+		"xl/sharedStrings.xml":     sst.String(),
+	} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write([]byte(body))
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestImportFormats(t *testing.T) {
+	csvPath := writeFile(t, "draws.csv", "draw_number,draw_date,n1,n2,n3,n4,n5,n6,bonus\n"+
+		"2,2002-12-14,9,13,21,25,32,42,2\n"+
+		"1,2002-12-07,10,23,29,33,37,40,16\n")
+	jsonPath := writeFile(t, "draws.json", `[
+		{"drwNo":1,"drwNoDate":"2002-12-07","drwtNo1":10,"drwtNo2":23,"drwtNo3":29,"drwtNo4":33,"drwtNo5":37,"drwtNo6":40,"bnusNo":16},
+		{"drwNo":2,"drwNoDate":"2002-12-14","drwtNo1":9,"drwtNo2":13,"drwtNo3":21,"drwtNo4":25,"drwtNo5":32,"drwtNo6":42,"bnusNo":2}
+	]`)
+	xlsxPath := writeOfficialXLSX(t, [][]string{
+		{"년도", "회차", "추첨일", "1등", "", "당첨번호"},
+		{"", "", "", "당첨자수", "당첨금액", "1", "2", "3", "4", "5", "6", "보너스"},
+		{"2002", "2", "2002.12.14", "0", "0", "9", "13", "21", "25", "32", "42", "2"},
+		{"", "1", "2002.12.07", "0", "0", "10", "23", "29", "33", "37", "40", "16"},
+	})
+
+	for _, path := range []string{csvPath, jsonPath, xlsxPath} {
+		t.Run(filepath.Ext(path), func(t *testing.T) {
+			dbConn := newSeededDB(t, 0)
+			ctx := context.Background()
+			n, err := importer.Import(ctx, dbConn, path, "")
+			if err != nil {
+				t.Fatalf("가져오기 실패: %v", err)
+			}
+			if latest, _ := dbConn.LatestDrawNumber(ctx); n != 2 || latest != 2 {
+				t.Fatalf("저장 %d개, 최신 회차 %d", n, latest)
+			}
+			d, err := dbConn.GetDraw(ctx, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d.Date != "2002-12-14" || d.Numbers[0] != 9 || d.Numbers[5] != 42 || d.Bonus != 2 {
+				t.Errorf("회차 2 데이터 불일치: %+v", d)
+			}
+		})
+	}
+}
+
+func TestImportValidation(t *testing.T) {
+	draw := func(no int, nums ...int) *fetcher.DrawData {
+		return &fetcher.DrawData{DrwNo: no, DrwtNo1: nums[0], DrwtNo2: nums[1], DrwtNo3: nums[2],
+			DrwtNo4: nums[3], DrwtNo5: nums[4], DrwtNo6: nums[5], BnusNo: nums[6]}
+	}
+	cases := map[string][]*fetcher.DrawData{
+		"범위 밖":       {draw(1, 0, 2, 3, 4, 5, 6, 7)},
+		"번호 중복":      {draw(1, 1, 1, 3, 4, 5, 6, 7)},
+		"보너스 중복":     {draw(1, 1, 2, 3, 4, 5, 6, 6)},
+		"회차 누락":      {draw(1, 1, 2, 3, 4, 5, 6, 7), draw(3, 1, 2, 3, 4, 5, 6, 7)},
+		"회차 중복":      {draw(1, 1, 2, 3, 4, 5, 6, 7), draw(1, 1, 2, 3, 4, 5, 6, 7)},
+		"DB 이후 빈 회차": {draw(5, 1, 2, 3, 4, 5, 6, 7)},
+	}
+	for name, draws := range cases {
+		var verr *importer.ValidationError
+		if err := importer.Validate(game.Lotto645, draws, 0); !errors.As(err, &verr) {
+			t.Errorf("%s: 검증 오류 기대, 결과 %v", name, err)
+		}
+	}
+
+	if err := importer.Validate(game.Lotto645, []*fetcher.DrawData{draw(4, 1, 2, 3, 4, 5, 6, 7), draw(3, 8, 9, 10, 11, 12, 13, 14)}, 3); err != nil {
+		t.Errorf("정상 데이터 검증 실패: %v", err)
+	}
+}
@@ -0,0 +1,133 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"lottopredictor/internal/analyzer"
+	"lottopredictor/internal/backtest"
+	"lottopredictor/internal/config"
+)
+
+func TestMarkovStrategy(t *testing.T) {
+	config.LoadConfig("../config.json")
+	defer config.LoadConfig("../config.json")
+	rnd := rand.New(rand.NewSource(4))
+	markov, err := analyzer.NewStrategy("markov", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 다음 회차는 직전 회차 번호 + 1 중 4개와 무작위 번호 2개
+	draws := map[int][]int{1: {1, 2, 3, 4, 5, 6}}
+	for d := 2; d <= 400; d++ {
+		in := map[int]bool{}
+		next := []int{}
+		for _, n := range draws[d-1][:4] {
+			m := n%45 + 1
+			in[m] = true
+			next = append(next, m)
+		}
+		for len(next) < 6 {
+			if m := rnd.Intn(45) + 1; !in[m] {
+				in[m] = true
+				next = append(next, m)
+			}
+		}
+		rnd.Shuffle(len(next), func(i, j int) { next[i], next[j] = next[j], next[i] })
+		draws[d] = next
+	}
+	p := markov.Predict(&analyzer.History{BaseDraw: 400, Draws: draws}, 5)
+	if got := p.Params["selected_order"]; got != 1 {
+		t.Errorf("전이 의존이 있는 이력에서 차수 1을 골라야 함: %v", got)
+	}
+	// 번호 6개 행의 평균이므로 직전 번호 + 1의 확률은 기본 출현율(13.3%)보다 높다
+	for _, n := range draws[400] {
+		next := n%45 + 1
+		if p.Probabilities[next] < 16 {
+			t.Errorf("%d번 다음 %d번 확률이 낮음: %.2f%%", n, next, p.Probabilities[next])
+		}
+	}
+	if len(p.Sets) != 5 {
+		t.Errorf("세트 수 불일치: %d", len(p.Sets))
+	}
+
+	// 독립 추첨 이력에서는 전이 모델이 우도를 높이지 못해 차수 0(전체 출현율)을 고른다
+	random := map[int][]int{}
+	for i, d := range randomDraws(rnd, 400) {
+		random[i+1] = d
+	}
+	p = markov.Predict(&analyzer.History{BaseDraw: 400, Draws: random}, 1)
+	if got := p.Params["selected_order"]; got != 0 {
+		t.Errorf("독립 추첨 이력에서 차수 %v를 고름", got)
+	}
+	// 전략 파라미터는 예측 이력과 관계없이 설정만 담는다
+	if _, ok := markov.Params()["selected_order"]; ok {
+		t.Errorf("전략 파라미터에 예측별 값이 섞임: %v", markov.Params())
+	}
+}
+
+func TestMarkovSnapshot(t *testing.T) {
+	config.LoadConfig("../config.json")
+	defer config.LoadConfig("../config.json")
+	ctx := context.Background()
+	store := newSeededDB(t, 60)
+
+	config.AppConfig.Strategy = "markov"
+	config.AppConfig.StrategyParams = map[string]float64{"order": 2}
+	if _, err := analyzer.AnalyzeWithDrawNumber(ctx, store, 60); err != nil {
+		t.Fatal(err)
+	}
+	probs, err := store.GetStrategyProbabilities(ctx, 60, "markov")
+	if err != nil {
+		t.Fatal(err)
+	}
+	total := 0.0
+	for _, p := range probs {
+		total += p
+	}
+	if len(probs) != 45 || total < 550 || total > 650 {
+		t.Errorf("전략 확률 스냅샷 이상: %d개, 합 %.1f%%", len(probs), total)
+	}
+	run, err := store.LatestPredictionRun(ctx, 61)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.Strategy != "markov" || run.StrategyParams != `{"max_order":3,"order":2,"smoothing":10,"validation":100}` {
+		t.Errorf("전략 파라미터 저장 불일치: %s %s", run.Strategy, run.StrategyParams)
+	}
+	if run.Params != `{"selected_order":2}` {
+		t.Errorf("예측별 전략 값 저장 불일치: %q", run.Params)
+	}
+
+	// 백테스트도 회차마다 고른 차수를 결과와 함께 남긴다
+	markov, err := analyzer.NewStrategy("markov", map[string]float64{"validation": 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := backtest.Run(ctx, store, markov, backtest.Options{From: 51, To: 60, SetsPerDraw: 1, Seed: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := markov.Params()["selected_order"]; ok || report.StrategyParams != analyzer.EncodeParams(markov) {
+		t.Errorf("백테스트 전략 파라미터 %s", report.StrategyParams)
+	}
+	results, err := store.BacktestResults(ctx, report.RunID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 10 {
+		t.Fatalf("백테스트 결과 %d개", len(results))
+	}
+	for _, r := range results {
+		var params map[string]float64
+		if err := json.Unmarshal([]byte(r.Params), &params); err != nil {
+			t.Fatalf("회차 %d 예측별 전략 값 %q: %v", r.DrawNumber, r.Params, err)
+		}
+		if o, ok := params["selected_order"]; !ok || o < 0 || o > 3 {
+			t.Errorf("회차 %d 고른 차수 %v", r.DrawNumber, params)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"lottopredictor/internal/db"
+)
+
+// 스키마 버전 관리 이전(최초 배포) 형태의 DB가 그대로 업그레이드되는지 확인
+func TestMigrateLegacyDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "legacy.db")
+	legacy, err := db.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, stmt := range []string{
+		`CREATE TABLE prediction_meta (draw_number INTEGER, idx INTEGER, created_at TEXT, PRIMARY KEY (draw_number, idx))`,
+		`CREATE TABLE lotto_results (draw_number INTEGER PRIMARY KEY, draw_date TEXT, n1 INTEGER, n2 INTEGER, n3 INTEGER, n4 INTEGER, n5 INTEGER, n6 INTEGER, bonus INTEGER)`,
+		`CREATE TABLE draw_probabilities (draw_number INTEGER, number INTEGER, probability REAL)`,
+		`CREATE TABLE reappearance_probabilities (draw_number INTEGER, number INTEGER, probability REAL)`,
+		`INSERT INTO lotto_results VALUES (1, '2002-12-07', 10, 23, 29, 33, 37, 40, 16)`,
+		`INSERT INTO prediction_meta VALUES (2, 1, datetime('now'))`,
+		`INSERT INTO reappearance_probabilities VALUES (1, 7, '1.000'), (1, 7, '2.500')`,
+	} {
+		if _, err := legacy.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+	legacy.Close()
+
+	dbConn, err := db.InitDB(path)
+	if err != nil {
+		t.Fatalf("마이그레이션 실패: %v", err)
+	}
+	defer dbConn.Close()
+
+	if v, _ := db.SchemaVersion(dbConn); v != db.LatestSchemaVersion() {
+		t.Fatalf("스키마 버전 %d, 기대 %d", v, db.LatestSchemaVersion())
+	}
+	store := db.NewStore(dbConn)
+	if d, err := store.GetDraw(context.Background(), 1); err != nil || d.Numbers[5] != 40 {
+		t.Errorf("기존 회차 유실: %v %+v", err, d)
+	}
+	run := &db.PredictionRun{DrawNumber: 2, Strategy: "frequency_gap", StrategyParams: "{}"}
+	if _, err := store.SavePredictionRun(context.Background(), run); err != nil {
+		t.Errorf("전략 컬럼 없음: %v", err)
+	}
+
+	var n int
+	var prob float64
+	var typ string
+	dbConn.QueryRow("SELECT COUNT(1), MAX(probability), typeof(MAX(probability)) FROM reappearance_probabilities").Scan(&n, &prob, &typ)
+	if n != 1 || prob != 2.5 || typ != "real" {
+		t.Errorf("확률 테이블 정리 실패: 행 %d, 값 %v (%s)", n, prob, typ)
+	}
+
+	// 두 번째 실행은 아무것도 하지 않음
+	if applied, err := db.Migrate(dbConn); err != nil || len(applied) != 0 {
+		t.Errorf("재실행 시 적용 %d건, 오류 %v", len(applied), err)
+	}
+}
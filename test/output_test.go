@@ -0,0 +1,109 @@
+package test
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"lottopredictor/internal/analyzer"
+	"lottopredictor/internal/output"
+	"lottopredictor/internal/xlsx"
+)
+
+func sampleResult() *analyzer.PredictionResult {
+	probs, gaps := map[int]float64{}, map[int]int{}
+	for n := 1; n <= 45; n++ {
+		probs[n] = float64(n) / 10
+		gaps[n] = n % 7
+	}
+	return &analyzer.PredictionResult{
+		DrawNumber:     31,
+		Strategy:       "uniform",
+		Probabilities:  probs,
+		Gaps:           gaps,
+		TopFrequent:    []int{45, 44, 43},
+		LeastFrequent:  []int{1, 2, 3},
+		RecentMissing:  []int{6, 13},
+		FreqInLast10:   []int{40},
+		SuggestionSets: [][]int{{1, 2, 3, 4, 5, 6}, {10, 11, 12, 13, 14, 15}},
+		Percentage:     []float64{50, 0},
+		Ranks:          []int{5, 0},
+	}
+}
+
+func TestOutputFormats(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "out")
+	paths, err := output.WriteAll(sampleResult(), dir, "report_{draw}_{strategy}", output.Formats())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != len(output.Formats()) {
+		t.Fatalf("저장 파일 %d개, 기대 %d개: %v", len(paths), len(output.Formats()), paths)
+	}
+	base := filepath.Join(dir, "report_31_uniform")
+	read := func(ext string) string {
+		b, err := os.ReadFile(base + "." + ext)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+
+	var report output.Report
+	if err := json.Unmarshal([]byte(read("json")), &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.DrawNumber != 31 || len(report.Numbers) != 45 || len(report.Sets) != 2 || *report.Sets[0].Rank != 5 || report.TopProbable[0] != 45 {
+		t.Errorf("JSON 결과 불일치: %+v", report)
+	}
+
+	rows, err := csv.NewReader(strings.NewReader(read("csv"))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 || rows[0][3] != "n1" || rows[2][3] != "10" || rows[1][10] != "5" {
+		t.Errorf("CSV 결과 불일치: %v", rows)
+	}
+
+	for _, ext := range []string{"txt", "html", "md"} {
+		s := read(ext)
+		for _, want := range []string{"추천 번호 세트", "10, 11, 12, 13, 14, 15", "번호별 통계"} {
+			if !strings.Contains(s, want) {
+				t.Errorf("%s에 %q 없음", ext, want)
+			}
+		}
+	}
+	if !strings.Contains(read("html"), "<svg") {
+		t.Error("HTML에 차트 없음")
+	}
+
+	sheets, err := xlsx.ReadSheets(base + ".xlsx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sheets) < 3 || sheets[0].Name != "요약" || sheets[2].Name != "추천 번호 세트" || sheets[2].Rows[1][1] != "1, 2, 3, 4, 5, 6" {
+		t.Errorf("XLSX 결과 불일치: %+v", sheets)
+	}
+}
+
+func TestOutputErrors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := output.WriteAll(sampleResult(), dir, "", []string{"txt", "pdf"}); err == nil {
+		t.Error("알 수 없는 형식인데 성공")
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("형식 오류인데 파일 저장됨: %v", entries)
+	}
+
+	// 디렉터리 자리에 파일이 있으면 쓰기 오류를 반환해야 한다
+	blocked := filepath.Join(dir, "blocked")
+	if err := os.WriteFile(blocked, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := output.Save(sampleResult(), filepath.Join(blocked, "a.txt"), "txt"); err == nil {
+		t.Error("쓰기 실패인데 오류 없음")
+	}
+}
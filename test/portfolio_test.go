@@ -0,0 +1,158 @@
+package test
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+
+	"lottopredictor/internal/analyzer"
+	"lottopredictor/internal/common"
+	"lottopredictor/internal/config"
+	"lottopredictor/internal/constraint"
+	"lottopredictor/internal/game"
+	"lottopredictor/internal/portfolio"
+)
+
+func TestPortfolioEvaluate(t *testing.T) {
+	s := portfolio.Evaluate(game.Lotto645, [][]int{{1, 2, 3, 4, 5, 6}})
+	var want int64
+	for rank := common.RankFirst; rank <= common.RankFifth; rank++ {
+		want += common.RankCombinations[rank]
+	}
+	if s.HitProbability != float64(want)/common.TotalCombinations || s.MatchProbabilities[6] != 1.0/common.TotalCombinations {
+		t.Errorf("세트 1개 확률 불일치: %+v", s)
+	}
+	if s.HitMatch != 3 {
+		t.Errorf("적중 기준 %d개, 기대 3개", s.HitMatch)
+	}
+	if s.Numbers != 6 || s.Pairs != 15 {
+		t.Errorf("번호/쌍 수 불일치: %d %d", s.Numbers, s.Pairs)
+	}
+}
+
+func TestPortfolioGame(t *testing.T) {
+	// 6/49는 최저 등수(7등)가 2개 일치이고, 확률은 C(49,6)가지 추첨 결과로 계산한다.
+	s := portfolio.Evaluate(game.Lotto649, [][]int{{1, 2, 3, 4, 5, 49}})
+	total := float64(common.Binomial(49, 6))
+	var want int64
+	for m := 2; m <= 6; m++ {
+		want += common.Binomial(6, m) * common.Binomial(43, 6-m)
+	}
+	if s.HitMatch != 2 || s.HitProbability != float64(want)/total || s.MatchProbabilities[6] != 1/total {
+		t.Errorf("6/49 세트 1개 확률 불일치: %+v", s)
+	}
+	if _, ok := s.MatchProbabilities[1]; ok {
+		t.Errorf("적중 기준 아래 일치 개수 확률이 있음: %v", s.MatchProbabilities)
+	}
+	if math.Abs(s.IndependentBound-s.HitProbability) > 1e-12 {
+		t.Errorf("세트 1개 상한 %g, 확률 %g", s.IndependentBound, s.HitProbability)
+	}
+
+	// 구매자가 보너스 풀 번호도 고르는 게임은 적중 확률을 계산할 수 없어 거부한다.
+	for _, g := range []*game.Game{game.Powerball, game.EuroMillions} {
+		if err := portfolio.Supports(g); err == nil {
+			t.Errorf("%s 포트폴리오를 지원한다고 함", g.ID)
+		}
+		opts := &portfolio.Options{Objective: portfolio.ObjectiveCoverage}
+		if _, _, err := portfolio.Optimize(g, [][]int{{1, 2, 3, 4, 5}}, 1, nil, opts, rand.Float64); err == nil {
+			t.Errorf("%s 최적화가 성공", g.ID)
+		}
+	}
+}
+
+func TestPortfolioOptimize(t *testing.T) {
+	rnd := rand.New(rand.NewSource(3))
+	scores := map[int]float64{}
+	for n := 1; n <= 45; n++ {
+		scores[n] = 1
+	}
+	// 번호 1~12에 몰린 후보가 대부분이라 앞에서부터 고르면 많이 겹친다
+	cands := [][]int{}
+	for i := 0; i < 60; i++ {
+		pool := 12
+		if i%4 == 3 {
+			pool = 45
+		}
+		perm := rnd.Perm(pool)[:6]
+		set := make([]int, 6)
+		for j, p := range perm {
+			set[j] = p + 1
+		}
+		cands = append(cands, set)
+	}
+	naive := portfolio.Evaluate(game.Lotto645, cands[:5])
+
+	for _, objective := range []string{portfolio.ObjectiveHit, portfolio.ObjectiveCoverage} {
+		opts := &portfolio.Options{Objective: objective, Samples: 5000}
+		sets, summary, err := portfolio.Optimize(game.Lotto645, cands, 5, scores, opts, rnd.Float64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// 같은 후보는 한 번만 센다
+		if len(sets) != 5 || summary.Candidates < 50 || summary.Candidates > 60 {
+			t.Fatalf("%s: 세트 %d개, 후보 %d개", objective, len(sets), summary.Candidates)
+		}
+		if summary.HitProbability <= naive.HitProbability || summary.Pairs <= naive.Pairs {
+			t.Errorf("%s: 최적화 결과가 앞 5개보다 나쁨 (확률 %.4f <= %.4f, 쌍 %d <= %d)",
+				objective, summary.HitProbability, naive.HitProbability, summary.Pairs, naive.Pairs)
+		}
+		if summary.HitProbability > summary.IndependentBound {
+			t.Errorf("%s: 확률 %.4f가 상한 %.4f보다 큼", objective, summary.HitProbability, summary.IndependentBound)
+		}
+	}
+
+	// 서로 다른 후보가 모자라면 적게 고르지 않고 오류
+	few := [][]int{{1, 2, 3, 4, 5, 6}, {6, 5, 4, 3, 2, 1}, {1, 2, 3, 4, 5, 7}}
+	if _, _, err := portfolio.Optimize(game.Lotto645, few, 3, scores, &portfolio.Options{Objective: portfolio.ObjectiveCoverage}, rnd.Float64); err == nil {
+		t.Error("후보 부족 오류가 없음")
+	}
+	if portfolio.Distinct(few) != 2 {
+		t.Errorf("서로 다른 후보 %d개, 기대 2개", portfolio.Distinct(few))
+	}
+
+	if err := (&portfolio.Options{Objective: "max"}).Validate(); err == nil {
+		t.Error("알 수 없는 목표인데 통과")
+	}
+}
+
+func TestPortfolioNarrowRules(t *testing.T) {
+	config.LoadConfig("../config.json")
+	defer func() {
+		// 설정 파일에 없는 항목은 LoadConfig로 되돌아가지 않는다
+		config.AppConfig.Constraints = constraint.Rules{}
+		config.AppConfig.Portfolio = portfolio.Options{}
+		config.AppConfig.Seed = 0
+		config.LoadConfig("../config.json")
+	}()
+	ctx := context.Background()
+	store := newSeededDB(t, 50)
+
+	// 1~5 포함, 12~45 제외면 가능한 세트는 6~11 중 하나를 더한 6개뿐
+	exclude := []int{}
+	for n := 12; n <= common.MaxLottoNum; n++ {
+		exclude = append(exclude, n)
+	}
+	config.AppConfig.Constraints = constraint.Rules{Include: []int{1, 2, 3, 4, 5}, Exclude: exclude}
+	config.AppConfig.Portfolio = portfolio.Options{Objective: portfolio.ObjectiveCoverage, Candidates: 1}
+	config.AppConfig.Seed = 5
+	config.AppConfig.Strategy = "uniform"
+	config.AppConfig.StrategyParams = nil
+	config.AppConfig.PairAffinity = 0
+
+	// 후보 5개로는 같은 세트가 섞여도 후보를 더 만들어 5세트를 채운다
+	config.AppConfig.SuggestionSetCount = 5
+	result, err := analyzer.AnalyzeWithDrawNumber(ctx, store, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.SuggestionSets) != 5 || portfolio.Distinct(result.SuggestionSets) != 5 {
+		t.Errorf("추천 세트 %v", result.SuggestionSets)
+	}
+
+	// 가능한 세트보다 많이 요청하면 적게 돌려주지 않고 오류
+	config.AppConfig.SuggestionSetCount = 7
+	if _, err := analyzer.AnalyzeWithDrawNumber(ctx, store, 50); err == nil {
+		t.Error("세트 부족 오류가 없음")
+	}
+}
@@ -1,90 +1,121 @@
 package test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"math/rand"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"lottopredictor/internal/analyzer"
+	"lottopredictor/internal/common"
 	"lottopredictor/internal/config"
-	"lottopredictor/internal/db"
+	"lottopredictor/internal/evaluator"
 	"lottopredictor/internal/fetcher"
 	"lottopredictor/internal/output"
+	"lottopredictor/internal/syncer"
 )
 
 /*
  * desc: AnalyzeWithDrawNumber()를 이용해 특정 회차 기준으로 3회 예측 수행
+ *       당첨 번호는 가짜 API 서버(fetcher.NewFakeServer)에서 받아 네트워크 없이 실행된다.
  * usage: drawNo 변수값 수정해서 테스트 진행
  */
 func TestPredictionAndEvaluation(t *testing.T) {
 	// 설정 로드
 	config.LoadConfig("../config.json")
 
-	// DB 연결 (경로 필요에 따라 조정)
-	dbConn, err := db.InitDB("../database/lotto.db")
-	if err != nil {
-		t.Fatalf("DB 연결 실패: %v", err)
-	} else {
-		log.Printf("[DB] connection success\n")
-	}
-
 	drawNo := 1166 // 테스트 기준 회차
 
+	// 임시 DB에 1 ~ drawNo 회차 저장
+	dbConn := newSeededDB(t, drawNo)
+	log.Printf("[DB] connection success\n")
+
+	// drawNo+1 회차 당첨 번호를 응답하는 가짜 API
+	srv, _ := fetcher.NewFakeServer([]*fetcher.DrawData{fakeDraw(rand.New(rand.NewSource(2)), drawNo+1)})
+	defer srv.Close()
+	source := fakeClient(t, srv)
+
 	// 3회 예측만 수행
+	ctx := context.Background()
 	for i := 0; i < 3; i++ {
-		analyzer.AnalyzeWithDrawNumber(dbConn, drawNo)
+		if _, err := analyzer.AnalyzeWithDrawNumber(ctx, dbConn, drawNo); err != nil {
+			t.Fatalf("예측 실패: %v", err)
+		}
 		log.Printf("[DB] AnalyzeWithDrawNumber(%d) success\n", drawNo)
 	}
 
-	// 다음 회차 실제 번호 불러오기
-	actualData, err := fetcher.FetchDrawResult(drawNo + 1)
+	// 다음 회차 실제 번호를 동기화하고 평가 전 예측을 자동 평가
+	s := syncer.New(dbConn, source, syncer.Options{InitialBackoff: time.Millisecond, RequestsPerSecond: 1000})
+	if _, err := s.Run(ctx); err != nil {
+		t.Fatalf("동기화 실패: %v", err)
+	}
+	summaries, err := evaluator.EvaluatePending(ctx, dbConn)
 	if err != nil {
-		t.Fatalf("당첨 번호 불러오기 실패: %v", err)
+		t.Fatalf("예측 결과 평가 실패: %v", err)
 	}
-
-	actual := []int{
-		actualData.DrwtNo1, actualData.DrwtNo2, actualData.DrwtNo3,
-		actualData.DrwtNo4, actualData.DrwtNo5, actualData.DrwtNo6,
+	if len(summaries) != 3 {
+		t.Fatalf("평가된 예측 %d건, 기대 3건", len(summaries))
+	}
+	for _, sum := range summaries {
+		if sum.DrawNumber != drawNo+1 || sum.Evaluated != config.AppConfig.SuggestionSetCount {
+			t.Errorf("평가 요약 불일치: %+v", sum)
+		}
+	}
+	if pending, _ := dbConn.PendingEvaluationDraws(ctx); len(pending) != 0 {
+		t.Errorf("평가 대기 회차 남음: %v", pending)
 	}
-	bonus := actualData.BnusNo
 
-	err = db.UpdatePredictionEvaluations(dbConn, actualData.DrwNo, actual, bonus)
+	actual, err := dbConn.GetDraw(ctx, drawNo+1)
 	if err != nil {
-		t.Fatalf("예측 결과 평가 실패: %v", err)
+		t.Fatal(err)
+	}
+	run, err := dbConn.LatestPredictionRun(ctx, drawNo+1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, set := range run.Sets {
+		matched, bonusMatched, rank := common.Rank(set.Numbers, actual.Numbers, actual.Bonus)
+		e := set.Evaluation
+		if e == nil || e.Matched != matched || e.BonusMatched != bonusMatched || e.Rank != rank || e.EvaluatedAt == "" {
+			t.Errorf("세트 %v 평가 불일치: %+v", set.Numbers, e)
+		}
 	}
 
 	// 분석 결과 출력용: 마지막 예측 결과를 HTML + TXT로 저장
-	result := analyzer.LoadLastPredictionResult(dbConn, drawNo+1)
+	// 추천 결과 평가 정보(일치율, 등수)도 함께 불러온다
+	result, err := analyzer.LoadLastPredictionResult(ctx, dbConn, drawNo+1)
+	if err != nil {
+		t.Fatalf("예측 결과 불러오기 실패: %v", err)
+	}
+	if len(result.SuggestionSets) != config.AppConfig.SuggestionSetCount {
+		t.Fatalf("추천 세트 %d개, 기대 %d개", len(result.SuggestionSets), config.AppConfig.SuggestionSetCount)
+	}
 
-	// 추천 결과 평가 정보도 가져와서 세팅
-	rows, err := dbConn.Query(`
-	SELECT percentage, rank 
-	FROM prediction_results
-	WHERE draw_number = ? ORDER BY meta_idx DESC, set_index
-	LIMIT ?`, drawNo, len(result.SuggestionSets))
-	if err == nil {
-		var perc float64
-		var rank int
-		for rows.Next() {
-			rows.Scan(&perc, &rank)
-			result.Percentage = append(result.Percentage, perc)
-			result.Ranks = append(result.Ranks, rank)
+	// 결과 파일 저장
+	outputPath := filepath.Join(t.TempDir(), fmt.Sprintf("lotto_analysis_%d", result.DrawNumber))
+	for _, format := range []string{"txt", "html"} {
+		if err := output.Save(result, outputPath+"."+format, format); err != nil {
+			t.Errorf("%s 저장 실패: %v", format, err)
 		}
-		rows.Close()
 	}
+}
 
-	// 결과 파일 저장
-	outputPath := "result/lotto_analysis_%d"
-	txtPath := fmt.Sprintf(outputPath+".txt", result.DrawNumber)
-	htmlPath := fmt.Sprintf(outputPath+".html", result.DrawNumber)
+func TestFakeServerNotFound(t *testing.T) {
+	srv, fake := fetcher.NewFakeServer(nil)
+	defer srv.Close()
+	source := fakeClient(t, srv)
 
-	err = output.SaveAsTXT(result, txtPath)
-	if err != nil {
-		t.Logf("TXT 저장 실패: %v", err)
-	}
-	err = output.SaveAsHTML(result, htmlPath)
-	if err != nil {
-		t.Logf("HTML 저장 실패: %v", err)
+	if _, err := source.FetchDraw(context.Background(), 1); !errors.Is(err, fetcher.ErrDrawNotFound) {
+		t.Fatalf("ErrDrawNotFound 기대, 결과 %v", err)
 	}
 
+	fake.Add(fakeDraw(rand.New(rand.NewSource(1)), 1))
+	d, err := source.FetchDraw(context.Background(), 1)
+	if err != nil || d.DrwNo != 1 {
+		t.Fatalf("추가한 회차 조회 실패: %v %+v", err, d)
+	}
 }
@@ -0,0 +1,116 @@
+package test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"lottopredictor/internal/analyzer"
+	"lottopredictor/internal/common"
+	"lottopredictor/internal/config"
+	"lottopredictor/internal/fetcher"
+	"lottopredictor/internal/game"
+	"lottopredictor/internal/syncer"
+)
+
+func TestPrizeDataSyncAndExpectedValue(t *testing.T) {
+	config.AppConfig = config.Config{}
+	dbConn := newSeededDB(t, 0)
+
+	r := rand.New(rand.NewSource(1))
+	draws := []*fetcher.DrawData{}
+	for i := 1; i <= 3; i++ {
+		d := fakeDraw(r, i)
+		d.TotSellamnt = 100000000000
+		d.FirstPrzwnerCo = i - 1 // 1회차는 1등 없음
+		d.FirstWinamnt = int64(i) * 1000000000
+		d.FirstAccumamnt = d.FirstWinamnt * int64(d.FirstPrzwnerCo)
+		draws = append(draws, d)
+	}
+	srv, _ := fetcher.NewFakeServer(draws)
+	defer srv.Close()
+
+	s := syncer.New(dbConn, fakeClient(t, srv), syncer.Options{
+		InitialBackoff:    time.Millisecond,
+		RequestsPerSecond: 1000,
+	})
+	if _, err := s.Run(context.Background()); err != nil {
+		t.Fatalf("동기화 실패: %v", err)
+	}
+
+	d, err := dbConn.GetDraw(context.Background(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.TotalSales != 100000000000 || d.FirstWinners != 2 || d.FirstPrize != 3000000000 || d.FirstTotal != 6000000000 {
+		t.Errorf("당첨금 정보 불일치: %+v", d)
+	}
+
+	// 1등 당첨자가 있던 2, 3회차 평균
+	prizes, err := analyzer.PrizeTable(context.Background(), dbConn, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prizes[common.RankFirst] != 2500000000 || prizes[common.RankFifth] != common.PrizeFifth {
+		t.Errorf("당첨금 표 불일치: %v", prizes)
+	}
+
+	ev := analyzer.ExpectedValue(dbConn.Game(), prizes)
+	if ev <= 0 || ev >= common.TicketPrice {
+		t.Errorf("기대 당첨금 %f", ev)
+	}
+}
+
+func TestPrizeOverrides(t *testing.T) {
+	config.LoadConfig("../config.json")
+	defer func() {
+		config.AppConfig.Prizes = nil
+		config.LoadConfig("../config.json")
+	}()
+
+	// 설정은 게임 id별로 적용되고 다른 게임 당첨금은 바꾸지 않는다
+	config.AppConfig.Prizes = map[string]map[int]int64{"lotto645": {common.RankFirst: 3000000000, common.RankThird: 1500000}}
+	if err := config.CheckPrizes(game.Lotto645); err != nil {
+		t.Fatal(err)
+	}
+	if got := config.PrizeAmount(game.Lotto645, common.RankFirst); got != 3000000000 {
+		t.Errorf("로또 6/45 1등 %d", got)
+	}
+	if got := config.PrizeAmount(game.Lotto645, common.RankThird); got != 1500000 {
+		t.Errorf("로또 6/45 3등 %d", got)
+	}
+	for _, g := range []*game.Game{game.Powerball, game.EuroMillions, game.Lotto649} {
+		if got := config.PrizeAmount(g, common.RankFirst); got != g.Prize(common.RankFirst) {
+			t.Errorf("%s 1등 %d, 기대 %d", g.ID, got, g.Prize(common.RankFirst))
+		}
+	}
+
+	// 게임 정의상 고정 당첨금 등수는 거부하고, 검증을 거치지 않아도 게임 금액을 쓴다
+	for _, c := range []struct {
+		g    *game.Game
+		rank int
+	}{
+		{game.Lotto645, common.RankFourth},
+		{game.Lotto645, common.RankFifth},
+		{game.Powerball, 2},
+		{game.Lotto649, 5},
+	} {
+		config.AppConfig.Prizes = map[string]map[int]int64{c.g.ID: {c.rank: 1}}
+		if err := config.CheckPrizes(c.g); err == nil {
+			t.Errorf("%s %d등 고정 당첨금 덮어쓰기 오류가 없음", c.g.ID, c.rank)
+		}
+		if got := config.PrizeAmount(c.g, c.rank); got != c.g.Prize(c.rank) {
+			t.Errorf("%s %d등 %d, 기대 %d", c.g.ID, c.rank, got, c.g.Prize(c.rank))
+		}
+	}
+	// 유로밀리언은 모든 등수가 변동 당첨금
+	config.AppConfig.Prizes = map[string]map[int]int64{"euromillions": {13: 500}}
+	if err := config.CheckPrizes(game.EuroMillions); err != nil || config.PrizeAmount(game.EuroMillions, 13) != 500 {
+		t.Errorf("유로밀리언 13등 덮어쓰기 %v", err)
+	}
+	config.AppConfig.Prizes = map[string]map[int]int64{"lotto645": {6: 1}}
+	if err := config.CheckPrizes(game.Lotto645); err == nil {
+		t.Error("없는 등수 오류가 없음")
+	}
+}
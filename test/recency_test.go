@@ -0,0 +1,116 @@
+package test
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+
+	"lottopredictor/internal/analyzer"
+	"lottopredictor/internal/config"
+	"lottopredictor/internal/game"
+	"lottopredictor/internal/recency"
+)
+
+func TestRecencyWeighted(t *testing.T) {
+	draws := [][]int{{1, 2, 3, 4, 5, 6}, {1, 2, 3, 4, 5, 7}, {7, 8, 9, 10, 11, 12}}
+	flat, total := recency.Weighted(draws, game.Lotto645.Main, 0)
+	if total != 3 || math.Abs(flat[0]-200.0/3) > 1e-9 || math.Abs(flat[6]-200.0/3) > 1e-9 {
+		t.Errorf("감쇠 없는 확률 불일치: %v (합 %v)", flat[:8], total)
+	}
+	// 반감기 1회: 가중치 0.25, 0.5, 1
+	decayed, total := recency.Weighted(draws, game.Lotto645.Main, 1)
+	if total != 1.75 || math.Abs(decayed[6]-1.5/1.75*100) > 1e-9 || math.Abs(decayed[0]-0.75/1.75*100) > 1e-9 {
+		t.Errorf("감쇠 확률 불일치: %v (합 %v)", decayed[:8], total)
+	}
+
+	m := recency.Compute(draws, game.Lotto645.Main, recency.Options{HalfLife: 1, Horizons: []int{1, 0}})
+	if len(m.Horizons) != 2 || m.Horizons[0].Draws != 1 || m.Horizons[0].Probabilities[0] != 0 || m.Horizons[1].Draws != 3 {
+		t.Errorf("기간별 확률 불일치: %+v", m.Horizons)
+	}
+	if top := m.Top(1); top[0] != 7 {
+		t.Errorf("감쇠 확률 1위는 7번: %v", top)
+	}
+}
+
+// Analyze와 AnalyzeWithDrawNumber(최신 회차)는 같은 통계를 낸다
+func TestRecencyConsistentPaths(t *testing.T) {
+	config.LoadConfig("../config.json")
+	defer config.LoadConfig("../config.json")
+	ctx := context.Background()
+	store := newSeededDB(t, 80)
+	config.AppConfig.LookbackRounds = 5
+	config.AppConfig.StrategyParams = map[string]float64{"half_life": 20}
+
+	latest, err := analyzer.Analyze(ctx, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base, err := analyzer.AnalyzeWithDrawNumber(ctx, store, 80)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest.DrawNumber != 81 || !reflect.DeepEqual(latest.FreqInLast10, base.FreqInLast10) ||
+		!reflect.DeepEqual(latest.Gaps, base.Gaps) || !reflect.DeepEqual(latest.Recency, base.Recency) {
+		t.Errorf("두 경로 통계 불일치: %v / %v", latest.FreqInLast10, base.FreqInLast10)
+	}
+	stats, err := analyzer.ComputeStats(ctx, store, 80)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 최근 5회 목록은 76~80회에 나온 번호만 포함한다
+	recent := map[int]bool{}
+	draws, err := store.ListDraws(ctx, 76, 80)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range draws {
+		for _, n := range d.Numbers {
+			recent[n] = true
+		}
+	}
+	for _, n := range stats.FreqInLast10 {
+		if !recent[n] {
+			t.Errorf("최근 5회에 없는 %d번이 최근 출현 목록에 있음", n)
+		}
+	}
+}
+
+// 기본 통계만 필요한 경로는 검정/추정 분석을 계산하지 않고, 결과 파일 경로는 예측과 같은 분석을 채운다
+func TestStatsAnalysesOnDemand(t *testing.T) {
+	config.LoadConfig("../config.json")
+	ctx := context.Background()
+	store := newSeededDB(t, 60)
+
+	predicted, err := analyzer.AnalyzeWithDrawNumber(ctx, store, 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats, err := analyzer.ComputeStats(ctx, store, 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	basic, err := analyzer.LoadPrediction(ctx, store, 61)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, r := range map[string]*analyzer.PredictionResult{"ComputeStats": stats, "LoadPrediction": basic} {
+		if r.Audit != nil || r.Cooccurrence != nil || r.Bayes != nil || r.Recency != nil {
+			t.Errorf("%s가 분석까지 계산함", name)
+		}
+		if !reflect.DeepEqual(r.Probabilities, predicted.Probabilities) || !reflect.DeepEqual(r.Gaps, predicted.Gaps) {
+			t.Errorf("%s 기본 통계가 예측과 다름", name)
+		}
+	}
+	if !reflect.DeepEqual(basic.SuggestionSets, predicted.SuggestionSets) {
+		t.Errorf("저장된 세트 %v, 기대 %v", basic.SuggestionSets, predicted.SuggestionSets)
+	}
+
+	report, err := analyzer.LoadPredictionReport(ctx, store, 61)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Audit == nil || report.Cooccurrence == nil || !reflect.DeepEqual(report.Bayes, predicted.Bayes) || !reflect.DeepEqual(report.Recency, predicted.Recency) {
+		t.Error("결과 파일용 분석이 예측과 다름")
+	}
+}
@@ -0,0 +1,72 @@
+package test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"lottopredictor/internal/analyzer"
+	"lottopredictor/internal/config"
+	"lottopredictor/internal/portfolio"
+)
+
+func TestSeededPredictionIsReproducible(t *testing.T) {
+	config.LoadConfig("../config.json")
+	defer func() {
+		config.AppConfig.Seed = 0
+		config.AppConfig.Portfolio = portfolio.Options{}
+		config.LoadConfig("../config.json")
+	}()
+	ctx := context.Background()
+	store := newSeededDB(t, 80)
+
+	config.AppConfig.Seed = 12345
+	config.AppConfig.PairAffinity = 0.5
+	config.AppConfig.Portfolio = portfolio.Options{Objective: portfolio.ObjectiveHit, Samples: 2000}
+	first, err := analyzer.AnalyzeWithDrawNumber(ctx, store, 80)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := analyzer.AnalyzeWithDrawNumber(ctx, store, 80)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Seed != 12345 || !reflect.DeepEqual(first.SuggestionSets, second.SuggestionSets) {
+		t.Fatalf("같은 시드인데 세트가 다름 (시드 %d):\n%v\n%v", first.Seed, first.SuggestionSets, second.SuggestionSets)
+	}
+
+	// 설정이 바뀌어도 저장된 시드와 생성 설정으로 같은 세트를 다시 만든다
+	config.AppConfig.PairAffinity = 0
+	config.AppConfig.Portfolio = portfolio.Options{}
+	run, err := store.LatestPredictionRun(ctx, 81)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.Seed != 12345 {
+		t.Fatalf("prediction_meta 시드 %d, 기대 12345", run.Seed)
+	}
+	replay, err := analyzer.ReplayRun(ctx, store, run)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !replay.Identical {
+		t.Errorf("replay 세트 불일치:\n%v\n%v", first.SuggestionSets, replay.Sets)
+	}
+
+	// 시드를 주지 않으면 실행마다 새 시드를 만들어 기록한다
+	config.AppConfig.Seed = 0
+	random, err := analyzer.AnalyzeWithDrawNumber(ctx, store, 80)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if random.Seed == 0 || random.Seed == 12345 {
+		t.Errorf("새 시드가 만들어지지 않음: %d", random.Seed)
+	}
+	run, err = store.LatestPredictionRun(ctx, 81)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replay, err := analyzer.ReplayRun(ctx, store, run); err != nil || !replay.Identical || run.Seed != random.Seed {
+		t.Errorf("새 시드 예측 replay 실패: %v", err)
+	}
+}
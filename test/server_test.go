@@ -0,0 +1,182 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"lottopredictor/internal/analyzer"
+	"lottopredictor/internal/config"
+	"lottopredictor/internal/server"
+)
+
+// getJSON 요청 후 상태 코드를 확인하고 응답 JSON을 v에 채운다.
+func getJSON(t *testing.T, method, url, body string, status int, v any) {
+	t.Helper()
+	req, _ := http.NewRequest(method, url, strings.NewReader(body))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != status {
+		t.Fatalf("%s %s: 상태 %d, 기대 %d", method, url, resp.StatusCode, status)
+	}
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		t.Errorf("%s %s: Content-Type %q", method, url, ct)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatalf("%s %s: JSON 파싱 실패: %v", method, url, err)
+	}
+}
+
+type errorBody struct {
+	Error struct {
+		Status  int    `json:"status"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func TestServerAPI(t *testing.T) {
+	config.LoadConfig("../config.json")
+	srv := httptest.NewServer(server.New(newSeededDB(t, 30)))
+	defer srv.Close()
+
+	var draws struct {
+		LatestDraw int `json:"latest_draw"`
+		Draws      []struct {
+			Number  int   `json:"number"`
+			Numbers []int `json:"numbers"`
+		} `json:"draws"`
+	}
+	getJSON(t, "GET", srv.URL+"/api/draws?limit=5", "", http.StatusOK, &draws)
+	if draws.LatestDraw != 30 || len(draws.Draws) != 5 || draws.Draws[0].Number != 26 || len(draws.Draws[4].Numbers) != 6 {
+		t.Errorf("회차 목록 불일치: %+v", draws)
+	}
+
+	// 오류는 모두 같은 형식
+	for _, c := range []struct {
+		method, path string
+		status       int
+	}{
+		{"GET", "/api/draws/31", http.StatusNotFound},
+		{"GET", "/api/draws/abc", http.StatusBadRequest},
+		{"GET", "/api/predictions/latest", http.StatusNotFound},
+		{"DELETE", "/api/draws", http.StatusMethodNotAllowed},
+		{"GET", "/api/unknown", http.StatusNotFound},
+	} {
+		var e errorBody
+		getJSON(t, c.method, srv.URL+c.path, "", c.status, &e)
+		if e.Error.Status != c.status || e.Error.Message == "" {
+			t.Errorf("%s %s 오류 본문 불일치: %+v", c.method, c.path, e)
+		}
+	}
+
+	var created analyzer.PredictionResult
+	getJSON(t, "POST", srv.URL+"/api/predictions", `{"strategy":"uniform"}`, http.StatusCreated, &created)
+	if created.DrawNumber != 31 || created.Strategy != "uniform" || len(created.SuggestionSets) != config.AppConfig.SuggestionSetCount {
+		t.Errorf("예측 생성 결과 불일치: %d %s %d세트", created.DrawNumber, created.Strategy, len(created.SuggestionSets))
+	}
+	var e errorBody
+	getJSON(t, "POST", srv.URL+"/api/predictions", `{"draw":40}`, http.StatusBadRequest, &e)
+
+	var latest analyzer.PredictionResult
+	getJSON(t, "GET", srv.URL+"/api/predictions/latest", "", http.StatusOK, &latest)
+	if latest.DrawNumber != 31 || len(latest.SuggestionSets) != len(created.SuggestionSets) || latest.SuggestionSets[0][0] != created.SuggestionSets[0][0] {
+		t.Errorf("최근 예측 불일치: %+v", latest.SuggestionSets)
+	}
+
+	var numbers struct {
+		DrawNumber int `json:"draw_number"`
+		Numbers    []struct {
+			Number int `json:"number"`
+			Gap    int `json:"gap"`
+		} `json:"numbers"`
+	}
+	getJSON(t, "GET", srv.URL+"/api/numbers?draw=11", "", http.StatusOK, &numbers)
+	if numbers.DrawNumber != 11 || len(numbers.Numbers) != 45 {
+		t.Errorf("번호별 통계 불일치: %+v", numbers)
+	}
+
+	var evals struct {
+		Runs []struct {
+			DrawNumber int `json:"draw_number"`
+			Summary    struct {
+				Sets      int `json:"sets"`
+				Evaluated int `json:"evaluated"`
+			} `json:"summary"`
+		} `json:"runs"`
+	}
+	getJSON(t, "GET", srv.URL+"/api/evaluations", "", http.StatusOK, &evals)
+	if len(evals.Runs) != 1 || evals.Runs[0].DrawNumber != 31 || evals.Runs[0].Summary.Evaluated != 0 {
+		t.Errorf("평가 목록 불일치: %+v", evals)
+	}
+}
+
+func TestDashboardEmbedded(t *testing.T) {
+	config.LoadConfig("../config.json")
+	srv := httptest.NewServer(server.New(newSeededDB(t, 3)))
+	defer srv.Close()
+
+	for _, path := range []string{"/", "/app.js", "/style.css"} {
+		resp, err := http.Get(srv.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK || len(b) == 0 {
+			t.Fatalf("%s: 상태 %d, %d바이트", path, resp.StatusCode, len(b))
+		}
+		// 오프라인 환경에서도 동작하도록 외부 리소스를 불러오지 않아야 한다
+		for _, external := range []string{`src="http`, `href="http`, "cdn", "@import"} {
+			if strings.Contains(string(b), external) {
+				t.Errorf("%s에 외부 리소스 참조 %q", path, external)
+			}
+		}
+	}
+
+	var strategies struct {
+		Strategies []string `json:"strategies"`
+		Default    string   `json:"default"`
+	}
+	getJSON(t, "GET", srv.URL+"/api/strategies", "", http.StatusOK, &strategies)
+	if len(strategies.Strategies) < 2 || strategies.Default != config.AppConfig.Strategy {
+		t.Errorf("전략 목록 불일치: %+v", strategies)
+	}
+}
+
+func TestServerPredictionParams(t *testing.T) {
+	config.LoadConfig("../config.json")
+	store := newSeededDB(t, 30)
+	srv := httptest.NewServer(server.New(store))
+	defer srv.Close()
+	ctx := context.Background()
+
+	// 전략 없이 보낸 파라미터는 설정의 전략에 적용된다
+	var created analyzer.PredictionResult
+	getJSON(t, "POST", srv.URL+"/api/predictions", `{"params":{"half_life":20}}`, http.StatusCreated, &created)
+	run, err := store.LatestPredictionRun(ctx, 31)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var params map[string]float64
+	if err := json.Unmarshal([]byte(run.StrategyParams), &params); err != nil {
+		t.Fatal(err)
+	}
+	if run.Strategy != config.AppConfig.Strategy || params["half_life"] != 20 {
+		t.Errorf("설정 전략 파라미터 불일치: %s %s", run.Strategy, run.StrategyParams)
+	}
+
+	getJSON(t, "POST", srv.URL+"/api/predictions", `{"strategy":"markov","params":{"order":2}}`, http.StatusCreated, &created)
+	if run, err = store.LatestPredictionRun(ctx, 31); err != nil {
+		t.Fatal(err)
+	}
+	if run.Strategy != "markov" || !strings.Contains(run.StrategyParams, `"order":2`) {
+		t.Errorf("요청 전략 파라미터 불일치: %s %s", run.Strategy, run.StrategyParams)
+	}
+}
@@ -0,0 +1,112 @@
+package test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"lottopredictor/internal/common"
+	"lottopredictor/internal/simulate"
+)
+
+func TestSimulateUniform(t *testing.T) {
+	opts := simulate.Options{Model: simulate.ModelUniform, Trials: 400000, Workers: 4, Seed: 1, Prizes: common.DefaultPrizes}
+	tickets := [][]int{{3, 11, 19, 27, 35, 43}}
+	r, err := simulate.Run(tickets, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 5등 빈도는 이론 확률의 5 표준편차 안
+	p := float64(common.RankCombinations[common.RankFifth]) / common.TotalCombinations
+	n := float64(opts.Trials)
+	if got := float64(r.RankCounts[common.RankFifth]); math.Abs(got-n*p) > 5*math.Sqrt(n*p*(1-p)) {
+		t.Errorf("5등 %v회, 기대 %.0f회", got, n*p)
+	}
+	// 세트 1개, 1회차면 5등 이상이 곧 손익분기
+	won := int64(0)
+	for rank := common.RankFirst; rank <= common.RankFifth; rank++ {
+		won += r.RankCounts[rank]
+	}
+	if r.Cost != common.TicketPrice || r.BreakEven != float64(won)/n {
+		t.Errorf("손익분기 확률 %v, 기대 %v", r.BreakEven, float64(won)/n)
+	}
+	if r.LossPerTicket <= 0 || r.LossPerTicket > common.TicketPrice {
+		t.Errorf("1게임당 기대 손실 %v", r.LossPerTicket)
+	}
+	checkHistogram(t, r)
+
+	// 같은 시드와 작업 수면 같은 결과
+	again, err := simulate.Run(tickets, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(r, again) {
+		t.Error("같은 시드인데 결과가 다름")
+	}
+}
+
+func TestSimulateFitted(t *testing.T) {
+	// 1~7번만 사실상 나오는 모델이면 1~6번 세트는 거의 항상 1, 2등
+	weights := make([]float64, common.MaxLottoNum)
+	for i := range weights {
+		weights[i] = 1e-9
+		if i < 7 {
+			weights[i] = 1
+		}
+	}
+	r, err := simulate.Run([][]int{{1, 2, 3, 4, 5, 6}}, simulate.Options{Model: simulate.ModelFitted, Weights: weights, Trials: 2000, Rounds: 2, Seed: 3, Prizes: common.DefaultPrizes})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if top := r.RankCounts[common.RankFirst] + r.RankCounts[common.RankSecond]; top < 3900 {
+		t.Errorf("1, 2등 %d회, 기대 4,000회 가까이", top)
+	}
+	if r.BreakEven < 0.99 || r.LossPerTicket >= 0 {
+		t.Errorf("손익분기 %v, 1게임당 손실 %v", r.BreakEven, r.LossPerTicket)
+	}
+	checkHistogram(t, r)
+
+	if _, err := simulate.Run([][]int{{1, 2, 3, 4, 5, 6}}, simulate.Options{Model: simulate.ModelFitted, Weights: weights[:10]}); err == nil {
+		t.Error("가중치 개수 오류가 없음")
+	}
+	if _, err := simulate.Run([][]int{{1, 2, 3, 4, 5, 5}}, simulate.Options{Model: simulate.ModelUniform}); err == nil {
+		t.Error("중복 번호 세트 오류가 없음")
+	}
+}
+
+// checkHistogram 배수 구간이 겹치지 않고 모든 결과를 한 번씩 담아 비율 합이 1인지 확인
+func checkHistogram(t *testing.T, r *simulate.Result) {
+	t.Helper()
+	buckets := r.Histogram(simulate.DefaultEdges)
+	if !buckets[0].ZeroOnly || buckets[len(buckets)-1].To != 0 {
+		t.Fatalf("구간 양 끝 %+v", buckets)
+	}
+	for i := 2; i < len(buckets); i++ {
+		if buckets[i].From != buckets[i-1].To {
+			t.Errorf("구간 %d 경계가 이어지지 않음: %+v", i, buckets)
+		}
+	}
+	ratios := []float64{0, 1e-9, 0.5, 1, 99.9, 100, 1e9}
+	for _, o := range r.Distribution {
+		ratios = append(ratios, float64(o.Return)/float64(r.Cost))
+	}
+	for _, ratio := range ratios {
+		n := 0
+		for _, b := range buckets {
+			if b.Contains(ratio) {
+				n++
+			}
+		}
+		if n != 1 {
+			t.Errorf("배수 %g가 구간 %d개에 속함", ratio, n)
+		}
+	}
+	total := 0.0
+	for _, b := range buckets {
+		total += b.Probability
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Errorf("분포 합 %v", total)
+	}
+}
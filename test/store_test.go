@@ -0,0 +1,74 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"lottopredictor/internal/db"
+)
+
+func TestStorePredictionRun(t *testing.T) {
+	ctx := context.Background()
+	store := newSeededDB(t, 3)
+
+	if _, err := store.GetDraw(ctx, 4); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("ErrNotFound 기대, 결과 %v", err)
+	}
+	if _, err := store.LatestPredictionRun(ctx, 4); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("ErrNotFound 기대, 결과 %v", err)
+	}
+
+	for i := 1; i <= 2; i++ {
+		run := &db.PredictionRun{
+			DrawNumber: 4,
+			Strategy:   "uniform",
+			Sets:       []db.PredictionSet{{Numbers: []int{1, 2, 3, 4, 5, 6}}, {Numbers: []int{7, 8, 9, 10, 11, 12}}},
+		}
+		idx, err := store.SavePredictionRun(ctx, run)
+		if err != nil || idx != i {
+			t.Fatalf("저장 idx %d, 기대 %d (%v)", idx, i, err)
+		}
+	}
+
+	run, err := store.LatestPredictionRun(ctx, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.Idx != 2 || run.Strategy != "uniform" || len(run.Sets) != 2 || run.Sets[1].Numbers[5] != 12 {
+		t.Errorf("예측 불일치: %+v", run)
+	}
+	if run.Sets[0].Evaluation != nil {
+		t.Errorf("평가 전 세트에 평가 결과 있음: %+v", run.Sets[0].Evaluation)
+	}
+}
+
+func TestStoreRollback(t *testing.T) {
+	ctx := context.Background()
+	store := newSeededDB(t, 0)
+
+	// 두 번째 세트가 잘못되면 메타와 첫 세트도 저장되지 않아야 한다
+	run := &db.PredictionRun{
+		DrawNumber: 1,
+		Sets:       []db.PredictionSet{{Numbers: []int{1, 2, 3, 4, 5, 6}}, {Numbers: []int{1, 2, 3}}},
+	}
+	if _, err := store.SavePredictionRun(ctx, run); err == nil {
+		t.Fatal("잘못된 세트인데 저장 성공")
+	}
+	for _, table := range []string{"prediction_meta", "prediction_results"} {
+		if n, err := store.CountRows(ctx, table); err != nil || n != 0 {
+			t.Errorf("%s %d행 남음 (%v)", table, n, err)
+		}
+	}
+
+	draws := []db.Draw{
+		{Number: 1, Numbers: []int{1, 2, 3, 4, 5, 6}, Bonus: 7},
+		{Number: 2, Numbers: []int{1, 2}, Bonus: 7},
+	}
+	if err := store.SaveDraws(ctx, draws); err == nil {
+		t.Fatal("잘못된 회차인데 저장 성공")
+	}
+	if latest, err := store.LatestDrawNumber(ctx); err != nil || latest != 0 {
+		t.Errorf("최신 회차 %d (%v), 롤백 기대", latest, err)
+	}
+}
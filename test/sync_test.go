@@ -0,0 +1,171 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"lottopredictor/internal/db"
+	"lottopredictor/internal/fetcher"
+	"lottopredictor/internal/game"
+	"lottopredictor/internal/syncer"
+)
+
+func TestSyncRetryAndGapRepair(t *testing.T) {
+	dbConn := newSeededDB(t, 10)
+	dbConn.DB().Exec("DELETE FROM lotto_results WHERE draw_number IN (4, 7)")
+
+	r := rand.New(rand.NewSource(1))
+	draws := []*fetcher.DrawData{}
+	for i := 1; i <= 12; i++ {
+		draws = append(draws, fakeDraw(r, i))
+	}
+	fake := fetcher.NewFakeHandler(draws)
+
+	// 처음 두 요청은 서버 오류로 응답
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= 2 {
+			http.Error(w, "temporary", http.StatusServiceUnavailable)
+			return
+		}
+		fake.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	s := syncer.New(dbConn, fakeClient(t, srv), syncer.Options{
+		MaxRetries:        3,
+		InitialBackoff:    time.Millisecond,
+		RequestsPerSecond: 1000,
+		RepairGaps:        true,
+	})
+	result, err := s.Run(context.Background())
+	if err != nil {
+		t.Fatalf("동기화 실패: %v", err)
+	}
+
+	if len(result.Repaired) != 2 || len(result.Added) != 2 || result.LatestDraw != 12 {
+		t.Fatalf("복구 %v, 추가 %v, 최신 %d", result.Repaired, result.Added, result.LatestDraw)
+	}
+	if missing, _ := dbConn.FindMissingDraws(context.Background()); len(missing) != 0 {
+		t.Errorf("남은 빈 회차 %v", missing)
+	}
+
+	runs, err := dbConn.RecentSyncRuns(context.Background(), 1)
+	if err != nil || len(runs) != 1 {
+		t.Fatalf("sync_runs 조회 실패: %v", err)
+	}
+	if runs[0].Status != db.SyncStatusSuccess || runs[0].DrawsAdded != 2 || runs[0].DrawsRepaired != 2 {
+		t.Errorf("sync_runs 기록 불일치: %+v", runs[0])
+	}
+}
+
+func TestSyncNetworkErrorIsNotEndOfDraws(t *testing.T) {
+	dbConn := newSeededDB(t, 3)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	s := syncer.New(dbConn, fakeClient(t, srv), syncer.Options{
+		MaxRetries:        2,
+		InitialBackoff:    time.Millisecond,
+		RequestsPerSecond: 1000,
+	})
+	if _, err := s.Run(context.Background()); err == nil {
+		t.Fatal("네트워크 오류가 동기화 종료로 처리됨")
+	}
+
+	runs, _ := dbConn.RecentSyncRuns(context.Background(), 1)
+	if len(runs) != 1 || runs[0].Status != db.SyncStatusFailed || len(runs[0].Errors) == 0 {
+		t.Errorf("실패 기록 불일치: %+v", runs)
+	}
+}
+
+func TestSyncRejectsInvalidDraw(t *testing.T) {
+	dbConn := newSeededDB(t, 3)
+
+	r := rand.New(rand.NewSource(1))
+	draws := []*fetcher.DrawData{}
+	for i := 1; i <= 5; i++ {
+		draws = append(draws, fakeDraw(r, i))
+	}
+	draws[3].BnusNo = draws[3].DrwtNo1 // 4회차 보너스가 당첨 번호와 중복
+	srv, _ := fetcher.NewFakeServer(draws)
+	defer srv.Close()
+
+	s := syncer.New(dbConn, fakeClient(t, srv), syncer.Options{
+		InitialBackoff:    time.Millisecond,
+		RequestsPerSecond: 1000,
+	})
+	result, err := s.Run(context.Background())
+	if err == nil {
+		t.Fatal("잘못된 응답이 저장됨")
+	}
+	if len(result.Added) != 0 || result.LatestDraw != 3 {
+		t.Errorf("추가 %v, 최신 %d", result.Added, result.LatestDraw)
+	}
+	runs, _ := dbConn.RecentSyncRuns(context.Background(), 1)
+	if len(runs) != 1 || runs[0].Status != db.SyncStatusFailed || len(runs[0].Errors) == 0 {
+		t.Errorf("실패 기록 불일치: %+v", runs)
+	}
+}
+
+func TestSyncGame(t *testing.T) {
+	ctx := context.Background()
+	store, err := db.OpenStore(filepath.Join(t.TempDir(), "powerball.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	if err := store.BindGame(ctx, "powerball"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := fetcher.NewClient(game.Powerball, "", nil); err == nil {
+		t.Error("동행복권 API로 파워볼 클라이언트를 만들 때 오류가 없음")
+	}
+	draws := []*fetcher.DrawData{}
+	for i := 1; i <= 2; i++ {
+		d := &fetcher.DrawData{DrwNo: i, DrwNoDate: fmt.Sprintf("2024-01-%02d", i), BnusNos: []int{i}}
+		d.SetNumbers([]int{60 + i, 2, 3, 4, 5})
+		draws = append(draws, d)
+	}
+	srv, _ := fetcher.NewFakeServer(draws)
+	defer srv.Close()
+	opts := syncer.Options{InitialBackoff: time.Millisecond, RequestsPerSecond: 1000}
+
+	// 로또 6/45 소스로는 파워볼 DB를 동기화하지 않고 실패로 기록한다
+	if _, err := syncer.New(store, fakeClient(t, srv), opts).Run(ctx); err == nil {
+		t.Error("게임이 다른 소스로 동기화됨")
+	}
+	source, err := fetcher.NewClient(game.Powerball, srv.URL+"/common.do", srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := syncer.New(store, source, opts).Run(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Added) != 2 || result.LatestDraw != 2 {
+		t.Errorf("파워볼 동기화 결과 %+v", result)
+	}
+	d, err := store.GetDraw(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Numbers) != 5 || d.Numbers[0] != 62 || len(d.BonusNumbers) != 1 || d.BonusNumbers[0] != 2 {
+		t.Errorf("파워볼 회차 %+v", d)
+	}
+	runs, _ := store.RecentSyncRuns(ctx, 2)
+	if len(runs) != 2 || runs[1].Status != db.SyncStatusFailed {
+		t.Errorf("동기화 기록 %+v", runs)
+	}
+}
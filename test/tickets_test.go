@@ -0,0 +1,175 @@
+package test
+
+import (
+	"context"
+	"math/rand"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"lottopredictor/internal/common"
+	"lottopredictor/internal/config"
+	"lottopredictor/internal/db"
+	"lottopredictor/internal/game"
+	"lottopredictor/internal/tickets"
+)
+
+func TestTickets(t *testing.T) {
+	config.LoadConfig("../config.json")
+	ctx := context.Background()
+	store := newSeededDB(t, 10)
+	g := store.Game()
+
+	d, err := store.GetDraw(ctx, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 당첨 번호, 보너스와 겹치지 않는 번호
+	misses := []int{}
+	for n := 1; len(misses) < common.SetSize; n++ {
+		if !slices.Contains(d.Numbers, n) && n != d.Bonus {
+			misses = append(misses, n)
+		}
+	}
+	second := append(append([]int{}, d.Numbers[:5]...), d.Bonus)
+	fifth := append(append([]int{}, d.Numbers[:3]...), misses[:3]...)
+
+	list := []db.Ticket{
+		{DrawNumber: 10, Buyer: "alice", Numbers: second},
+		{DrawNumber: 10, Buyer: "alice", Numbers: fifth, Cost: 2000},
+		{DrawNumber: 10, Buyer: "bob", Strategy: "hot", Numbers: misses},
+		{DrawNumber: 11, Buyer: "bob", Strategy: "hot", Numbers: misses},
+	}
+	if err := tickets.Add(ctx, store, list); err != nil {
+		t.Fatal(err)
+	}
+	if list[0].ID == 0 || list[0].Strategy != tickets.StrategyManual || list[0].Cost != g.TicketPrice {
+		t.Errorf("기본값이 채워지지 않음: %+v", list[0])
+	}
+	for _, bad := range []db.Ticket{
+		{DrawNumber: 10, Numbers: misses},
+		{DrawNumber: 10, Buyer: "carol", Numbers: []int{1, 2, 3, 4, 5, 5}},
+		{DrawNumber: 10, Buyer: "carol", Numbers: []int{1, 2, 3, 4, 5}},
+	} {
+		if err := tickets.Add(ctx, store, []db.Ticket{bad}); err == nil {
+			t.Errorf("잘못된 티켓 %+v 오류가 없음", bad)
+		}
+	}
+
+	// 당첨 번호가 있는 회차만 확인된다
+	summaries, err := tickets.CheckPending(ctx, store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summaries) != 1 || summaries[0].DrawNumber != 10 || summaries[0].Tickets != 3 || len(summaries[0].Winners) != 2 {
+		t.Fatalf("확인 요약 %+v", summaries)
+	}
+	prize2, prize5 := config.PrizeAmount(g, common.RankSecond), config.PrizeAmount(g, common.RankFifth)
+	if s := summaries[0]; s.RankCounts[common.RankSecond] != 1 || s.RankCounts[common.RankFifth] != 1 || s.Prize != prize2+prize5 {
+		t.Errorf("회차 10 요약 %+v", s)
+	}
+	pending, err := store.ListTickets(ctx, db.TicketFilter{Pending: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || pending[0].DrawNumber != 11 || pending[0].Check != nil {
+		t.Fatalf("확인 전 티켓 %+v", pending)
+	}
+	alice, err := store.ListTickets(ctx, db.TicketFilter{Buyer: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tk := range alice {
+		m := g.Rank(tk.Numbers, tk.Bonus, d.Numbers, d.Bonus, d.BonusNumbers)
+		if c := tk.Check; c == nil || c.Rank != m.Rank || c.Matched != m.Main || c.BonusMatched != m.Extra {
+			t.Errorf("티켓 %v 확인 %+v, 기대 %+v", tk.Numbers, c, m)
+		}
+	}
+
+	// 다음 회차가 저장되면 남은 티켓도 확인된다
+	next := db.DrawFromData(fakeDraw(rand.New(rand.NewSource(2)), 11))
+	if err := store.SaveDraws(ctx, []db.Draw{next}); err != nil {
+		t.Fatal(err)
+	}
+	if summaries, err = tickets.CheckPending(ctx, store); err != nil || len(summaries) != 1 || summaries[0].DrawNumber != 11 {
+		t.Fatalf("회차 11 확인 %+v, %v", summaries, err)
+	}
+	if draws, _ := store.PendingTicketDraws(ctx); len(draws) != 0 {
+		t.Errorf("확인 대기 회차 남음: %v", draws)
+	}
+
+	byBuyer, err := store.TicketTotals(ctx, "buyer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(byBuyer) != 2 || byBuyer[0].Key != "alice" || byBuyer[1].Key != "bob" {
+		t.Fatalf("구매자별 합계 %+v", byBuyer)
+	}
+	a := byBuyer[0]
+	if a.Tickets != 2 || a.Spend != g.TicketPrice+2000 || a.Winnings != prize2+prize5 || a.Net() != a.Winnings-a.Spend {
+		t.Errorf("alice 합계 %+v", a)
+	}
+	if want := float64(a.Net()) / float64(a.Spend); a.ROI() != want {
+		t.Errorf("alice ROI %v, 기대 %v", a.ROI(), want)
+	}
+	byStrategy, err := store.TicketTotals(ctx, "strategy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(byStrategy) != 2 || byStrategy[0].Key != "hot" || byStrategy[0].Tickets != 2 || byStrategy[1].Key != tickets.StrategyManual {
+		t.Errorf("전략별 합계 %+v", byStrategy)
+	}
+	if _, err := store.TicketTotals(ctx, "cost"); err == nil {
+		t.Error("알 수 없는 합계 기준 오류가 없음")
+	}
+
+	// 1등은 회차의 실제 1인당 당첨금을 쓴다
+	if got := tickets.Prize(g, &db.Draw{FirstPrize: 123})(common.RankFirst); got != 123 {
+		t.Errorf("1등 당첨금 %d, 기대 123", got)
+	}
+}
+
+func TestTicketsPowerball(t *testing.T) {
+	config.LoadConfig("../config.json")
+	ctx := context.Background()
+	store, err := db.OpenStore(filepath.Join(t.TempDir(), "powerball.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	if err := store.BindGame(ctx, "powerball"); err != nil {
+		t.Fatal(err)
+	}
+	draw := db.Draw{Number: 1, Date: "2024-01-01", Numbers: []int{1, 2, 3, 4, 5}, BonusNumbers: []int{7}}
+	if err := store.SaveDraws(ctx, []db.Draw{draw}); err != nil {
+		t.Fatal(err)
+	}
+
+	list := []db.Ticket{
+		{DrawNumber: 1, Buyer: "alice", Numbers: []int{50, 40, 30, 20, 10}, Bonus: []int{7}},
+		{DrawNumber: 1, Buyer: "alice", Numbers: []int{1, 2, 3, 4, 5}, Bonus: []int{8}},
+	}
+	if err := tickets.Add(ctx, store, list); err != nil {
+		t.Fatal(err)
+	}
+	if err := tickets.Add(ctx, store, []db.Ticket{{DrawNumber: 1, Buyer: "alice", Numbers: []int{1, 2, 3, 4, 5}}}); err == nil {
+		t.Error("보너스 풀 번호 누락 오류가 없음")
+	}
+	s, err := tickets.Check(ctx, store, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.RankCounts[9] != 1 || s.RankCounts[2] != 1 || s.Cost != 2*game.Powerball.TicketPrice {
+		t.Errorf("파워볼 확인 요약 %+v", s)
+	}
+	saved, err := store.ListTickets(ctx, db.TicketFilter{DrawNumber: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved[0].Numbers[0] != 10 || saved[0].Check.BonusMatches != 1 {
+		t.Errorf("저장된 티켓 %+v", saved[0])
+	}
+	if _, err := tickets.Check(ctx, store, 2); err == nil {
+		t.Error("당첨 번호 없는 회차 확인 오류가 없음")
+	}
+}
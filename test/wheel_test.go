@@ -0,0 +1,98 @@
+package test
+
+import (
+	"testing"
+
+	"lottopredictor/internal/common"
+	"lottopredictor/internal/game"
+	"lottopredictor/internal/wheel"
+)
+
+func TestAbbreviatedWheel(t *testing.T) {
+	pool := []int{3, 8, 11, 17, 20, 24, 29, 31, 36, 40, 42, 45}
+	for _, g := range []wheel.Guarantee{{Match: 4, Hit: 3}, {Match: 5, Hit: 4}, {Match: 3, Hit: 3}, {Match: 6, Hit: 5}} {
+		w, err := wheel.Abbreviated(game.Lotto645, pool, g, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		full := int(common.Binomial(len(pool), common.SetSize))
+		if len(w.Tickets) == 0 || len(w.Tickets) >= full {
+			t.Errorf("%v: 세트 %d개 (완전 휠 %d개)", g, len(w.Tickets), full)
+		}
+		if got := wheel.Verify(pool, w.Tickets, g.Match); got < g.Hit {
+			t.Errorf("%v: 보장 %d개 일치, 기대 %d개", g, got, g.Hit)
+		}
+		// 세트를 하나라도 빼면 보장이 깨져야 한다 (불필요한 세트 없음)
+		for i := range w.Tickets {
+			rest := append(append([][]int{}, w.Tickets[:i]...), w.Tickets[i+1:]...)
+			if wheel.Verify(pool, rest, g.Match) >= g.Hit {
+				t.Errorf("%v: 세트 %v 없이도 보장 유지", g, w.Tickets[i])
+			}
+		}
+		if w.Cost() != int64(len(w.Tickets))*common.TicketPrice {
+			t.Errorf("구매 비용 %d", w.Cost())
+		}
+	}
+
+	if _, err := wheel.Abbreviated(game.Lotto645, pool, wheel.Guarantee{Match: 3, Hit: 4}, nil); err == nil {
+		t.Error("일치 개수가 풀 당첨 번호보다 큰데 성공")
+	}
+	if _, err := wheel.Abbreviated(game.Lotto645, []int{1, 2, 3, 4, 5, 6}, wheel.Guarantee{Match: 4, Hit: 3}, nil); err == nil {
+		t.Error("풀이 너무 작은데 성공")
+	}
+	if _, err := wheel.Abbreviated(game.Lotto645, []int{1, 2, 3, 4, 5, 6, 46}, wheel.Guarantee{Match: 4, Hit: 3}, nil); err == nil {
+		t.Error("풀 번호가 게임 범위를 벗어났는데 성공")
+	}
+}
+
+func TestWheelGame(t *testing.T) {
+	// 6/49는 45보다 큰 번호를 쓸 수 있고, 비용은 게임 티켓 가격·통화로 계산한다.
+	pool := []int{2, 9, 14, 21, 27, 33, 38, 44, 46, 49}
+	w, err := wheel.Abbreviated(game.Lotto649, pool, wheel.Guarantee{Match: 4, Hit: 3}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Game != game.Lotto649.ID {
+		t.Errorf("휠 게임 %q", w.Game)
+	}
+	if w.Cost() != int64(len(w.Tickets))*game.Lotto649.TicketPrice {
+		t.Errorf("구매 비용 %d", w.Cost())
+	}
+	if got, want := w.FormatCost(), game.Lotto649.FormatMoney(w.Cost()); got != want {
+		t.Errorf("구매 비용 표시 %q, 기대 %q", got, want)
+	}
+	// 10개 풀에 당첨 번호 6개가 모두 들어올 확률 = C(10,6) / C(49,6)
+	if p := w.PoolHitProbability(6); p != 210.0/float64(common.Binomial(49, 6)) {
+		t.Errorf("풀 확률 %g", p)
+	}
+
+	// 구매자가 보너스 풀 번호도 고르는 게임은 보장을 계산할 수 없어 거부한다.
+	for _, g := range []*game.Game{game.Powerball, game.EuroMillions} {
+		if err := wheel.Supports(g); err == nil {
+			t.Errorf("%s 휠을 지원한다고 함", g.ID)
+		}
+		if _, err := wheel.FullWheel(g, []int{1, 2, 3, 4, 5, 6, 7}); err == nil {
+			t.Errorf("%s 완전 휠이 성공", g.ID)
+		}
+	}
+}
+
+func TestFullWheel(t *testing.T) {
+	pool := []int{1, 5, 9, 13, 17, 21, 25, 29, 33, 37}
+	w, err := wheel.FullWheel(game.Lotto645, pool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(w.Tickets) != 210 {
+		t.Errorf("완전 휠 세트 %d개, 기대 210개", len(w.Tickets))
+	}
+	for _, l := range w.Levels() {
+		if l.MinMatched != l.InPool {
+			t.Errorf("완전 휠 보장 불일치: %+v", l)
+		}
+	}
+	// 10개 풀에 당첨 번호 6개가 모두 들어올 확률 = C(10,6) / C(45,6)
+	if p := w.PoolHitProbability(6); p != 210.0/common.TotalCombinations {
+		t.Errorf("풀 확률 %g", p)
+	}
+}